@@ -0,0 +1,191 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// viewportItem is implemented by form items which need to know the screen rows
+// in which the form shows items, e.g. to place popups.
+type viewportItem interface {
+	// setViewport sets the first and the last (exclusive) screen row in which
+	// the form shows items.
+	setViewport(top, bottom int)
+}
+
+// suggestionList tracks whether the suggestion list of a tview input field is
+// shown, which the input field doesn't reveal.
+type suggestionList struct {
+	shown bool
+}
+
+// suggested records the suggestions the input field's autocomplete function
+// returned and returns them. The list is shown if there are any.
+func (l *suggestionList) suggested(entries []string) []string {
+	l.shown = len(entries) > 0
+	return entries
+}
+
+// processed records that the input field processed the given key. Escape
+// hides the list, the other keys which end it select a suggestion or leave the
+// field.
+func (l *suggestionList) processed(key tcell.Key) {
+	switch key {
+	case tcell.KeyEscape, tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab:
+		l.shown = false
+	}
+}
+
+// usesKey returns whether the input field uses the given key itself: Home and
+// End move the cursor, Up, Down, PgUp, and PgDn select a suggestion while the
+// list is shown.
+func (l *suggestionList) usesKey(key tcell.Key) bool {
+	switch key {
+	case tcell.KeyHome, tcell.KeyEnd:
+		return true
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+		return l.shown
+	}
+	return false
+}
+
+// AutocompleteField is an input field whose suggestions are provided by a
+// function (see tview.InputField.SetAutocompleteFunc). Unlike plain input
+// fields, it places the suggestions above the field if they don't fit into
+// the visible part of its form below it (e.g. when the field was scrolled to
+// the bottom of the form) and there is more space above.
+type AutocompleteField struct {
+	*tview.InputField
+
+	// The function which returns the suggestions for the current text and the
+	// number of suggestions it returned last.
+	complete func(current string) []string
+	entries  int
+
+	// Whether the suggestions are shown.
+	list suggestionList
+
+	// The first and the last (exclusive) screen row in which the form shows
+	// items. Both are 0 if the field is not part of a form.
+	viewTop, viewBottom int
+}
+
+var (
+	_ tview.FormItem = (*AutocompleteField)(nil)
+	_ viewportItem   = (*AutocompleteField)(nil)
+)
+
+// NewAutocompleteField returns a new input field which suggests the entries
+// returned by the given function for the current text. The entries are shown
+// as they are (they are not interpreted as style tags).
+func NewAutocompleteField(complete func(current string) []string) *AutocompleteField {
+	a := &AutocompleteField{
+		InputField: tview.NewInputField(),
+		complete:   complete,
+	}
+	a.SetAutocompleteFunc(a.suggestions)
+	return a
+}
+
+// suggestions returns the escaped suggestions for the given text and remembers
+// their number.
+func (a *AutocompleteField) suggestions(current string) []string {
+	if a.complete == nil {
+		a.entries = 0
+		return a.list.suggested(nil)
+	}
+	entries := a.complete(current)
+	escaped := make([]string, len(entries))
+	for index, entry := range entries {
+		escaped[index] = tview.Escape(entry)
+	}
+	a.entries = len(escaped)
+	return a.list.suggested(escaped)
+}
+
+// UsesKey returns whether the field uses the given key itself, e.g. Up and
+// Down while suggestions are shown.
+func (a *AutocompleteField) UsesKey(key tcell.Key) bool {
+	return a.list.usesKey(key)
+}
+
+// SubmitsOnEnter returns true: Enter in the field submits the form like in
+// an input field (see SetSubmitFunc) unless it picks a suggestion.
+func (a *AutocompleteField) SubmitsOnEnter() bool {
+	return true
+}
+
+// InputHandler returns the handler for this primitive.
+func (a *AutocompleteField) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return a.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		a.InputField.InputHandler()(event, setFocus)
+		a.list.processed(event.Key())
+	})
+}
+
+// Blur is called when this primitive loses focus.
+func (a *AutocompleteField) Blur() {
+	a.list.shown = false
+	a.InputField.Blur()
+}
+
+// setViewport sets the first and the last (exclusive) screen row in which the
+// form shows items.
+func (a *AutocompleteField) setViewport(top, bottom int) {
+	a.viewTop, a.viewBottom = top, bottom
+}
+
+// Draw draws this primitive onto the screen.
+func (a *AutocompleteField) Draw(screen tcell.Screen) {
+	a.InputField.Draw(a.placementScreen(screen))
+}
+
+// placementScreen returns the screen the input field is drawn on. The input
+// field shows its suggestions above itself if they reach the bottom of the
+// screen and there is more space above, so the returned screen reports the
+// field's row as its height when the suggestions should be shown above.
+func (a *AutocompleteField) placementScreen(screen tcell.Screen) tcell.Screen {
+	if a.viewBottom <= a.viewTop || a.entries == 0 {
+		return screen
+	}
+	_, y, _, _ := a.GetInnerRect()
+	below := a.viewBottom - y - 1
+	above := y - a.viewTop
+	if a.entries <= below || above <= below {
+		return screen
+	}
+
+	// The input field only shows suggestions above if (with ly being the row
+	// below the field) ly-2 > entries-ly.
+	if ly := y + 1; ly-2 <= a.entries-ly {
+		return screen
+	}
+	return &sizedScreen{Screen: screen, height: y}
+}
+
+// sizedScreen is a screen which reports a different height.
+type sizedScreen struct {
+	tcell.Screen
+	height int
+}
+
+// Size returns the screen's width and the reported height.
+func (s *sizedScreen) Size() (width, height int) {
+	width, _ = s.Screen.Size()
+	return width, s.height
+}
+
+// AddAutocompleteField adds an input field to the form which suggests the
+// entries returned by the "complete" function for the current text (see
+// AutocompleteField). A field width of 0 means the field takes all available
+// space. The optional "changed" function is called with the text whenever it
+// changes.
+func (f *FormScrollable) AddAutocompleteField(label, value string, fieldWidth int, complete func(current string) []string, changed func(text string)) *FormScrollable {
+	field := NewAutocompleteField(complete)
+	field.SetLabel(label).
+		SetText(value).
+		SetFieldWidth(fieldWidth).
+		SetChangedFunc(changed)
+	f.addItem(field)
+	return f
+}
@@ -0,0 +1,110 @@
+package form
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/rivo/tview"
+)
+
+// defaultMaxRedrawRate is the default number of redraws per second the form
+// triggers in low-bandwidth mode.
+const defaultMaxRedrawRate = 5
+
+// redrawLimiter holds the settings of the low-bandwidth mode and coalesces the
+// redraws the form triggers on its own in this mode. It is used from several
+// goroutines.
+type redrawLimiter struct {
+	mutex sync.Mutex
+
+	// Whether the form is in low-bandwidth mode and the minimum time between
+	// two redraws in this mode.
+	enabled  bool
+	interval time.Duration
+
+	// Whether a redraw is scheduled and when the last one was requested.
+	pending bool
+	last    time.Time
+}
+
+// SetLowBandwidthMode sets whether the form keeps the amount of data sent to
+// the terminal low, e.g. when it is used over a slow SSH connection. In this
+// mode, the loading spinner is not animated, the form doesn't keep scrolling
+// after dragging it (see SetDragScrolling), the field of loading items is not
+// filled, and the redraws the form triggers on its own (e.g. when notices
+// expire or items finished loading) are coalesced so that there are no more
+// than the given maximum redraw rate (see SetMaxRedrawRate).
+func (f *FormScrollable) SetLowBandwidthMode(enabled bool) *FormScrollable {
+	f.redraws.mutex.Lock()
+	f.redraws.enabled = enabled
+	f.redraws.mutex.Unlock()
+	if enabled {
+		f.stopMomentum()
+		f.stopLoadingAnimation()
+	} else if f.isLoading() {
+		f.startLoadingAnimation()
+	}
+	return f
+}
+
+// IsLowBandwidthMode returns whether the form is in low-bandwidth mode (see
+// SetLowBandwidthMode).
+func (f *FormScrollable) IsLowBandwidthMode() bool {
+	f.redraws.mutex.Lock()
+	defer f.redraws.mutex.Unlock()
+	return f.redraws.enabled
+}
+
+// SetMaxRedrawRate sets the maximum number of redraws per second the form
+// triggers on its own in low-bandwidth mode (see SetLowBandwidthMode). The
+// default is 5. Values less than 1 are treated as 1.
+func (f *FormScrollable) SetMaxRedrawRate(fps int) *FormScrollable {
+	f.redraws.mutex.Lock()
+	f.redraws.interval = time.Second / time.Duration(max(fps, 1))
+	f.redraws.mutex.Unlock()
+	return f
+}
+
+// queueUpdateDraw runs the given function on the application's goroutine and
+// redraws the application afterwards. The application must have been set. In
+// low-bandwidth mode, redraws are delayed so that they don't exceed the
+// maximum redraw rate, and redraws requested in the meantime are coalesced.
+// With a redraw coordinator (see SetRedrawCoordinator), the coordinator
+// limits the redraws instead. It may be called from any goroutine.
+func (f *FormScrollable) queueUpdateDraw(update func()) {
+	if coordinator := f.coordinator; coordinator != nil {
+		coordinator.QueueUpdateDraw(update)
+		return
+	}
+	f.redraws.queueUpdateDraw(f.app, update)
+}
+
+// queueUpdateDraw runs the given function on the given application's
+// goroutine and redraws the application afterwards. If the limiter is
+// enabled, redraws are delayed so that they don't exceed its rate, and redraws
+// requested in the meantime are coalesced.
+func (limiter *redrawLimiter) queueUpdateDraw(app *Application, update func()) {
+	limiter.mutex.Lock()
+	enabled, pending := limiter.enabled, limiter.pending
+	delay := time.Until(limiter.last.Add(limiter.interval))
+	if enabled {
+		limiter.pending = true
+	}
+	limiter.mutex.Unlock()
+
+	if !enabled {
+		app.QueueUpdateDraw(update)
+		return
+	}
+	app.QueueUpdate(update)
+	if pending {
+		return
+	}
+	time.AfterFunc(delay, func() {
+		limiter.mutex.Lock()
+		limiter.pending = false
+		limiter.last = time.Now()
+		limiter.mutex.Unlock()
+		app.QueueUpdateDraw(func() {})
+	})
+}
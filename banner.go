@@ -0,0 +1,109 @@
+package form
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// ansiStyleTag matches the style tags which tview.TranslateANSI produces.
+var ansiStyleTag = regexp.MustCompile(`\[[a-zA-Z0-9#\-]*:[a-zA-Z0-9#\-]*(:[a-zA-Z\-]*)?\]`)
+
+// AddBanner adds a banner with the given preformatted text, e.g. figlet output
+// or ANSI art, at the top of the form. Banners are not form items: they have
+// no index, can't receive focus, and don't scroll with the items. Like
+// toolbars, they take their rows below the top toolbar. ANSI escape sequences
+// are translated into colors, other text is shown as is (no style tags).
+//
+// The lines of a banner are centered as a block, so that their alignment
+// within the block is kept, and truncated if the form is too narrow. Banners
+// take at most half of the form's height, rows beyond that are cut off.
+func (f *FormScrollable) AddBanner(text string) *FormScrollable {
+	lines := strings.Split(TranslateANSI(escapeANSIText(strings.TrimRight(text, "\n"))), "\n")
+
+	// Colors set in one line last until they are changed, so each line starts
+	// with the last style tag of the line before it.
+	var style string
+	for index, line := range lines {
+		lines[index] = style + line
+		if tags := ansiStyleTag.FindAllString(line, -1); len(tags) > 0 {
+			style = tags[len(tags)-1]
+		}
+	}
+	f.banners = append(f.banners, lines...)
+	return f
+}
+
+// ClearBanners removes all banners added with AddBanner.
+func (f *FormScrollable) ClearBanners() *FormScrollable {
+	f.banners = nil
+	return f
+}
+
+// drawBanners draws the banners at the given position, taking at most the
+// given height, and returns the number of rows they took.
+func (f *FormScrollable) drawBanners(screen tcell.Screen, x, y, width, height int) int {
+	rows := min(len(f.banners), height)
+	if rows <= 0 || width <= 0 {
+		return 0
+	}
+	var blockWidth int
+	for _, line := range f.banners {
+		blockWidth = max(blockWidth, TaggedStringWidth(line))
+	}
+	left := x + max(width-blockWidth, 0)/2
+	for row, line := range f.banners[:rows] {
+		Print(screen, strings.TrimRight(line, "\r"), left, y+row, x+width-left, AlignLeft, f.labelColor)
+	}
+	return rows
+}
+
+// escapeANSIText escapes the style tags in the text between the ANSI escape
+// sequences of the given text, leaving the sequences intact.
+func escapeANSIText(text string) string {
+	var (
+		result strings.Builder
+		plain  strings.Builder
+	)
+	runes := []rune(text)
+	for index := 0; index < len(runes); index++ {
+		if runes[index] != 27 {
+			plain.WriteRune(runes[index])
+			continue
+		}
+		result.WriteString(Escape(plain.String()))
+		plain.Reset()
+		end := ansiSequenceEnd(runes, index)
+		result.WriteString(string(runes[index:end]))
+		index = end - 1
+	}
+	result.WriteString(Escape(plain.String()))
+	return result.String()
+}
+
+// ansiSequenceEnd returns the index after the end of the ANSI escape sequence
+// which starts at the given index of the given runes.
+func ansiSequenceEnd(runes []rune, start int) int {
+	if start+1 >= len(runes) {
+		return len(runes)
+	}
+	switch runes[start+1] {
+	case '[': // Control sequence, ending with a character from '@' to '~'.
+		for index := start + 2; index < len(runes); index++ {
+			if runes[index] >= '@' && runes[index] <= '~' {
+				return index + 1
+			}
+		}
+		return len(runes)
+	case ']': // Operating system command, ending with BEL or ST.
+		for index := start + 2; index < len(runes); index++ {
+			if runes[index] == 7 || runes[index] == '\\' && runes[index-1] == 27 {
+				return index + 1
+			}
+		}
+		return len(runes)
+	}
+	return start + 2
+}
@@ -0,0 +1,45 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// SetBaseline sets the values the form items are compared with, e.g. default
+// settings or the state on a server, keyed by the items' labels and given as
+// text (see SetValidator for how values are converted to text). Items whose
+// value differs from their baseline value are modified (see IsItemModified):
+// their label is drawn in the modified color and a marker is shown left of
+// them (see SetModifiedStyle). Items without a baseline value are never
+// modified. A nil map turns this off.
+func (f *FormScrollable) SetBaseline(values map[string]string) *FormScrollable {
+	f.baseline = values
+	return f
+}
+
+// SetModifiedStyle sets the label color of modified items and the marker
+// shown left of them (see SetBaseline). A marker of 0 shows no marker.
+func (f *FormScrollable) SetModifiedStyle(color tcell.Color, marker rune) *FormScrollable {
+	f.modifiedColor = color
+	f.modifiedMarker = marker
+	return f
+}
+
+// IsItemModified returns whether the value of the form item at the given index
+// differs from its baseline value (see SetBaseline).
+func (f *FormScrollable) IsItemModified(index int) bool {
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	return f.isModified(f.items[index])
+}
+
+// isModified returns whether the value of the given item differs from its
+// baseline value.
+func (f *FormScrollable) isModified(item FormItem) bool {
+	if f.baseline == nil {
+		return false
+	}
+	value, ok := f.baseline[item.GetLabel()]
+	return ok && getItemText(item) != value
+}
@@ -0,0 +1,308 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	. "github.com/rivo/tview"
+)
+
+// ErrRequired is the validation error of required items (see SetItemRequired)
+// and of items bound to struct fields which are tagged as required which have
+// no value.
+var ErrRequired = errors.New("a value is required")
+
+// binding connects a form item to the struct field or flag it was generated
+// from.
+type binding struct {
+	item   FormItem
+	secret bool
+
+	// parse converts the item's text and returns a function which writes the
+	// result back.
+	parse func(text string) (write func() error, err error)
+}
+
+// fieldOptions holds the settings of a struct field's "form" tag.
+type fieldOptions struct {
+	label    string
+	width    int
+	height   int
+	required bool
+	password bool
+	options  []string
+}
+
+// parseFieldOptions parses a "form" tag of the form
+// "Label,width=20,height=3,required,password,options=a|b|c". An empty label
+// is replaced by the given field name.
+func parseFieldOptions(tag, name string) (fieldOptions, error) {
+	parts := strings.Split(tag, ",")
+	options := fieldOptions{label: parts[0]}
+	if options.label == "" {
+		options.label = name
+	}
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		var err error
+		switch key {
+		case "width":
+			options.width, err = strconv.Atoi(value)
+		case "height":
+			options.height, err = strconv.Atoi(value)
+		case "required":
+			options.required = true
+		case "password":
+			options.password = true
+		case "options":
+			options.options = strings.Split(value, "|")
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return options, fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+	return options, nil
+}
+
+// BindStruct adds a form item for each exported field of the struct the given
+// pointer points to and remembers the fields so that Submit can write the
+// values back. The items are initialized with the fields' current values.
+//
+// Items are configured with the field's "form" tag, e.g.
+//
+//	Name  string `form:"Name,width=20,required"`
+//	Note  string `form:"Note,height=5"`
+//	Pin   string `form:"PIN,password"`
+//	Color string `form:"Color,options=red|green|blue"`
+//	Port  int    `form:"Port,width=6"`
+//	Debug bool   `form:"Debug mode"`
+//
+// The first value is the label (the field name if empty). Strings become input
+// fields (text areas if height is greater than 1, drop-downs if options are
+// given), booleans become checkboxes, and numbers become input fields which
+// only accept numbers. Required fields get a validator (see SetValidator)
+// which rejects empty values. Fields tagged with "-" are skipped. An error is
+// returned and no items are added if the value is not a pointer to a struct,
+// a tag is invalid, or a field has an unsupported type.
+func (f *FormScrollable) BindStruct(ptr any) error {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, got %T", ptr)
+	}
+	value = value.Elem()
+
+	// Generate the items first so that nothing is added on errors.
+	var (
+		bindings   []binding
+		validators []func(text string) error
+	)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag := field.Tag.Get("form")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		options, err := parseFieldOptions(tag, field.Name)
+		if err != nil {
+			return err
+		}
+		item, validator, err := newBoundItem(value.Field(i), options)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		bindings = append(bindings, binding{item: item, secret: options.password, parse: fieldParser(value.Field(i))})
+		validators = append(validators, validator)
+	}
+
+	for i, binding := range bindings {
+		f.AddFormItem(binding.item)
+		if validators[i] != nil {
+			f.SetValidator(len(f.items)-1, validators[i])
+		}
+		if binding.secret {
+			f.SetItemSecret(len(f.items)-1, true)
+		}
+	}
+	f.bindings = append(f.bindings, bindings...)
+	return nil
+}
+
+// UnbindStruct stops writing values back to the bound struct fields, flags,
+// and configuration keys on Submit. The generated items remain in the form.
+func (f *FormScrollable) UnbindStruct() *FormScrollable {
+	f.bindings = nil
+	f.configStores = nil
+	return f
+}
+
+// Submit validates the form (see Validate) and writes the values of the items
+// generated by BindStruct, BindFlags, and BindConfig back to their struct
+// fields, flags, and configuration keys, then saves the configuration stores
+// if requested (see SetConfigAutoSave). If the form is invalid, the
+// validation errors are returned (joined) and nothing is changed.
+func (f *FormScrollable) Submit() error {
+	if errs := f.Validate(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Convert all values before writing any of them.
+	writes := make([]func() error, len(f.bindings))
+	for i, binding := range f.bindings {
+		write, err := binding.parse(f.rawText(binding.item))
+		if err != nil {
+			return &ValidationError{Index: f.itemIndex(binding.item), Label: binding.item.GetLabel(), Err: err}
+		}
+		writes[i] = write
+	}
+	var errs []error
+	for i, binding := range f.bindings {
+		if err := writes[i](); err != nil {
+			errs = append(errs, &ValidationError{Index: f.itemIndex(binding.item), Label: binding.item.GetLabel(), Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return f.saveConfigs()
+}
+
+// fieldParser returns the parse function of a binding to the given struct
+// field.
+func fieldParser(field reflect.Value) func(text string) (func() error, error) {
+	return func(text string) (func() error, error) {
+		value, err := parseFieldValue(field.Type(), text)
+		if err != nil {
+			return nil, err
+		}
+		return func() error {
+			field.Set(value)
+			return nil
+		}, nil
+	}
+}
+
+// unbindItem removes the binding of the given item and ends the watch of its
+// value (see BindWatch), if any.
+func (f *FormScrollable) unbindItem(item FormItem) {
+	f.unwatchItem(item)
+	for i, binding := range f.bindings {
+		if binding.item == item {
+			f.bindings = append(f.bindings[:i], f.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// newBoundItem returns a form item for the given struct field and the validator
+// for its values, if any.
+func newBoundItem(field reflect.Value, options fieldOptions) (FormItem, func(text string) error, error) {
+	var (
+		item   FormItem
+		number bool
+	)
+	switch field.Kind() {
+	case reflect.Bool:
+		item = NewCheckbox().SetLabel(options.label).SetChecked(field.Bool())
+	case reflect.String:
+		text := field.String()
+		switch {
+		case len(options.options) > 0:
+			dropDown := NewDropDown().SetLabel(options.label).SetFieldWidth(options.width).SetOptions(options.options, nil)
+			for index, option := range options.options {
+				if option == text {
+					dropDown.SetCurrentOption(index)
+				}
+			}
+			item = dropDown
+		case options.height > 1:
+			item = NewTextArea().SetLabel(options.label).SetSize(options.height, options.width).SetText(text, false)
+		default:
+			inputField := NewInputField().SetLabel(options.label).SetFieldWidth(options.width).SetText(text)
+			if options.password {
+				inputField.SetMaskCharacter('*')
+			}
+			item = inputField
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = true
+		item = NewInputField().SetLabel(options.label).SetFieldWidth(options.width).
+			SetText(strconv.FormatInt(field.Int(), 10)).SetAcceptanceFunc(InputFieldInteger)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = true
+		item = NewInputField().SetLabel(options.label).SetFieldWidth(options.width).
+			SetText(strconv.FormatUint(field.Uint(), 10)).SetAcceptanceFunc(InputFieldInteger)
+	case reflect.Float32, reflect.Float64:
+		number = true
+		item = NewInputField().SetLabel(options.label).SetFieldWidth(options.width).
+			SetText(strconv.FormatFloat(field.Float(), 'g', -1, field.Type().Bits())).SetAcceptanceFunc(InputFieldFloat)
+	default:
+		return nil, nil, fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	if !number && !options.required {
+		return item, nil, nil
+	}
+	fieldType := field.Type()
+	return item, func(text string) error {
+		if text == "" {
+			if options.required {
+				return ErrRequired
+			}
+			return nil
+		}
+		if number {
+			_, err := parseFieldValue(fieldType, text)
+			return err
+		}
+		return nil
+	}, nil
+}
+
+// parseFieldValue converts the text of a bound item to a value of the given
+// type. Empty numbers are converted to 0.
+func parseFieldValue(fieldType reflect.Type, text string) (reflect.Value, error) {
+	value := reflect.New(fieldType).Elem()
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		checked, err := strconv.ParseBool(text)
+		if err != nil {
+			return value, err
+		}
+		value.SetBool(checked)
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if text == "" {
+			break
+		}
+		number, err := strconv.ParseInt(text, 10, fieldType.Bits())
+		if err != nil {
+			return value, errors.Unwrap(err)
+		}
+		value.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if text == "" {
+			break
+		}
+		number, err := strconv.ParseUint(text, 10, fieldType.Bits())
+		if err != nil {
+			return value, errors.Unwrap(err)
+		}
+		value.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		if text == "" {
+			break
+		}
+		number, err := strconv.ParseFloat(text, fieldType.Bits())
+		if err != nil {
+			return value, errors.Unwrap(err)
+		}
+		value.SetFloat(number)
+	}
+	return value, nil
+}
@@ -0,0 +1,61 @@
+package form
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBindStruct(t *testing.T) {
+	settings := struct {
+		Name  string `form:"Name,width=20,required"`
+		Port  int    `form:"Port,width=6"`
+		Color string `form:"Color,options=red|green|blue"`
+		Debug bool   `form:"Debug mode"`
+		Skip  string `form:"-"`
+	}{Name: "server", Port: 8080, Color: "green"}
+
+	f := NewFormScrollable()
+	if err := f.BindStruct(&settings); err != nil {
+		t.Fatal(err)
+	}
+	if count := f.GetFormItemCount(); count != 4 {
+		t.Fatalf("expected 4 items, got %d", count)
+	}
+	if text := getItemText(f.GetFormItem(1)); text != "8080" {
+		t.Errorf("expected the port %q, got %q", "8080", text)
+	}
+
+	setItemText(f.GetFormItem(0), "")
+	if err := f.Submit(); !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired, got %v", err)
+	}
+	if settings.Name != "server" {
+		t.Fatalf("an invalid form changed the struct: %q", settings.Name)
+	}
+
+	setItemText(f.GetFormItem(0), "proxy")
+	setItemText(f.GetFormItem(1), "9090")
+	setItemText(f.GetFormItem(3), "true")
+	if err := f.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if settings.Name != "proxy" || settings.Port != 9090 || settings.Color != "green" || !settings.Debug {
+		t.Errorf("unexpected values after submitting: %+v", settings)
+	}
+}
+
+func TestBindStructErrors(t *testing.T) {
+	f := NewFormScrollable()
+	if err := f.BindStruct(struct{}{}); err == nil {
+		t.Error("expected an error for a non-pointer")
+	}
+	bad := struct {
+		Name string `form:"Name,unknown"`
+	}{}
+	if err := f.BindStruct(&bad); err == nil {
+		t.Error("expected an error for an unknown option")
+	}
+	if count := f.GetFormItemCount(); count != 0 {
+		t.Errorf("expected no items after errors, got %d", count)
+	}
+}
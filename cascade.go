@@ -0,0 +1,94 @@
+package form
+
+import . "github.com/rivo/tview"
+
+// dropDownLink connects a child drop-down to the parent item whose value
+// determines the child's options.
+type dropDownLink struct {
+	parent, child FormItem
+	optionsFor    func(parentValue string) []string
+
+	// The parent's value the child's options were last set for.
+	value string
+}
+
+// LinkDropDowns makes the item at childIndex depend on the item at
+// parentIndex: whenever the parent's value changes, the child's options are
+// replaced with the ones returned by optionsFor for the new value and the
+// child's selection is reset. The child's options are set right away.
+//
+// The parent may be any item with a value (see GetFormValues), typically a
+// drop-down. The child may be a DropDown, an OptionsDropDown, or a ComboBox
+// (whose text is reset). Links can be chained, e.g. country, region, city.
+// Changes are picked up when the form is drawn.
+func (f *FormScrollable) LinkDropDowns(parentIndex, childIndex int, optionsFor func(parentValue string) []string) *FormScrollable {
+	link := &dropDownLink{
+		parent:     f.items[parentIndex],
+		child:      f.items[childIndex],
+		optionsFor: optionsFor,
+	}
+	link.update()
+	f.links = append(f.links, link)
+	return f
+}
+
+// UnlinkDropDowns removes the dependency of the item at childIndex on other
+// items (see LinkDropDowns). Its options remain unchanged.
+func (f *FormScrollable) UnlinkDropDowns(childIndex int) *FormScrollable {
+	f.unlinkItem(f.items[childIndex])
+	return f
+}
+
+// unlinkItem removes all links the given item takes part in.
+func (f *FormScrollable) unlinkItem(item FormItem) {
+	links := f.links[:0]
+	for _, link := range f.links {
+		if link.parent != item && link.child != item {
+			links = append(links, link)
+		}
+	}
+	f.links = links
+}
+
+// updateLinks updates the options of all linked children whose parent's
+// value changed. Links are processed in the order they were created so that
+// chained children are updated in one go.
+func (f *FormScrollable) updateLinks() {
+	for _, link := range f.links {
+		if getItemText(link.parent) != link.value {
+			link.update()
+		}
+	}
+}
+
+// update replaces the child's options with the ones for the parent's current
+// value and resets the child's selection.
+func (l *dropDownLink) update() {
+	l.value = getItemText(l.parent)
+	options := l.optionsFor(l.value)
+
+	child := l.child
+	if wrapped, ok := child.(*WrappedItem); ok {
+		child = wrapped.GetItem()
+	}
+	switch child := child.(type) {
+	case *DropDown:
+		for child.GetOptionCount() > 0 {
+			child.RemoveOption(0)
+		}
+		for _, option := range options {
+			child.AddOption(option, nil)
+		}
+		child.SetCurrentOption(-1)
+	case *OptionsDropDown:
+		dropDownOptions := make([]*DropDownOption, len(options))
+		for index, option := range options {
+			dropDownOptions[index] = &DropDownOption{Text: option}
+		}
+		child.SetOptions(dropDownOptions)
+		child.SetCurrentOption(-1)
+	case *ComboBox:
+		child.SetOptions(options)
+		setInputFieldText(child.InputField, "")
+	}
+}
@@ -0,0 +1,464 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ChatCommand is a slash command suggested by a ChatInput, e.g. "/clear".
+type ChatCommand struct {
+	// The command's name without the leading slash, e.g. "clear".
+	Name string
+
+	// An optional description shown next to the name.
+	Description string
+}
+
+// ChatInput is a multi-line input for chat messages, e.g. for terminal tools
+// talking to a language model. It grows with its text from its minimum to
+// its maximum height (see SetHeights) and scrolls beyond that.
+//
+// Enter sends the message (see SetSendFunc) and clears the input. Shift+Enter
+// and Alt+Enter insert a newline, as many terminals don't report Shift+Enter.
+// Sent messages are kept in a history: Ctrl+P and Ctrl+N, as well as Up and
+// Down if the text is a single line, go to the previous and the next message.
+// Text starting with a slash suggests the matching commands (see
+// SetCommands) above the input. Up and Down select a suggestion, Tab and
+// Enter complete it, and Escape hides the suggestions.
+//
+// ChatInput can be used on its own, in a form, or in a ChatView.
+type ChatInput struct {
+	*tview.TextArea
+
+	// The minimum and maximum height in rows.
+	minHeight, maxHeight int
+
+	// An optional function which is called with the text to be sent.
+	send func(text string)
+
+	// The sent messages, oldest first, their maximum number, the index of the
+	// shown message (len(history) for the draft), and the text entered before
+	// browsing the history.
+	history      []string
+	historyLimit int
+	historyIndex int
+	draft        string
+
+	// The slash commands, the ones matching the current text, the index of
+	// the selected one, and the text for which the suggestions were hidden
+	// with Escape.
+	commands    []ChatCommand
+	suggestions []ChatCommand
+	selected    int
+	dismissed   string
+
+	// The styles of the suggestions and of the selected suggestion.
+	suggestionStyle, selectedStyle tcell.Style
+}
+
+var _ tview.FormItem = (*ChatInput)(nil)
+
+// NewChatInput returns a new, empty chat input which grows from 1 to 6 rows
+// and keeps the last 100 sent messages.
+func NewChatInput() *ChatInput {
+	c := &ChatInput{
+		TextArea:        tview.NewTextArea(),
+		minHeight:       1,
+		maxHeight:       6,
+		historyLimit:    100,
+		suggestionStyle: tcell.StyleDefault.Background(tview.Styles.MoreContrastBackgroundColor).Foreground(tview.Styles.PrimaryTextColor),
+		selectedStyle:   tcell.StyleDefault.Background(tview.Styles.PrimaryTextColor).Foreground(tview.Styles.PrimitiveBackgroundColor),
+	}
+	return c
+}
+
+// SetHeights sets the minimum and the maximum height of the input in rows.
+// The defaults are 1 and 6.
+func (c *ChatInput) SetHeights(min, max int) *ChatInput {
+	c.minHeight, c.maxHeight = clamp(min, 1, max), max
+	return c
+}
+
+// SetSendFunc sets a function which is called with the text when the user
+// sends a message. Blank messages are not sent.
+func (c *ChatInput) SetSendFunc(handler func(text string)) *ChatInput {
+	c.send = handler
+	return c
+}
+
+// SetCommands sets the slash commands which are suggested when the text
+// starts with a slash.
+func (c *ChatInput) SetCommands(commands []ChatCommand) *ChatInput {
+	c.commands = commands
+	return c
+}
+
+// SetSuggestionStyles sets the styles of the suggested commands and of the
+// selected one.
+func (c *ChatInput) SetSuggestionStyles(normal, selected tcell.Style) *ChatInput {
+	c.suggestionStyle, c.selectedStyle = normal, selected
+	return c
+}
+
+// SetHistory replaces the sent messages, oldest first, e.g. with those of a
+// previous session.
+func (c *ChatInput) SetHistory(history []string) *ChatInput {
+	c.history = append([]string(nil), history...)
+	c.trimHistory()
+	c.historyIndex = len(c.history)
+	return c
+}
+
+// GetHistory returns the sent messages, oldest first.
+func (c *ChatInput) GetHistory() []string {
+	return append([]string(nil), c.history...)
+}
+
+// SetHistoryLimit sets the maximum number of sent messages kept in the
+// history. The default is 100.
+func (c *ChatInput) SetHistoryLimit(limit int) *ChatInput {
+	c.historyLimit = limit
+	c.trimHistory()
+	c.historyIndex = len(c.history)
+	return c
+}
+
+// trimHistory drops the oldest messages beyond the history limit.
+func (c *ChatInput) trimHistory() {
+	if c.historyLimit >= 0 && len(c.history) > c.historyLimit {
+		c.history = c.history[len(c.history)-c.historyLimit:]
+	}
+}
+
+// Send sends the text as a message, adds it to the history, and clears the
+// input. Nothing happens if the text is blank.
+func (c *ChatInput) Send() *ChatInput {
+	text := c.GetText()
+	if strings.TrimSpace(text) == "" {
+		return c
+	}
+	if len(c.history) == 0 || c.history[len(c.history)-1] != text {
+		c.history = append(c.history, text)
+		c.trimHistory()
+	}
+	c.historyIndex, c.draft = len(c.history), ""
+	c.SetText("", true)
+	if c.send != nil {
+		c.send(text)
+	}
+	return c
+}
+
+// browse shows the message of the history at the given offset from the one
+// shown, keeping the entered text as the draft.
+func (c *ChatInput) browse(offset int) {
+	index := clamp(c.historyIndex+offset, 0, len(c.history))
+	if index == c.historyIndex {
+		return
+	}
+	if c.historyIndex == len(c.history) {
+		c.draft = c.GetText()
+	}
+	c.historyIndex = index
+	if index == len(c.history) {
+		c.SetText(c.draft, true)
+	} else {
+		c.SetText(c.history[index], true)
+	}
+}
+
+// update updates the suggested commands for the current text.
+func (c *ChatInput) update() {
+	text := c.GetText()
+	c.suggestions = nil
+	if text != c.dismissed {
+		c.dismissed = ""
+	}
+	if !strings.HasPrefix(text, "/") || strings.ContainsAny(text, " \t\n") || c.dismissed != "" {
+		return
+	}
+	for _, command := range c.commands {
+		if strings.HasPrefix(command.Name, text[1:]) {
+			c.suggestions = append(c.suggestions, command)
+		}
+	}
+	c.selected = clamp(c.selected, 0, max(len(c.suggestions)-1, 0))
+}
+
+// complete replaces the text with the selected command.
+func (c *ChatInput) complete() {
+	if len(c.suggestions) == 0 {
+		return
+	}
+	c.SetText("/"+c.suggestions[c.selected].Name+" ", true)
+}
+
+// rows returns the number of rows the text needs when wrapped at the given
+// width.
+func (c *ChatInput) rows(width int) int {
+	rows := 0
+	for _, line := range strings.Split(c.GetText(), "\n") {
+		rows++
+		if width > 0 {
+			rows += max(tview.TaggedStringWidth(tview.Escape(line))-1, 0) / width
+		}
+	}
+	return rows
+}
+
+// height returns the height of the input for the given width of the text,
+// limited to the minimum and maximum height.
+func (c *ChatInput) height(width int) int {
+	return clamp(c.rows(width), c.minHeight, c.maxHeight)
+}
+
+// fieldWidth returns the width of the text, i.e. the inner width without the
+// label.
+func (c *ChatInput) fieldWidth() int {
+	_, _, width, _ := c.GetInnerRect()
+	if labelWidth := c.GetLabelWidth(); labelWidth > 0 {
+		return width - labelWidth
+	}
+	if label := c.GetLabel(); label != "" {
+		return width - tview.TaggedStringWidth(label) - 1
+	}
+	return width
+}
+
+// GetFieldHeight returns the height of the input, which grows with its text.
+func (c *ChatInput) GetFieldHeight() int {
+	return c.height(c.fieldWidth())
+}
+
+// Draw draws the input and, if there are any, the suggested commands above
+// it or, if there is no space above, below it.
+func (c *ChatInput) Draw(screen tcell.Screen) {
+	c.update()
+	if _, _, _, height := c.GetInnerRect(); c.rows(c.fieldWidth()) <= height {
+		c.SetOffset(0, 0) // The input grew, all of the text fits now.
+	}
+	c.TextArea.Draw(screen)
+	if len(c.suggestions) == 0 || !c.HasFocus() {
+		return
+	}
+	x, y, width, height := c.GetInnerRect()
+	_, screenHeight := screen.Size()
+	rows := len(c.suggestions)
+	top := y - rows
+	if top < 0 {
+		top = y + height
+		rows = min(rows, screenHeight-top)
+	}
+	first := clamp(c.selected-rows+1, 0, max(len(c.suggestions)-rows, 0))
+	for row := 0; row < rows; row++ {
+		index := first + row
+		style := c.suggestionStyle
+		if index == c.selected {
+			style = c.selectedStyle
+		}
+		for column := 0; column < width; column++ {
+			screen.SetContent(x+column, top+row, ' ', nil, style)
+		}
+		command := c.suggestions[index]
+		text := "/" + command.Name
+		if command.Description != "" {
+			text += "  " + command.Description
+		}
+		foreground, _, _ := style.Decompose()
+		tview.Print(screen, " "+tview.Escape(text), x, top+row, width, tview.AlignLeft, foreground)
+	}
+}
+
+// UsesKey returns whether the input uses the given key itself. Like a text
+// area, it uses all keys, e.g. Up and Down to browse the history.
+func (c *ChatInput) UsesKey(key tcell.Key) bool {
+	return true
+}
+
+// InputHandler returns the handler for this primitive.
+func (c *ChatInput) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		key, modifiers := event.Key(), event.Modifiers()
+		c.update()
+		if len(c.suggestions) > 0 {
+			switch key {
+			case tcell.KeyUp:
+				c.selected = (c.selected - 1 + len(c.suggestions)) % len(c.suggestions)
+				return
+			case tcell.KeyDown:
+				c.selected = (c.selected + 1) % len(c.suggestions)
+				return
+			case tcell.KeyTab:
+				c.complete()
+				return
+			case tcell.KeyEnter:
+				if modifiers == tcell.ModNone && c.GetText() != "/"+c.suggestions[c.selected].Name {
+					c.complete()
+					return
+				}
+			case tcell.KeyEscape:
+				c.dismissed, c.suggestions = c.GetText(), nil
+				return
+			}
+		}
+		singleLine := !strings.Contains(c.GetText(), "\n")
+		switch {
+		case key == tcell.KeyEnter && modifiers&(tcell.ModShift|tcell.ModAlt) == 0:
+			c.Send()
+			return
+		case key == tcell.KeyCtrlP || key == tcell.KeyUp && singleLine:
+			c.browse(-1)
+			return
+		case key == tcell.KeyCtrlN || key == tcell.KeyDown && singleLine:
+			c.browse(1)
+			return
+		}
+		if handler := c.TextArea.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// chatMessage is a message shown by a ChatLog.
+type chatMessage struct {
+	sender, text string
+}
+
+// ChatLog is a text view listing chat messages, each one below its sender.
+// It follows new messages unless the user scrolled up, and messages can be
+// extended while they are being received (see AppendToLastMessage), e.g. when
+// streaming the response of a language model. Its methods must be called
+// from the application's goroutine (see tview.Application.QueueUpdateDraw).
+type ChatLog struct {
+	*tview.TextView
+
+	// The messages.
+	messages []chatMessage
+
+	// The colors of the senders' names, by sender, and the color of the
+	// others.
+	senderColors map[string]tcell.Color
+	senderColor  tcell.Color
+}
+
+// NewChatLog returns a new chat log without messages.
+func NewChatLog() *ChatLog {
+	l := &ChatLog{
+		TextView:     tview.NewTextView(),
+		senderColors: make(map[string]tcell.Color),
+		senderColor:  tview.Styles.SecondaryTextColor,
+	}
+	l.SetDynamicColors(true).
+		SetWordWrap(true).
+		ScrollToEnd()
+	return l
+}
+
+// SetSenderColor sets the color of the name of the given sender. An empty
+// sender sets the color of the senders without a color of their own.
+func (l *ChatLog) SetSenderColor(sender string, color tcell.Color) *ChatLog {
+	if sender == "" {
+		l.senderColor = color
+	} else {
+		l.senderColors[sender] = color
+	}
+	l.render()
+	return l
+}
+
+// AddMessage adds a message from the given sender. Its text is shown as it is
+// (it is not interpreted as style tags). An empty sender shows the text
+// without a name above it, e.g. for notes.
+func (l *ChatLog) AddMessage(sender, text string) *ChatLog {
+	l.messages = append(l.messages, chatMessage{sender: sender, text: text})
+	l.render()
+	return l
+}
+
+// AppendToLastMessage appends the given text to the last message, e.g. the
+// next chunk of a streamed response. If there is no message, one without a
+// sender is added.
+func (l *ChatLog) AppendToLastMessage(text string) *ChatLog {
+	if len(l.messages) == 0 {
+		return l.AddMessage("", text)
+	}
+	l.messages[len(l.messages)-1].text += text
+	l.render()
+	return l
+}
+
+// GetMessageCount returns the number of messages.
+func (l *ChatLog) GetMessageCount() int {
+	return len(l.messages)
+}
+
+// ClearMessages removes all messages.
+func (l *ChatLog) ClearMessages() *ChatLog {
+	l.messages = nil
+	l.render()
+	return l
+}
+
+// render sets the text view's text to the messages. Rendering all messages
+// again, rather than writing the new text, keeps text appended in chunks from
+// forming style tags.
+func (l *ChatLog) render() {
+	var text strings.Builder
+	for index, message := range l.messages {
+		if index > 0 {
+			text.WriteString("\n\n")
+		}
+		if message.sender != "" {
+			color, ok := l.senderColors[message.sender]
+			if !ok {
+				color = l.senderColor
+			}
+			text.WriteString("[" + color.String() + "::b]" + tview.Escape(message.sender) + "[-::-]\n")
+		}
+		text.WriteString(tview.Escape(message.text))
+	}
+	l.SetText(text.String())
+}
+
+// ChatView combines a ChatLog and a ChatInput below it, which grows with its
+// text. The input has focus. Messages sent with the input are not added to
+// the log automatically, the app adds them along with the responses (see
+// ChatInput.SetSendFunc).
+type ChatView struct {
+	*tview.Flex
+
+	// The log and the input.
+	log   *ChatLog
+	input *ChatInput
+}
+
+// NewChatView returns a new chat view with an empty log and input.
+func NewChatView() *ChatView {
+	v := &ChatView{
+		Flex:  tview.NewFlex().SetDirection(tview.FlexRow),
+		log:   NewChatLog(),
+		input: NewChatInput(),
+	}
+	v.AddItem(v.log, 0, 1, false).
+		AddItem(v.input, 1, 0, true)
+	return v
+}
+
+// GetLog returns the log of messages.
+func (v *ChatView) GetLog() *ChatLog {
+	return v.log
+}
+
+// GetInput returns the input.
+func (v *ChatView) GetInput() *ChatInput {
+	return v.input
+}
+
+// Draw draws the log and the input, which is resized to fit its text.
+func (v *ChatView) Draw(screen tcell.Screen) {
+	_, _, width, _ := v.GetInnerRect()
+	v.ResizeItem(v.input, v.input.height(width), 0)
+	v.Flex.Draw(screen)
+}
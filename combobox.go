@@ -0,0 +1,135 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ComboBox is an input field which offers a list of options as suggestions
+// while accepting any text. Typing shows the options containing the text,
+// Down shows all options. Its value is the text, whether it was typed or
+// picked from the list.
+type ComboBox struct {
+	*tview.InputField
+
+	// The suggested options.
+	options []string
+
+	// Whether all options are suggested, regardless of the text. This is set
+	// while Down is processed.
+	showAll bool
+
+	// The suggestions last shown and the text of the suggestion the user
+	// navigated to in the list, which keeps the list unchanged.
+	entries   []string
+	navigated string
+
+	// Whether the suggestions are shown.
+	list suggestionList
+}
+
+var _ tview.FormItem = (*ComboBox)(nil)
+
+// NewComboBox returns a new combo box suggesting the given options.
+func NewComboBox(options []string) *ComboBox {
+	c := &ComboBox{
+		InputField: tview.NewInputField(),
+		options:    options,
+	}
+	c.SetAutocompleteFunc(c.suggestions)
+	c.SetAutocompletedFunc(func(text string, index, source int) bool {
+		c.SetText(text)
+		if source == tview.AutocompletedNavigate {
+			c.navigated = text
+			return false
+		}
+		return true
+	})
+	return c
+}
+
+// SetOptions replaces the suggested options.
+func (c *ComboBox) SetOptions(options []string) *ComboBox {
+	c.options = options
+	return c
+}
+
+// GetOptions returns the suggested options.
+func (c *ComboBox) GetOptions() []string {
+	return c.options
+}
+
+// suggestions returns the options to suggest for the given text. While the
+// user navigates the list, it is kept unchanged.
+func (c *ComboBox) suggestions(text string) []string {
+	if c.navigated != "" && text == c.navigated {
+		return c.list.suggested(c.entries)
+	}
+	c.navigated = ""
+	c.entries = c.filter(text)
+	return c.list.suggested(c.entries)
+}
+
+// filter returns all options if requested with Down, otherwise the options
+// containing the given text (ignoring case). Nothing is suggested for an empty
+// text or if the text matches the only suggestion.
+func (c *ComboBox) filter(text string) []string {
+	if text == "" && !c.showAll {
+		return nil
+	}
+	var entries []string
+	lower := strings.ToLower(text)
+	for _, option := range c.options {
+		if c.showAll || strings.Contains(strings.ToLower(option), lower) {
+			entries = append(entries, tview.Escape(option))
+		}
+	}
+	if !c.showAll && len(entries) == 1 && entries[0] == tview.Escape(text) {
+		return nil
+	}
+	return entries
+}
+
+// InputHandler returns the handler for this primitive.
+func (c *ComboBox) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if event.Key() == tcell.KeyDown {
+			c.showAll = true
+			defer func() { c.showAll = false }()
+		}
+		c.InputField.InputHandler()(event, setFocus)
+		c.list.processed(event.Key())
+	})
+}
+
+// UsesKey returns whether the combo box uses the given key itself, e.g. Up and
+// Down while suggestions are shown.
+func (c *ComboBox) UsesKey(key tcell.Key) bool {
+	return c.list.usesKey(key)
+}
+
+// SubmitsOnEnter returns true: Enter in the combo box submits the form like in
+// an input field (see SetSubmitFunc) unless it picks a suggestion.
+func (c *ComboBox) SubmitsOnEnter() bool {
+	return true
+}
+
+// Blur is called when this primitive loses focus.
+func (c *ComboBox) Blur() {
+	c.list.shown = false
+	c.InputField.Blur()
+}
+
+// AddComboBox adds a combo box to the form (see ComboBox). It accepts any text
+// and suggests the given options. The optional "changed" function is called
+// with the text whenever it changes, be it by typing or by picking an option.
+func (f *FormScrollable) AddComboBox(label string, options []string, value string, changed func(text string)) *FormScrollable {
+	comboBox := NewComboBox(options)
+	comboBox.SetLabel(label).
+		SetText(value).
+		SetChangedFunc(changed)
+	f.addItem(comboBox)
+	return f
+}
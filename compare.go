@@ -0,0 +1,199 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// CompareForm shows the values of the items of a FormScrollable side by side
+// with a second set of values, e.g. the current and the proposed settings.
+// Rows whose values differ are highlighted and have a toggle which decides
+// whether the proposed value is applied to the form (see Apply). Up and down
+// select a row, Space, Enter, or a click on the toggle switches it.
+type CompareForm struct {
+	*tview.Table
+
+	// The form whose items are compared.
+	form *FormScrollable
+
+	// The values the items are compared with, keyed by the items' labels.
+	proposed map[string]string
+
+	// The column titles.
+	currentTitle, proposedTitle string
+
+	// The labels of the rows whose proposed value is applied.
+	applied map[string]bool
+
+	// The labels of the rows, in order (without the title row).
+	labels []string
+
+	// The color of values which differ.
+	diffColor tcell.Color
+}
+
+// NewCompareForm returns a new comparison of the values of the given form's
+// items (as text, see FormScrollable.SetValidator) with the proposed values,
+// keyed by the items' labels. Initially, all proposed values which differ are
+// applied.
+func NewCompareForm(form *FormScrollable, proposed map[string]string) *CompareForm {
+	c := &CompareForm{
+		Table:         tview.NewTable(),
+		form:          form,
+		proposed:      proposed,
+		currentTitle:  "Current",
+		proposedTitle: "Proposed",
+		applied:       make(map[string]bool),
+		diffColor:     tcell.ColorYellow,
+	}
+	for label, value := range proposed {
+		if item := form.GetFormItemByLabel(label); item != nil && getItemText(item) != value {
+			c.applied[label] = true
+		}
+	}
+	c.SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSelectedFunc(func(row, column int) {
+			c.toggle(row)
+		})
+	c.Refresh()
+	return c
+}
+
+// SetTitles sets the titles of the columns of the current and the proposed
+// values.
+func (c *CompareForm) SetTitles(current, proposed string) *CompareForm {
+	c.currentTitle, c.proposedTitle = current, proposed
+	c.Refresh()
+	return c
+}
+
+// SetDiffColor sets the color of values which differ.
+func (c *CompareForm) SetDiffColor(color tcell.Color) *CompareForm {
+	c.diffColor = color
+	c.Refresh()
+	return c
+}
+
+// SetApplied sets whether the proposed value of the item with the given label
+// is applied. Rows whose values don't differ are never applied.
+func (c *CompareForm) SetApplied(label string, apply bool) *CompareForm {
+	if apply && c.differs(label) {
+		c.applied[label] = true
+	} else {
+		delete(c.applied, label)
+	}
+	c.Refresh()
+	return c
+}
+
+// IsApplied returns whether the proposed value of the item with the given
+// label is applied.
+func (c *CompareForm) IsApplied(label string) bool {
+	return c.applied[label] && c.differs(label)
+}
+
+// Apply sets the items whose proposed values are applied to these values. An
+// error is returned for items whose value couldn't be set from text (e.g.
+// tview drop-downs), the other items are set nonetheless.
+func (c *CompareForm) Apply() error {
+	var failed []string
+	for _, label := range c.labels {
+		if !c.IsApplied(label) {
+			continue
+		}
+		if !setItemText(c.form.GetFormItemByLabel(label), c.proposed[label]) {
+			failed = append(failed, label)
+		}
+	}
+	c.Refresh()
+	if len(failed) > 0 {
+		return fmt.Errorf("values can't be set for %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// differs returns whether the item with the given label exists and its value
+// differs from its proposed value.
+func (c *CompareForm) differs(label string) bool {
+	proposed, ok := c.proposed[label]
+	if !ok {
+		return false
+	}
+	item := c.form.GetFormItemByLabel(label)
+	return item != nil && getItemText(item) != proposed
+}
+
+// toggle toggles whether the proposed value of the given table row is applied.
+func (c *CompareForm) toggle(row int) {
+	if row < 1 || row > len(c.labels) {
+		return
+	}
+	label := c.labels[row-1]
+	c.SetApplied(label, !c.applied[label])
+}
+
+// Refresh updates the rows with the current values of the form's items. It
+// must be called after items were added or removed or their values changed
+// while the comparison is shown.
+func (c *CompareForm) Refresh() *CompareForm {
+	c.Clear()
+	c.labels = c.labels[:0]
+	title := func(text string) *tview.TableCell {
+		return tview.NewTableCell(tview.Escape(text)).
+			SetTextColor(tview.Styles.SecondaryTextColor).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false)
+	}
+	c.SetCell(0, 0, title(""))
+	c.SetCell(0, 1, title(""))
+	c.SetCell(0, 2, title(c.currentTitle))
+	c.SetCell(0, 3, title(c.proposedTitle))
+
+	for index := 0; index < c.form.GetFormItemCount(); index++ {
+		item, err := c.form.GetFormItemChecked(index)
+		if err != nil {
+			break
+		}
+		if _, ok := getItemValue(item); !ok {
+			continue
+		}
+		label := item.GetLabel()
+		c.labels = append(c.labels, label)
+		row := len(c.labels)
+
+		toggle, color := "   ", tview.Styles.PrimaryTextColor
+		proposed, ok := c.proposed[label]
+		if c.differs(label) {
+			toggle, color = "[ ]", c.diffColor
+			if c.applied[label] {
+				toggle = "[x]"
+			}
+		} else if !ok {
+			proposed = "—"
+		}
+		c.SetCell(row, 0, tview.NewTableCell(tview.Escape(toggle)).SetClickedFunc(func() bool {
+			c.toggle(row)
+			return false
+		}))
+		c.SetCell(row, 1, tview.NewTableCell(tview.Escape(label)).SetTextColor(tview.Styles.SecondaryTextColor))
+		c.SetCell(row, 2, tview.NewTableCell(tview.Escape(getItemText(item))).SetExpansion(1))
+		c.SetCell(row, 3, tview.NewTableCell(tview.Escape(proposed)).SetTextColor(color).SetExpansion(1))
+	}
+	return c
+}
+
+// InputHandler returns the handler for this primitive.
+func (c *CompareForm) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if event.Key() == tcell.KeyRune && event.Rune() == ' ' {
+			row, _ := c.GetSelection()
+			c.toggle(row)
+			return
+		}
+		c.Table.InputHandler()(event, setFocus)
+	})
+}
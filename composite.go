@@ -0,0 +1,429 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// CompositeFormItem is a form item which consists of several parts, each of
+// them a form item itself (e.g. an input field for a number and a drop-down for
+// its unit). The parts are laid out horizontally, one cell apart, to the right
+// of the composite item's label.
+//
+// CompositeFormItem takes care of the tview.FormItem contract: it draws the
+// label, passes the form attributes on to its parts, forwards focus and events
+// to the focused part, and wires the parts' finished functions so that Tab and
+// Backtab move between the parts before leaving the item. Custom multi-part
+// items may embed it.
+type CompositeFormItem struct {
+	*tview.Box
+
+	// The parts of the item.
+	parts []tview.FormItem
+
+	// The index of the part which receives focus.
+	focusedPart int
+
+	// The delegate of the last call to Focus, used to move focus between
+	// parts.
+	delegate func(p tview.Primitive)
+
+	// The last key which finished a part. It is repeated for parts which
+	// cannot receive focus.
+	lastKey tcell.Key
+
+	// Whether a part is currently being focused. Only then, parts which cannot
+	// receive focus are skipped.
+	focusing bool
+
+	// The text to be displayed before the parts.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The label color.
+	labelColor tcell.Color
+
+	// Whether or not the item is disabled.
+	disabled bool
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var (
+	_ tview.FormItem  = (*CompositeFormItem)(nil)
+	_ ItemValuer      = (*CompositeFormItem)(nil)
+	_ ItemValueSetter = (*CompositeFormItem)(nil)
+)
+
+// NewCompositeFormItem returns a new composite form item with the given label
+// and parts.
+func NewCompositeFormItem(label string, parts ...tview.FormItem) *CompositeFormItem {
+	c := &CompositeFormItem{
+		Box:        tview.NewBox(),
+		label:      label,
+		labelColor: tview.Styles.SecondaryTextColor,
+		lastKey:    tcell.KeyTab,
+	}
+	for _, part := range parts {
+		c.AddPart(part)
+	}
+	return c
+}
+
+// AddPart adds a part to the right of the existing parts.
+func (c *CompositeFormItem) AddPart(part tview.FormItem) *CompositeFormItem {
+	index := len(c.parts)
+	c.parts = append(c.parts, part)
+	part.SetFinishedFunc(func(key tcell.Key) {
+		c.partFinished(index, key)
+	})
+	if c.disabled {
+		part.SetDisabled(true)
+	}
+	return c
+}
+
+// GetPart returns the part at the given index.
+func (c *CompositeFormItem) GetPart(index int) tview.FormItem {
+	return c.parts[index]
+}
+
+// GetPartCount returns the number of parts.
+func (c *CompositeFormItem) GetPartCount() int {
+	return len(c.parts)
+}
+
+// SetLabel sets the text to be displayed before the parts.
+func (c *CompositeFormItem) SetLabel(label string) *CompositeFormItem {
+	c.label = label
+	return c
+}
+
+// GetLabel returns the text to be displayed before the parts.
+func (c *CompositeFormItem) GetLabel() string {
+	return c.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (c *CompositeFormItem) SetLabelWidth(width int) *CompositeFormItem {
+	c.labelWidth = width
+	return c
+}
+
+// SetLabelColor sets the color of the label.
+func (c *CompositeFormItem) SetLabelColor(color tcell.Color) *CompositeFormItem {
+	c.labelColor = color
+	return c
+}
+
+// SetFormAttributes sets attributes shared by all form items. The parts are
+// set up without label width so that their own labels (if any) are shown in
+// full.
+func (c *CompositeFormItem) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	c.labelWidth = labelWidth
+	c.labelColor = labelColor
+	c.SetBackgroundColor(bgColor)
+	for _, part := range c.parts {
+		part.SetFormAttributes(0, labelColor, bgColor, fieldTextColor, fieldBgColor)
+	}
+	return c
+}
+
+// GetFieldWidth returns the width of all parts including the gaps between
+// them. If any part has a flexible width, 0 is returned.
+func (c *CompositeFormItem) GetFieldWidth() int {
+	width := 0
+	for index, part := range c.parts {
+		partWidth := formItemWidth(part)
+		if partWidth <= 0 {
+			return 0
+		}
+		if index > 0 {
+			width++
+		}
+		width += partWidth
+	}
+	return width
+}
+
+// GetFieldHeight returns the height of the highest part.
+func (c *CompositeFormItem) GetFieldHeight() int {
+	height := 1
+	for _, part := range c.parts {
+		height = max(height, part.GetFieldHeight())
+	}
+	return height
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (c *CompositeFormItem) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	c.finished = handler
+	return c
+}
+
+// SetDisabled sets whether or not the item and all its parts are disabled.
+func (c *CompositeFormItem) SetDisabled(disabled bool) tview.FormItem {
+	c.disabled = disabled
+	for _, part := range c.parts {
+		part.SetDisabled(disabled)
+	}
+	if c.finished != nil {
+		c.finished(-1)
+	}
+	return c
+}
+
+// IsDisabled returns whether or not the item is disabled.
+func (c *CompositeFormItem) IsDisabled() bool {
+	return c.disabled
+}
+
+// GetValue returns the values of the parts which have a value, in order.
+func (c *CompositeFormItem) GetValue() any {
+	var values []any
+	for _, part := range c.parts {
+		if value, ok := getItemValue(part); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// SetValue sets the values of the parts which have a value, in order, from a
+// slice as returned by GetValue. Other values are ignored.
+func (c *CompositeFormItem) SetValue(value any) {
+	values, ok := value.([]any)
+	if !ok {
+		return
+	}
+	for _, part := range c.parts {
+		if _, ok := getItemValue(part); ok && len(values) > 0 {
+			setItemValue(part, values[0])
+			values = values[1:]
+		}
+	}
+}
+
+// formItemWidth returns the screen width of the given form item, including its
+// label, when it is set up without label width. A value of 0 means that the
+// item's width is flexible.
+func formItemWidth(item tview.FormItem) int {
+	fieldWidth := item.GetFieldWidth()
+	if fieldWidth <= 0 {
+		return 0
+	}
+	labelWidth := tview.TaggedStringWidth(item.GetLabel())
+	if labelWidth > 0 {
+		labelWidth++
+	}
+	return labelWidth + fieldWidth
+}
+
+// partFinished is called when the part at the given index was finished with
+// the given key.
+func (c *CompositeFormItem) partFinished(index int, key tcell.Key) {
+	if key < 0 {
+		if !c.focusing {
+			return // A part was disabled while not being focused.
+		}
+		key = c.lastKey
+	} else {
+		c.lastKey = key
+	}
+	switch key {
+	case tcell.KeyTab:
+		if index+1 < len(c.parts) && !c.disabled {
+			c.focusedPart = index + 1
+			c.focusPart()
+			return
+		}
+	case tcell.KeyBacktab:
+		if index > 0 && !c.disabled {
+			c.focusedPart = index - 1
+			c.focusPart()
+			return
+		}
+	}
+	if c.finished != nil {
+		c.finished(key)
+	}
+}
+
+// focusPart moves focus to the focused part using the delegate of the last
+// call to Focus.
+func (c *CompositeFormItem) focusPart() {
+	if c.delegate != nil {
+		focusing := c.focusing
+		c.focusing = true
+		c.parts[c.focusedPart].Focus(c.delegate)
+		c.focusing = focusing
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (c *CompositeFormItem) Draw(screen tcell.Screen) {
+	c.Box.DrawForSubclass(screen, c)
+
+	x, y, width, height := c.GetInnerRect()
+	rightLimit := x + width
+
+	// Draw label.
+	if c.labelWidth > 0 {
+		labelWidth := min(c.labelWidth, width)
+		tview.Print(screen, c.label, x, y, labelWidth, tview.AlignLeft, c.labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := tview.Print(screen, c.label, x, y, width, tview.AlignLeft, c.labelColor)
+		x += drawnWidth
+	}
+
+	// Distribute the remaining space among the flexible parts.
+	fixed, flexible := 0, 0
+	for index, part := range c.parts {
+		if index > 0 {
+			fixed++
+		}
+		if partWidth := formItemWidth(part); partWidth > 0 {
+			fixed += partWidth
+		} else {
+			flexible++
+		}
+	}
+	flexibleWidth := 0
+	if flexible > 0 {
+		flexibleWidth = max(rightLimit-x-fixed, 0) / flexible
+	}
+
+	// Draw parts.
+	for index, part := range c.parts {
+		if index > 0 {
+			x++
+		}
+		partWidth := formItemWidth(part)
+		if partWidth <= 0 {
+			partWidth = flexibleWidth + tview.TaggedStringWidth(part.GetLabel())
+		}
+		partWidth = clamp(partWidth, 0, rightLimit-x)
+		partHeight := clamp(part.GetFieldHeight(), 1, height)
+		part.SetRect(x, y, partWidth, partHeight)
+		if partWidth > 0 {
+			part.Draw(screen)
+		}
+		x += partWidth
+	}
+}
+
+// Focus is called when this primitive receives focus. The focused part
+// receives focus instead.
+func (c *CompositeFormItem) Focus(delegate func(p tview.Primitive)) {
+	if c.disabled && c.finished != nil {
+		c.finished(-1)
+		return
+	}
+	if len(c.parts) == 0 {
+		c.Box.Focus(delegate)
+		return
+	}
+	c.delegate = delegate
+	switch c.lastKey {
+	case tcell.KeyTab:
+		c.focusedPart = 0
+	case tcell.KeyBacktab:
+		c.focusedPart = len(c.parts) - 1
+	}
+	c.focusPart()
+}
+
+// HasFocus returns whether or not this item or one of its parts has focus.
+func (c *CompositeFormItem) HasFocus() bool {
+	for _, part := range c.parts {
+		if part.HasFocus() {
+			return true
+		}
+	}
+	return c.Box.HasFocus()
+}
+
+// Blur is called when this primitive loses focus.
+func (c *CompositeFormItem) Blur() {
+	for _, part := range c.parts {
+		if part.HasFocus() {
+			part.Blur()
+		}
+	}
+	c.Box.Blur()
+}
+
+// UsesKey returns whether the item uses the given key itself, i.e. whether the
+// focused part uses it.
+func (c *CompositeFormItem) UsesKey(key tcell.Key) bool {
+	for _, part := range c.parts {
+		if part.HasFocus() {
+			return itemUsesKey(part, key)
+		}
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive. Events are forwarded to
+// the part which has focus.
+func (c *CompositeFormItem) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		for index, part := range c.parts {
+			if part.HasFocus() {
+				c.focusedPart = index
+				if handler := part.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+				return
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive. Events are
+// forwarded to the parts.
+func (c *CompositeFormItem) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return c.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if c.disabled || !c.InRect(event.Position()) {
+			return false, nil
+		}
+		for index, part := range c.parts {
+			consumed, capture = part.MouseHandler()(action, event, func(p tview.Primitive) {
+				c.focusedPart = index
+				c.delegate = setFocus
+				setFocus(p)
+			})
+			if consumed {
+				return
+			}
+		}
+
+		// Clicks on the label focus the item.
+		if action == tview.MouseLeftDown {
+			setFocus(c)
+			consumed = true
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive. Pasted text is forwarded
+// to the part which has focus.
+func (c *CompositeFormItem) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return c.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		for _, part := range c.parts {
+			if part.HasFocus() {
+				if handler := part.PasteHandler(); handler != nil {
+					handler(pastedText, setFocus)
+				}
+				return
+			}
+		}
+	})
+}
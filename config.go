@@ -0,0 +1,175 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/rivo/tview"
+)
+
+// ConfigStore is a hierarchical configuration whose keys are separated by
+// dots, e.g. "server.port". It is implemented by *viper.Viper from
+// github.com/spf13/viper. Stores which also implement ConfigWriter can be
+// saved on Submit (see SetConfigAutoSave).
+type ConfigStore interface {
+	// AllKeys returns the keys of all values.
+	AllKeys() []string
+
+	// Get returns the value of the given key (or its default).
+	Get(key string) any
+
+	// Set overrides the value of the given key.
+	Set(key string, value any)
+}
+
+// ConfigWriter is implemented by configuration stores which can be saved to
+// the file they were read from.
+type ConfigWriter interface {
+	WriteConfig() error
+}
+
+// BindConfig adds a form item for each key of the given configuration store
+// which starts with the given prefix (followed by a dot, all keys if the
+// prefix is empty), in lexicographical order, and remembers the keys so that
+// Submit writes changed values back to the store. The items are labeled with
+// the keys without the prefix and initialized with the keys' current values.
+//
+// Booleans become checkboxes, numbers become input fields which only accept
+// numbers, durations become input fields which only accept durations (e.g.
+// "1m30s"), strings become input fields, and lists become tags fields (see
+// TagsField). Values are written back with their original type, lists as
+// []string. Keys with other values are skipped.
+func (f *FormScrollable) BindConfig(store ConfigStore, prefix string) error {
+	if store == nil {
+		return fmt.Errorf("no configuration store")
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+	keys := store.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		item, validator, parse := newConfigItem(store, key, strings.TrimPrefix(key, prefix))
+		if item == nil {
+			continue
+		}
+		f.AddFormItem(item)
+		if validator != nil {
+			f.SetValidator(len(f.items)-1, validator)
+		}
+		f.bindings = append(f.bindings, binding{item: item, parse: parse})
+	}
+	for _, bound := range f.configStores {
+		if bound == store {
+			return nil
+		}
+	}
+	f.configStores = append(f.configStores, store)
+	return nil
+}
+
+// SetConfigAutoSave sets whether Submit saves the configuration stores bound
+// with BindConfig which implement ConfigWriter after writing the values back
+// to them.
+func (f *FormScrollable) SetConfigAutoSave(save bool) *FormScrollable {
+	f.configAutoSave = save
+	return f
+}
+
+// saveConfigs saves the bound configuration stores if requested.
+func (f *FormScrollable) saveConfigs() error {
+	if !f.configAutoSave {
+		return nil
+	}
+	for _, store := range f.configStores {
+		if writer, ok := store.(ConfigWriter); ok {
+			if err := writer.WriteConfig(); err != nil {
+				return fmt.Errorf("saving configuration: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// newConfigItem returns a form item with the given label for the value of the
+// given configuration key, the validator for its values, if any, and the
+// parse function of its binding. A nil item is returned if the value's type is
+// not supported.
+func newConfigItem(store ConfigStore, key, label string) (FormItem, func(text string) error, func(text string) (func() error, error)) {
+	value := store.Get(key)
+
+	// Write values back only if they changed so that overrides are not
+	// created needlessly.
+	write := func(newValue any) func() error {
+		return func() error {
+			current := store.Get(key)
+			if tags, ok := configTags(current); ok {
+				current = tags
+			}
+			if !reflect.DeepEqual(newValue, current) {
+				store.Set(key, newValue)
+			}
+			return nil
+		}
+	}
+
+	if tags, ok := configTags(value); ok {
+		field := NewTagsField().SetLabel(label).SetTags(tags)
+		return field, nil, func(text string) (func() error, error) {
+			return write(field.GetTags()), nil
+		}
+	}
+
+	if duration, ok := value.(time.Duration); ok {
+		validator := func(text string) error {
+			_, err := time.ParseDuration(text)
+			return err
+		}
+		return NewInputField().SetLabel(label).SetText(duration.String()), validator,
+			func(text string) (func() error, error) {
+				duration, err := time.ParseDuration(text)
+				if err != nil {
+					return nil, err
+				}
+				return write(duration), nil
+			}
+	}
+
+	if value == nil {
+		return nil, nil, nil
+	}
+	reflected := reflect.ValueOf(value)
+	item, validator, err := newBoundItem(reflected, fieldOptions{label: label})
+	if err != nil {
+		return nil, nil, nil
+	}
+	return item, validator, func(text string) (func() error, error) {
+		parsed, err := parseFieldValue(reflected.Type(), text)
+		if err != nil {
+			return nil, err
+		}
+		return write(parsed.Interface()), nil
+	}
+}
+
+// configTags returns the elements of the given list value as strings and
+// whether the value is a list.
+func configTags(value any) ([]string, bool) {
+	switch value := value.(type) {
+	case []string:
+		return value, true
+	case []any:
+		tags := make([]string, len(value))
+		for index, element := range value {
+			tags[index] = fmt.Sprint(element)
+		}
+		return tags, true
+	}
+	return nil, false
+}
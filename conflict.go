@@ -0,0 +1,141 @@
+package form
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// Conflict describes a form value which was changed both locally and
+// remotely, e.g. while editing a shared resource.
+type Conflict struct {
+	Local  string // The value of the local edit.
+	Remote string // The value found remotely.
+}
+
+// ConflictResolution is the user's choice for a conflict.
+type ConflictResolution int
+
+// Conflict resolutions.
+const (
+	ConflictKeepLocal  ConflictResolution = iota // The local value is kept.
+	ConflictTakeRemote                           // The remote value is taken.
+	ConflictEdit                                 // The user edits the value.
+)
+
+// SetConflicts sets the conflicting values of form items, keyed by the items'
+// labels. Below each conflicting item, both values are shown together with a
+// chooser to keep the local value, take the remote value, or edit the value.
+// The chooser opens when the line is clicked or when Ctrl+R is pressed while
+// the item has focus. Values are given as text (see SetValidator for how
+// values are converted to text). A nil map removes all conflicts.
+func (f *FormScrollable) SetConflicts(conflicts map[string]Conflict) *FormScrollable {
+	f.conflicts = make(map[string]Conflict, len(conflicts))
+	for label, conflict := range conflicts {
+		f.conflicts[label] = conflict
+	}
+	return f
+}
+
+// HasConflicts returns whether any conflict has not been resolved yet.
+func (f *FormScrollable) HasConflicts() bool {
+	return len(f.conflicts) > 0
+}
+
+// SetConflictResolvedFunc sets a handler which is called when the user
+// resolved the conflict of the item with the given label. For ConflictEdit,
+// the item receives focus and the value is the item's current value.
+func (f *FormScrollable) SetConflictResolvedFunc(handler func(label string, resolution ConflictResolution, value string)) *FormScrollable {
+	f.conflictResolved = handler
+	return f
+}
+
+// ResolveConflict resolves the conflict of the form item at the given index as
+// if the user had chosen the given resolution. Nothing happens if the item has
+// no conflict.
+func (f *FormScrollable) ResolveConflict(index int, resolution ConflictResolution) *FormScrollable {
+	f.resolveConflict(index, resolution, nil)
+	return f
+}
+
+// resolveConflict applies the given resolution to the conflict of the item at
+// the given index. The setFocus function may be nil.
+func (f *FormScrollable) resolveConflict(index int, resolution ConflictResolution, setFocus func(p Primitive)) {
+	item := f.items[index]
+	label := item.GetLabel()
+	conflict, ok := f.conflicts[label]
+	if !ok {
+		return
+	}
+	delete(f.conflicts, label)
+	switch resolution {
+	case ConflictKeepLocal:
+		setItemText(item, conflict.Local)
+	case ConflictTakeRemote:
+		setItemText(item, conflict.Remote)
+	case ConflictEdit:
+		f.focusedElement = index
+		if setFocus != nil {
+			f.Focus(setFocus)
+		}
+	}
+	f.validateItem(item)
+	if f.conflictResolved != nil {
+		f.conflictResolved(label, resolution, getItemText(item))
+	}
+}
+
+// conflictMessage returns the message shown below the given item if it has a
+// conflict and whether it has one.
+func (f *FormScrollable) conflictMessage(item FormItem) (itemMessage, bool) {
+	conflict, ok := f.conflicts[item.GetLabel()]
+	if !ok {
+		return itemMessage{}, false
+	}
+	return itemMessage{
+		text:  fmt.Sprintf("Conflict: local %q, remote %q (resolve…)", conflict.Local, conflict.Remote),
+		color: f.conflictColor,
+		clicked: func(y int, setFocus func(p Primitive)) {
+			if index := f.itemIndex(item); index >= 0 {
+				f.showConflictMenu(index, y, setFocus)
+			}
+		},
+	}, true
+}
+
+// showConflictMenu opens the chooser for the conflict of the item at the given
+// index next to the given screen row.
+func (f *FormScrollable) showConflictMenu(index, y int, setFocus func(p Primitive)) {
+	conflict, ok := f.conflicts[f.items[index].GetLabel()]
+	if !ok {
+		return
+	}
+	entries := []string{
+		Escape(fmt.Sprintf("Keep local (%s)", conflict.Local)),
+		Escape(fmt.Sprintf("Take remote (%s)", conflict.Remote)),
+		"Edit",
+	}
+	x, _, width, _ := f.items[index].GetRect()
+	f.showMenu(x, y, width, entries, func(choice int) {
+		f.resolveConflict(index, ConflictResolution(choice), setFocus)
+	})
+}
+
+// resolveFocusedConflict opens the chooser for the conflict of the focused
+// item. Returns whether the focused item has a conflict.
+func (f *FormScrollable) resolveFocusedConflict(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	if event.Key() != tcell.KeyCtrlR {
+		return false
+	}
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	if _, ok := f.conflicts[f.items[index].GetLabel()]; !ok {
+		return false
+	}
+	_, y, _, _ := f.items[index].GetRect()
+	f.showConflictMenu(index, y, setFocus)
+	return true
+}
@@ -0,0 +1,299 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// CRUDOptions configures a CRUD view (see NewCRUD). The persistence
+// functions are optional. If one of them returns an error, the error is shown
+// to the user and the records are not changed.
+type CRUDOptions[T any] struct {
+	// The names of the struct fields shown as the table's columns, in this
+	// order. If empty, all fields which are bound to form items are shown
+	// (see FormScrollable.BindStruct).
+	Columns []string
+
+	// An optional function which returns a new record to be edited in the
+	// form for creating records. If nil, the zero value is used.
+	New func() T
+
+	// An optional function which is called with each form after the
+	// record's fields were bound to it, e.g. to add validators.
+	Configure func(form *FormScrollable, record *T)
+
+	// Functions which are called when the user created, changed, or deleted
+	// the record with the given index.
+	Create func(record T) error
+	Update func(index int, record T) error
+	Delete func(index int, record T) error
+}
+
+// crudColumn is a column of a CRUD view's table.
+type crudColumn struct {
+	field  int
+	label  string
+	secret bool
+}
+
+// CRUD lists records of a struct type in a table and lets the user create,
+// edit, and delete them with forms generated from the struct (see
+// FormScrollable.BindStruct), the common layout of administration tools.
+//
+// In the table, Enter edits the selected record, n or Insert creates a new
+// one, and d or Delete deletes the selected record after a confirmation. The
+// forms are saved with their "Save" button and closed with their "Cancel"
+// button or Escape.
+type CRUD[T any] struct {
+	*tview.Pages
+
+	// The table listing the records and its columns.
+	table   *tview.Table
+	columns []crudColumn
+
+	// The records.
+	records []T
+
+	// The settings.
+	options CRUDOptions[T]
+}
+
+// NewCRUD returns a new CRUD view for the given records, which must be
+// structs. The slice is copied. An error is returned if T is not a struct
+// type, its fields can't be bound to form items (see
+// FormScrollable.BindStruct), or a column names an unknown field.
+func NewCRUD[T any](records []T, options CRUDOptions[T]) (*CRUD[T], error) {
+	columns, err := crudColumns(reflect.TypeOf((*T)(nil)).Elem(), options.Columns)
+	if err != nil {
+		return nil, err
+	}
+	var record T
+	if err := NewFormScrollable().BindStruct(&record); err != nil {
+		return nil, err
+	}
+	c := &CRUD[T]{
+		Pages: tview.NewPages(),
+		table: tview.NewTable().
+			SetSelectable(true, false).
+			SetFixed(1, 0),
+		columns: columns,
+		records: append([]T(nil), records...),
+		options: options,
+	}
+	c.table.SetSelectedFunc(func(row, column int) {
+		c.edit(row - 1)
+	})
+	c.AddPage("table", c.table, true, true)
+	c.update()
+	return c, nil
+}
+
+// crudColumns returns the columns of a table listing structs of the given
+// type, limited to the fields with the given names unless there are none.
+func crudColumns(structType reflect.Type, names []string) ([]crudColumn, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct type, got %s", structType)
+	}
+	var columns []crudColumn
+	byName := make(map[string]crudColumn)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("form")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		options, err := parseFieldOptions(tag, field.Name)
+		if err != nil {
+			return nil, err
+		}
+		column := crudColumn{field: i, label: options.label, secret: options.password}
+		columns = append(columns, column)
+		byName[field.Name] = column
+	}
+	if len(names) == 0 {
+		return columns, nil
+	}
+	columns = columns[:0]
+	for _, name := range names {
+		column, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("no bound field %s in %s", name, structType)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// GetTable returns the table listing the records, e.g. to style it.
+func (c *CRUD[T]) GetTable() *tview.Table {
+	return c.table
+}
+
+// GetRecords returns a copy of the records.
+func (c *CRUD[T]) GetRecords() []T {
+	return append([]T(nil), c.records...)
+}
+
+// SetRecords replaces the records, e.g. after they were reloaded.
+func (c *CRUD[T]) SetRecords(records []T) *CRUD[T] {
+	c.records = append([]T(nil), records...)
+	c.update()
+	return c
+}
+
+// update fills the table with the records.
+func (c *CRUD[T]) update() {
+	c.table.Clear()
+	for column, col := range c.columns {
+		c.table.SetCell(0, column, tview.NewTableCell(col.label).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+	for index, record := range c.records {
+		value := reflect.ValueOf(record)
+		for column, col := range c.columns {
+			text := "********"
+			if !col.secret {
+				text = fmt.Sprint(value.Field(col.field).Interface())
+			}
+			c.table.SetCell(index+1, column, tview.NewTableCell(tview.Escape(text)).SetExpansion(1))
+		}
+	}
+	if row, _ := c.table.GetSelection(); row > len(c.records) {
+		c.table.Select(len(c.records), 0)
+	}
+}
+
+// create opens the form for a new record.
+func (c *CRUD[T]) create() {
+	var record T
+	if c.options.New != nil {
+		record = c.options.New()
+	}
+	c.openForm("New", record, func(record T) error {
+		if c.options.Create != nil {
+			if err := c.options.Create(record); err != nil {
+				return err
+			}
+		}
+		c.records = append(c.records, record)
+		c.update()
+		c.table.Select(len(c.records), 0)
+		return nil
+	})
+}
+
+// edit opens the form for the record at the given index.
+func (c *CRUD[T]) edit(index int) {
+	if index < 0 || index >= len(c.records) {
+		return
+	}
+	c.openForm("Edit", c.records[index], func(record T) error {
+		if c.options.Update != nil {
+			if err := c.options.Update(index, record); err != nil {
+				return err
+			}
+		}
+		c.records[index] = record
+		c.update()
+		return nil
+	})
+}
+
+// delete asks whether to delete the record at the given index and deletes it.
+func (c *CRUD[T]) delete(index int) {
+	if index < 0 || index >= len(c.records) {
+		return
+	}
+	c.showModal("Delete this record?", []string{"Delete", "Cancel"}, func(button int) {
+		if button != 0 {
+			return
+		}
+		if c.options.Delete != nil {
+			if err := c.options.Delete(index, c.records[index]); err != nil {
+				c.showError(err)
+				return
+			}
+		}
+		c.records = append(c.records[:index], c.records[index+1:]...)
+		c.update()
+	})
+}
+
+// openForm shows a form with the given title for editing a copy of the given
+// record. When the form is saved, the copy is passed to the given function,
+// which closes the form unless it returns an error.
+func (c *CRUD[T]) openForm(title string, record T, save func(record T) error) {
+	form := NewFormScrollable()
+	form.SetBorder(true).SetTitle(" " + title + " ")
+	if err := form.BindStruct(&record); err != nil {
+		c.showError(err) // The struct was checked already.
+		return
+	}
+	if c.options.Configure != nil {
+		c.options.Configure(form, &record)
+	}
+	form.CaptureDefaults()
+	closeForm := func() {
+		c.RemovePage("form")
+	}
+	form.AddButton("Save", func() {
+		err := form.Submit()
+		if err == nil {
+			err = save(record)
+		}
+		if err != nil {
+			c.showError(err)
+			return
+		}
+		closeForm()
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+	c.AddPage("form", form, true, true)
+}
+
+// showError shows the given error in a modal dialog.
+func (c *CRUD[T]) showError(err error) {
+	c.showModal(err.Error(), []string{"OK"}, nil)
+}
+
+// showModal shows a modal dialog with the given text and buttons. The
+// optional "done" function is called with the index of the button which was
+// pressed, -1 for Escape.
+func (c *CRUD[T]) showModal(text string, buttons []string, done func(button int)) {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			c.RemovePage("modal")
+			if done != nil {
+				done(buttonIndex)
+			}
+		})
+	c.AddPage("modal", modal, true, true)
+}
+
+// InputHandler returns the handler for this primitive.
+func (c *CRUD[T]) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if c.table.HasFocus() {
+			row, _ := c.table.GetSelection()
+			switch {
+			case event.Key() == tcell.KeyInsert || event.Key() == tcell.KeyRune && event.Rune() == 'n':
+				c.create()
+				return
+			case event.Key() == tcell.KeyDelete || event.Key() == tcell.KeyRune && event.Rune() == 'd':
+				c.delete(row - 1)
+				return
+			}
+		}
+		if handler := c.Pages.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
@@ -0,0 +1,307 @@
+package form
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ItemDecorator draws additional chrome (prefixes, suffixes, counters,
+// borders, ...) around the field of a form item. See WrapItem.
+type ItemDecorator interface {
+	// Insets returns the number of rows above and below and the number of
+	// columns to the left and to the right of the field which the decorator
+	// occupies.
+	Insets() (top, bottom, left, right int)
+
+	// Decorate draws the decoration around the field of the given item. The
+	// field occupies the given rectangle, the decoration must be drawn within
+	// the insets around it. The style has the item's label color as
+	// foreground and the form's background color as background.
+	Decorate(screen tcell.Screen, item tview.FormItem, x, y, width, height int, style tcell.Style)
+}
+
+// WrappedItem is a form item which draws decorations around the field of
+// another form item. It is created with WrapItem.
+type WrappedItem struct {
+	tview.FormItem
+
+	// The decorations, innermost first.
+	decorators []ItemDecorator
+
+	// The position of the wrapped item including its decorations.
+	x, y, width, height int
+
+	// The attributes set by the form.
+	labelWidth int
+	labelColor tcell.Color
+	bgColor    tcell.Color
+}
+
+var (
+	_ tview.FormItem  = (*WrappedItem)(nil)
+	_ ItemValuer      = (*WrappedItem)(nil)
+	_ ItemValueSetter = (*WrappedItem)(nil)
+)
+
+// WrapItem returns a form item which shows the given item surrounded by the
+// decorations. The first decorator is drawn closest to the item's field. The
+// item itself is not modified; left decorations are placed between its label
+// and its field.
+func WrapItem(item tview.FormItem, decorators ...ItemDecorator) *WrappedItem {
+	return &WrappedItem{
+		FormItem:   item,
+		decorators: decorators,
+		labelColor: tview.Styles.SecondaryTextColor,
+		bgColor:    tview.Styles.PrimitiveBackgroundColor,
+	}
+}
+
+// GetItem returns the wrapped item.
+func (w *WrappedItem) GetItem() tview.FormItem {
+	return w.FormItem
+}
+
+// unwrapItem returns the given item or, if it is wrapped (possibly several
+// times), the innermost wrapped item.
+func unwrapItem(item tview.FormItem) tview.FormItem {
+	for {
+		wrapped, ok := item.(*WrappedItem)
+		if !ok {
+			return item
+		}
+		item = wrapped.GetItem()
+	}
+}
+
+// insets returns the sum of the insets of all decorators.
+func (w *WrappedItem) insets() (top, bottom, left, right int) {
+	for _, decorator := range w.decorators {
+		t, b, l, r := decorator.Insets()
+		top, bottom, left, right = top+t, bottom+b, left+l, right+r
+	}
+	return
+}
+
+// itemLabelWidth returns the width of the wrapped item's label area, excluding
+// the left insets.
+func (w *WrappedItem) itemLabelWidth() int {
+	if w.labelWidth > 0 {
+		return w.labelWidth
+	}
+	return tview.TaggedStringWidth(w.GetLabel())
+}
+
+// SetFormAttributes sets attributes shared by all form items. The label width
+// of the wrapped item is extended by the left insets.
+func (w *WrappedItem) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	w.labelWidth = labelWidth
+	w.labelColor = labelColor
+	w.bgColor = bgColor
+	_, _, left, _ := w.insets()
+	w.FormItem.SetFormAttributes(w.itemLabelWidth()+left, labelColor, bgColor, fieldTextColor, fieldBgColor)
+	return w
+}
+
+// GetFieldWidth returns the field width of the wrapped item plus the left and
+// right insets, or 0 if the field width is flexible.
+func (w *WrappedItem) GetFieldWidth() int {
+	fieldWidth := w.FormItem.GetFieldWidth()
+	if fieldWidth <= 0 {
+		return 0
+	}
+	_, _, left, right := w.insets()
+	return fieldWidth + left + right
+}
+
+// GetFieldHeight returns the field height of the wrapped item plus the top and
+// bottom insets.
+func (w *WrappedItem) GetFieldHeight() int {
+	fieldHeight := w.FormItem.GetFieldHeight()
+	if fieldHeight <= 0 {
+		fieldHeight = tview.DefaultFormFieldHeight
+	}
+	top, bottom, _, _ := w.insets()
+	return fieldHeight + top + bottom
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (w *WrappedItem) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	w.FormItem.SetFinishedFunc(handler)
+	return w
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (w *WrappedItem) SetDisabled(disabled bool) tview.FormItem {
+	w.FormItem.SetDisabled(disabled)
+	return w
+}
+
+// GetValue returns the value of the wrapped item.
+func (w *WrappedItem) GetValue() any {
+	value, _ := getItemValue(w.FormItem)
+	return value
+}
+
+// SetValue sets the value of the wrapped item.
+func (w *WrappedItem) SetValue(value any) {
+	setItemValue(w.FormItem, value)
+}
+
+// SetRect sets the position of the item including its decorations.
+func (w *WrappedItem) SetRect(x, y, width, height int) {
+	w.x, w.y, w.width, w.height = x, y, width, height
+	top, bottom, _, right := w.insets()
+	w.FormItem.SetRect(x, y+top, max(width-right, 0), max(height-top-bottom, 0))
+}
+
+// GetRect returns the position of the item including its decorations.
+func (w *WrappedItem) GetRect() (int, int, int, int) {
+	return w.x, w.y, w.width, w.height
+}
+
+// Draw draws the wrapped item and its decorations.
+func (w *WrappedItem) Draw(screen tcell.Screen) {
+	w.FormItem.Draw(screen)
+
+	// Determine the field's area.
+	top, bottom, left, right := w.insets()
+	x := w.x + w.itemLabelWidth() + left
+	y := w.y + top
+	width := w.x + w.width - right - x
+	if fieldWidth := w.FormItem.GetFieldWidth(); fieldWidth > 0 && fieldWidth < width {
+		width = fieldWidth
+	}
+	height := w.height - top - bottom
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the decorations from the inside out.
+	style := tcell.StyleDefault.Foreground(w.labelColor).Background(w.bgColor)
+	for _, decorator := range w.decorators {
+		decorator.Decorate(screen, w.FormItem, x, y, width, height, style)
+		t, b, l, r := decorator.Insets()
+		x, y, width, height = x-l, y-t, width+l+r, height+t+b
+	}
+}
+
+// AffixDecorator shows fixed text before and after the field of a form item,
+// e.g. a currency symbol or a unit.
+type AffixDecorator struct {
+	prefix, suffix string
+}
+
+// NewAffixDecorator returns a decorator which shows the prefix before and the
+// suffix after the field. Both may contain style tags.
+func NewAffixDecorator(prefix, suffix string) *AffixDecorator {
+	return &AffixDecorator{prefix: prefix, suffix: suffix}
+}
+
+// Insets returns the widths of the prefix and the suffix.
+func (d *AffixDecorator) Insets() (top, bottom, left, right int) {
+	return 0, 0, tview.TaggedStringWidth(d.prefix), tview.TaggedStringWidth(d.suffix)
+}
+
+// Decorate draws the prefix and the suffix.
+func (d *AffixDecorator) Decorate(screen tcell.Screen, item tview.FormItem, x, y, width, height int, style tcell.Style) {
+	color, _, _ := style.Decompose()
+	_, _, left, right := d.Insets()
+	tview.Print(screen, d.prefix, x-left, y, left, tview.AlignLeft, color)
+	tview.Print(screen, d.suffix, x+width, y, right, tview.AlignLeft, color)
+}
+
+// CounterDecorator shows the number of characters of a form item's value after
+// its field, e.g. "12/40".
+type CounterDecorator struct {
+	limit int
+}
+
+// NewCounterDecorator returns a decorator which counts the characters of the
+// item's value. If limit is greater than 0, it is shown as well and the counter
+// is highlighted (tview.Styles.TertiaryTextColor) when the value exceeds it.
+func NewCounterDecorator(limit int) *CounterDecorator {
+	return &CounterDecorator{limit: limit}
+}
+
+// Insets returns the width of the counter including a leading space.
+func (d *CounterDecorator) Insets() (top, bottom, left, right int) {
+	if d.limit > 0 {
+		digits := len(strconv.Itoa(d.limit))
+		return 0, 0, 0, 2*digits + 2
+	}
+	return 0, 0, 0, 6
+}
+
+// Decorate draws the counter.
+func (d *CounterDecorator) Decorate(screen tcell.Screen, item tview.FormItem, x, y, width, height int, style tcell.Style) {
+	color, _, _ := style.Decompose()
+	count := len([]rune(getItemText(item)))
+	text := strconv.Itoa(count)
+	if d.limit > 0 {
+		text = fmt.Sprintf("%d/%d", count, d.limit)
+		if count > d.limit {
+			color = tview.Styles.TertiaryTextColor
+		}
+	}
+	_, _, _, right := d.Insets()
+	tview.Print(screen, text, x+width+1, y, right-1, tview.AlignRight, color)
+}
+
+// BorderDecorator draws a single-line border around the field of a form item.
+type BorderDecorator struct{}
+
+// NewBorderDecorator returns a decorator which draws a border around the field.
+func NewBorderDecorator() *BorderDecorator {
+	return &BorderDecorator{}
+}
+
+// Insets returns one row or column on each side.
+func (d *BorderDecorator) Insets() (top, bottom, left, right int) {
+	return 1, 1, 1, 1
+}
+
+// Decorate draws the border.
+func (d *BorderDecorator) Decorate(screen tcell.Screen, item tview.FormItem, x, y, width, height int, style tcell.Style) {
+	left, top, right, bottom := x-1, y-1, x+width, y+height
+	for cx := x; cx < right; cx++ {
+		screen.SetContent(cx, top, tview.Borders.Horizontal, nil, style)
+		screen.SetContent(cx, bottom, tview.Borders.Horizontal, nil, style)
+	}
+	for cy := y; cy < bottom; cy++ {
+		screen.SetContent(left, cy, tview.Borders.Vertical, nil, style)
+		screen.SetContent(right, cy, tview.Borders.Vertical, nil, style)
+	}
+	screen.SetContent(left, top, tview.Borders.TopLeft, nil, style)
+	screen.SetContent(right, top, tview.Borders.TopRight, nil, style)
+	screen.SetContent(left, bottom, tview.Borders.BottomLeft, nil, style)
+	screen.SetContent(right, bottom, tview.Borders.BottomRight, nil, style)
+}
+
+// ValidityDecorator shows whether the value of a form item is valid after its
+// field, as a check mark or a cross.
+type ValidityDecorator struct {
+	validate func(value string) error
+}
+
+// NewValidityDecorator returns a decorator which validates the item's value
+// with the given function whenever it is drawn.
+func NewValidityDecorator(validate func(value string) error) *ValidityDecorator {
+	return &ValidityDecorator{validate: validate}
+}
+
+// Insets returns the width of the indicator including a leading space.
+func (d *ValidityDecorator) Insets() (top, bottom, left, right int) {
+	return 0, 0, 0, 2
+}
+
+// Decorate draws the indicator.
+func (d *ValidityDecorator) Decorate(screen tcell.Screen, item tview.FormItem, x, y, width, height int, style tcell.Style) {
+	if d.validate(getItemText(item)) != nil {
+		screen.SetContent(x+width+1, y, '✗', nil, style.Foreground(tcell.ColorRed))
+	} else {
+		screen.SetContent(x+width+1, y, '✓', nil, style.Foreground(tcell.ColorGreen))
+	}
+}
@@ -0,0 +1,234 @@
+package form
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// DemoScript is a sequence of user actions on a form, such as filling in
+// fields, scrolling, and pressing buttons, which a DemoDriver plays against a
+// running application, e.g. to record reproducible demos of the widgets in
+// this package.
+type DemoScript struct {
+	steps []func(d *DemoDriver) error
+}
+
+// NewDemoScript returns a new, empty demo script.
+func NewDemoScript() *DemoScript {
+	return &DemoScript{}
+}
+
+// Focus moves the focus to the form item or button with the given label.
+func (s *DemoScript) Focus(label string) *DemoScript {
+	s.steps = append(s.steps, func(d *DemoDriver) error {
+		return d.focus(label)
+	})
+	return s
+}
+
+// Type types the given text, one character after the other, into the focused
+// element.
+func (s *DemoScript) Type(text string) *DemoScript {
+	s.steps = append(s.steps, func(d *DemoDriver) error {
+		d.typeText(text)
+		return nil
+	})
+	return s
+}
+
+// Fill moves the focus to the form item with the given label, clears it, and
+// types the given text into it.
+func (s *DemoScript) Fill(label, text string) *DemoScript {
+	s.steps = append(s.steps, func(d *DemoDriver) error {
+		if err := d.focus(label); err != nil {
+			return err
+		}
+		d.update(func() {
+			if item := d.form.GetFormItemByLabel(label); item != nil {
+				setItemText(item, "")
+			}
+		})
+		d.typeText(text)
+		return nil
+	})
+	return s
+}
+
+// Press presses the given key, e.g. tcell.KeyTab, in the focused element.
+func (s *DemoScript) Press(key tcell.Key) *DemoScript {
+	s.steps = append(s.steps, func(d *DemoDriver) error {
+		d.key(tcell.NewEventKey(key, 0, tcell.ModNone))
+		d.pause(d.stepDelay)
+		return nil
+	})
+	return s
+}
+
+// Click moves the focus to the button with the given label and presses it.
+func (s *DemoScript) Click(label string) *DemoScript {
+	s.steps = append(s.steps, func(d *DemoDriver) error {
+		if err := d.focus(label); err != nil {
+			return err
+		}
+		d.key(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+		d.pause(d.stepDelay)
+		return nil
+	})
+	return s
+}
+
+// Scroll scrolls the form by the given number of rows, down if positive, up
+// if negative, one row after the other.
+func (s *DemoScript) Scroll(rows int) *DemoScript {
+	s.steps = append(s.steps, func(d *DemoDriver) error {
+		step := 1
+		if rows < 0 {
+			step = -1
+		}
+		for row := 0; row != rows; row += step {
+			d.update(func() {
+				d.form.ScrollTo(d.form.GetScrollOffset() + step)
+			})
+			d.typingPause()
+		}
+		d.pause(d.stepDelay)
+		return nil
+	})
+	return s
+}
+
+// Pause waits for the given duration.
+func (s *DemoScript) Pause(duration time.Duration) *DemoScript {
+	s.steps = append(s.steps, func(d *DemoDriver) error {
+		time.Sleep(duration)
+		return nil
+	})
+	return s
+}
+
+// DemoDriver plays demo scripts (see DemoScript) against a form in a running
+// application, with human-like timing: typed characters and scrolled rows are
+// apart by a typing delay which varies randomly, and steps are followed by a
+// pause. The random variation is seeded so that demos are reproducible.
+type DemoDriver struct {
+	app  *tview.Application
+	form *FormScrollable
+
+	// The average delay between characters, its maximum random deviation, and
+	// the pause after each step.
+	typingDelay, typingJitter, stepDelay time.Duration
+
+	// The source of the random variation.
+	random *rand.Rand
+}
+
+// NewDemoDriver returns a driver which plays demo scripts against the given
+// form in the given application.
+func NewDemoDriver(app *tview.Application, form *FormScrollable) *DemoDriver {
+	return &DemoDriver{
+		app:          app,
+		form:         form,
+		typingDelay:  120 * time.Millisecond,
+		typingJitter: 60 * time.Millisecond,
+		stepDelay:    700 * time.Millisecond,
+		random:       rand.New(rand.NewSource(1)),
+	}
+}
+
+// SetTiming sets the average delay between typed characters (and scrolled
+// rows), its maximum random deviation, and the pause after each step.
+func (d *DemoDriver) SetTiming(typing, jitter, step time.Duration) *DemoDriver {
+	d.typingDelay, d.typingJitter, d.stepDelay = typing, jitter, step
+	return d
+}
+
+// SetSeed sets the seed of the random variation of the typing delay. Demos
+// played with the same seed have the same timing.
+func (d *DemoDriver) SetSeed(seed int64) *DemoDriver {
+	d.random = rand.New(rand.NewSource(seed))
+	return d
+}
+
+// Play plays the given script and returns when it is done or when a step
+// failed, e.g. because there is no element with a given label. It must not be
+// called from the application's goroutine (e.g. from a handler), as the
+// actions are queued to it (see tview.Application.QueueUpdateDraw).
+func (d *DemoDriver) Play(script *DemoScript) error {
+	for _, step := range script.steps {
+		if err := step(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update runs the given function in the application's goroutine, redraws the
+// screen, and waits until it is done.
+func (d *DemoDriver) update(update func()) {
+	done := make(chan struct{})
+	d.app.QueueUpdateDraw(func() {
+		defer close(done)
+		update()
+	})
+	<-done
+}
+
+// focus moves the focus to the form item or button with the given label.
+func (d *DemoDriver) focus(label string) error {
+	var err error
+	d.update(func() {
+		index := d.form.GetFormItemIndex(label)
+		if index < 0 {
+			if button := d.form.GetButtonIndex(label); button >= 0 {
+				index = d.form.GetFormItemCount() + button
+			}
+		}
+		if index < 0 {
+			err = fmt.Errorf("no form item or button labeled %q", label)
+			return
+		}
+		d.form.SetFocus(index)
+		d.app.SetFocus(d.form)
+	})
+	if err == nil {
+		d.pause(d.stepDelay)
+	}
+	return err
+}
+
+// typeText types the given text into the focused element.
+func (d *DemoDriver) typeText(text string) {
+	for _, r := range text {
+		d.key(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		d.typingPause()
+	}
+	d.pause(d.stepDelay)
+}
+
+// key passes the given key event to the form, as if the user pressed it.
+func (d *DemoDriver) key(event *tcell.EventKey) {
+	d.update(func() {
+		if handler := d.form.InputHandler(); handler != nil {
+			handler(event, func(p tview.Primitive) { d.app.SetFocus(p) })
+		}
+	})
+}
+
+// pause waits for the given delay.
+func (d *DemoDriver) pause(delay time.Duration) {
+	time.Sleep(delay)
+}
+
+// typingPause waits for the typing delay, varied randomly by up to the typing
+// jitter.
+func (d *DemoDriver) typingPause() {
+	delay := d.typingDelay
+	if d.typingJitter > 0 {
+		delay += time.Duration(d.random.Int63n(int64(2*d.typingJitter))) - d.typingJitter
+	}
+	time.Sleep(delay) // Negative delays return immediately.
+}
@@ -0,0 +1,86 @@
+package form
+
+import "reflect"
+
+// EscapePolicy decides what happens when the user presses Escape in the form
+// item at the given index (see SetEscapePolicy). Use one of the predefined
+// policies or EscapeCustom.
+type EscapePolicy func(f *FormScrollable, index int)
+
+// Predefined escape policies.
+var (
+	// RestoreFieldValue sets the item back to the value it had when it
+	// received focus, reverting the user's edit.
+	RestoreFieldValue EscapePolicy = func(f *FormScrollable, index int) {
+		item := f.items[index]
+		if item != f.focusChangedElement {
+			return
+		}
+		if value, _ := f.snapshot(item); reflect.DeepEqual(value, f.focusValue) {
+			return
+		}
+		restoreItemSnapshot(item, f.focusValue)
+		if state, ok := f.itemStates[item]; ok && state.err != nil {
+			f.validateItem(item)
+		}
+	}
+
+	// ClearField empties the item if it contains text.
+	ClearField EscapePolicy = func(f *FormScrollable, index int) {
+		item := f.items[index]
+		if setItemText(item, "") {
+			if state, ok := f.itemStates[item]; ok && state.err != nil {
+				f.validateItem(item)
+			}
+		}
+	}
+
+	// CancelForm calls the form's cancel handler (see SetCancelFunc and
+	// SetCancelConfirm).
+	CancelForm EscapePolicy = func(f *FormScrollable, index int) {
+		f.cancelForm()
+	}
+)
+
+// EscapeCustom returns an escape policy which calls the given function.
+func EscapeCustom(handler func()) EscapePolicy {
+	return func(f *FormScrollable, index int) {
+		handler()
+	}
+}
+
+// SetEscapePolicy sets what happens when the user presses Escape in a form
+// item, e.g. RestoreFieldValue. By default (or if the policy is nil), the
+// form's cancel handler is called (see SetCancelFunc) or, if there is none,
+// the focus moves to the first element. Items can have their own policy (see
+// SetItemEscapePolicy). Buttons always use the default.
+func (f *FormScrollable) SetEscapePolicy(policy EscapePolicy) *FormScrollable {
+	f.escapePolicy = policy
+	return f
+}
+
+// SetItemEscapePolicy sets what happens when the user presses Escape in the
+// form item at the given index, overriding the form's policy (see
+// SetEscapePolicy). A nil policy removes the item's own policy.
+func (f *FormScrollable) SetItemEscapePolicy(index int, policy EscapePolicy) *FormScrollable {
+	f.state(f.items[index]).escapePolicy = policy
+	return f
+}
+
+// escapeByPolicy applies the escape policy of the focused item. Returns false
+// if the default applies.
+func (f *FormScrollable) escapeByPolicy() bool {
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	policy := f.escapePolicy
+	if state, ok := f.itemStates[f.items[index]]; ok && state.escapePolicy != nil {
+		policy = state.escapePolicy
+	}
+	if policy == nil {
+		return false
+	}
+	policy(f, index)
+	return true
+}
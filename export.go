@@ -0,0 +1,188 @@
+package form
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// DocumentFormat is the format of documents created by ExportDocument.
+type DocumentFormat int
+
+// Document formats.
+const (
+	DocumentMarkdown DocumentFormat = iota
+	DocumentHTML
+)
+
+// secretMask replaces the values of secret items in exported documents.
+const secretMask = "********"
+
+// SetItemSecret sets whether the value of the form item at the given index is
+// secret and masked in exported documents (see ExportDocument). Password
+// fields are secret by default.
+func (f *FormScrollable) SetItemSecret(index int, secret bool) *FormScrollable {
+	f.state(f.items[index]).secret = secret
+	return f
+}
+
+// ExportDocument returns a documentation-style rendering of the form in the
+// given format, e.g. for audit records: the form's title as a heading, the
+// labels, current values (as text, see SetValidator), and help texts (see
+// SetItemHelp) of the items in tables, and the text of text views which are
+// not scrollable as paragraphs between them. Values of secret items (see
+// SetItemSecret) are masked, hidden items and items without a value are left
+// out.
+func (f *FormScrollable) ExportDocument(format DocumentFormat) (string, error) {
+	var doc documentWriter
+	switch format {
+	case DocumentMarkdown:
+		doc = &markdownWriter{}
+	case DocumentHTML:
+		doc = &htmlWriter{}
+	default:
+		return "", fmt.Errorf("unknown document format %d", format)
+	}
+
+	doc.heading(f.GetTitle())
+	for index, item := range f.items {
+		if !f.IsItemVisible(index) {
+			continue
+		}
+		state := f.itemStates[item]
+		if state != nil && state.fixed {
+			doc.paragraph(item.GetLabel(), getItemText(item))
+			continue
+		}
+		if _, ok := getItemValue(item); !ok {
+			continue
+		}
+		value, help := getItemText(item), ""
+		if state != nil {
+			if state.secret {
+				value = secretMask
+			}
+			help = state.help
+		}
+		doc.row(item.GetLabel(), value, help)
+	}
+	return doc.String(), nil
+}
+
+// documentWriter renders the parts of an exported document. Consecutive rows
+// form a table.
+type documentWriter interface {
+	// heading adds the document's heading.
+	heading(text string)
+
+	// paragraph adds a paragraph with an optional title.
+	paragraph(title, text string)
+
+	// row adds a table row describing a form item.
+	row(label, value, help string)
+
+	// String returns the document.
+	String() string
+}
+
+// markdownWriter renders documents in Markdown.
+type markdownWriter struct {
+	strings.Builder
+	inTable bool
+}
+
+// heading adds the document's heading.
+func (w *markdownWriter) heading(text string) {
+	if text != "" {
+		fmt.Fprintf(w, "# %s\n\n", markdownEscape(text))
+	}
+}
+
+// paragraph adds a paragraph with an optional title.
+func (w *markdownWriter) paragraph(title, text string) {
+	w.endTable()
+	if title != "" {
+		fmt.Fprintf(w, "**%s**\n\n", markdownEscape(title))
+	}
+	fmt.Fprintf(w, "%s\n\n", text)
+}
+
+// row adds a table row, starting a new table if necessary.
+func (w *markdownWriter) row(label, value, help string) {
+	if !w.inTable {
+		w.WriteString("| Field | Value | Description |\n| --- | --- | --- |\n")
+		w.inTable = true
+	}
+	fmt.Fprintf(w, "| %s | %s | %s |\n", markdownEscape(label), markdownEscape(value), markdownEscape(help))
+}
+
+// String returns the document.
+func (w *markdownWriter) String() string {
+	w.endTable()
+	return strings.TrimSuffix(w.Builder.String(), "\n")
+}
+
+// endTable ends the current table, if any.
+func (w *markdownWriter) endTable() {
+	if w.inTable {
+		w.WriteString("\n")
+		w.inTable = false
+	}
+}
+
+// markdownEscape escapes text for a single line or table cell in Markdown.
+func markdownEscape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		"|", `\|`,
+		"*", `\*`,
+		"_", `\_`,
+		"`", "\\`",
+		"\n", "<br>",
+	).Replace(text)
+}
+
+// htmlWriter renders documents in HTML.
+type htmlWriter struct {
+	strings.Builder
+	inTable bool
+}
+
+// heading adds the document's heading.
+func (w *htmlWriter) heading(text string) {
+	if text != "" {
+		fmt.Fprintf(w, "<h1>%s</h1>\n", html.EscapeString(text))
+	}
+}
+
+// paragraph adds a paragraph with an optional title.
+func (w *htmlWriter) paragraph(title, text string) {
+	w.endTable()
+	if title != "" {
+		fmt.Fprintf(w, "<h2>%s</h2>\n", html.EscapeString(title))
+	}
+	fmt.Fprintf(w, "<p>%s</p>\n", strings.ReplaceAll(html.EscapeString(text), "\n", "<br>"))
+}
+
+// row adds a table row, starting a new table if necessary.
+func (w *htmlWriter) row(label, value, help string) {
+	if !w.inTable {
+		w.WriteString("<table>\n<tr><th>Field</th><th>Value</th><th>Description</th></tr>\n")
+		w.inTable = true
+	}
+	fmt.Fprintf(w, "<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n", html.EscapeString(label), html.EscapeString(value), html.EscapeString(help))
+}
+
+// String returns the document.
+func (w *htmlWriter) String() string {
+	w.endTable()
+	return w.Builder.String()
+}
+
+// endTable ends the current table, if any.
+func (w *htmlWriter) endTable() {
+	if w.inTable {
+		w.WriteString("</table>\n")
+		w.inTable = false
+	}
+}
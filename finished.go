@@ -0,0 +1,148 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// EnterAction is what happens when the user presses Enter on a form item (see
+// FinishedKeyPolicy).
+type EnterAction int
+
+// Available Enter actions.
+const (
+	EnterDefault EnterAction = iota // The item's usual behaviour.
+	EnterAdvance                    // Act like Tab, usually moving to the next element.
+	EnterSubmit                     // Submit the form (see SetSubmitFunc).
+	EnterOpen                       // Pass Enter on to the item, e.g. to open a drop-down.
+)
+
+// FinishedKeyPolicy controls how the form reacts to the keys with which the
+// user leaves a form item.
+//
+// By default, Enter advances to the next element in input fields (or submits
+// the form, see SetSubmitFunc) and is passed on to all other items, e.g.
+// opening drop-downs, toggling checkboxes, and inserting new lines in text
+// areas. The Enter field changes this. EnterOpen explicitly keeps passing
+// Enter on, e.g. for an item whose type's policy says otherwise. EnterSubmit
+// falls back to passing Enter on if the form can't be submitted.
+//
+// When an item can't keep or receive focus, e.g. because it was disabled or is
+// hidden, the form repeats the last navigation, e.g. moving backwards after
+// Backtab. If NoReplay is true, the focus moves on to the next element
+// instead.
+type FinishedKeyPolicy struct {
+	Enter    EnterAction
+	NoReplay bool
+}
+
+// SetFinishedKeyPolicy sets the policy for the keys with which the user leaves
+// the form item at the given index. It takes precedence over the policy for
+// the item's type (see SetTypeFinishedKeyPolicy).
+func (f *FormScrollable) SetFinishedKeyPolicy(index int, policy FinishedKeyPolicy) *FormScrollable {
+	f.state(f.items[index]).finishedPolicy = &policy
+	return f
+}
+
+// SetTypeFinishedKeyPolicy sets the policy for the keys with which the user
+// leaves form items of the same type as the given example, e.g.
+// (*tview.DropDown)(nil) for all drop-downs.
+func (f *FormScrollable) SetTypeFinishedKeyPolicy(example FormItem, policy FinishedKeyPolicy) *FormScrollable {
+	if f.typePolicies == nil {
+		f.typePolicies = make(map[reflect.Type]FinishedKeyPolicy)
+	}
+	f.typePolicies[reflect.TypeOf(example)] = policy
+	return f
+}
+
+// SetItemFinishedFunc sets a handler which is called when the user leaves the
+// form item at the given index with a key (e.g. Tab, Backtab, Enter, or
+// Escape), before the form moves the focus. If it returns true, the key is
+// consumed: the item is not validated and keeps focus. For example, Enter in a
+// search field may trigger a lookup instead of moving on. Unlike
+// SetFinishedFunc on the item itself, the handler isn't replaced when the form
+// receives focus.
+func (f *FormScrollable) SetItemFinishedFunc(index int, handler func(key tcell.Key) bool) *FormScrollable {
+	f.state(f.items[index]).finished = handler
+	return f
+}
+
+// SetItemExitFunc sets a handler which is called when the user leaves the
+// form item at the given index with a key (e.g. Tab, Backtab, Enter, or
+// Escape), after the form validated the item and moved the focus or submitted
+// the form. This is the item counterpart of SetButtonExitFunc: custom behavior
+// runs alongside the form's navigation, e.g. saving a draft whenever the user
+// leaves a field. Keys consumed by the handler set with SetItemFinishedFunc
+// don't leave the item. A nil handler removes it.
+func (f *FormScrollable) SetItemExitFunc(index int, handler func(key tcell.Key)) *FormScrollable {
+	f.state(f.items[index]).exit = handler
+	return f
+}
+
+// itemExited calls the "exit" handler of the given item, if any.
+func (f *FormScrollable) itemExited(item FormItem, key tcell.Key) {
+	if state, ok := f.itemStates[item]; ok && state.exit != nil {
+		state.exit(key)
+	}
+}
+
+// itemFinished calls the "finished" handler of the given item, if any, and
+// returns whether it consumed the key.
+func (f *FormScrollable) itemFinished(item FormItem, key tcell.Key) bool {
+	state, ok := f.itemStates[item]
+	return ok && state.finished != nil && state.finished(key)
+}
+
+// finishedKeyPolicy returns the policy for the given item.
+func (f *FormScrollable) finishedKeyPolicy(item FormItem) FinishedKeyPolicy {
+	if state, ok := f.itemStates[item]; ok && state.finishedPolicy != nil {
+		return *state.finishedPolicy
+	}
+	return f.typePolicies[reflect.TypeOf(item)]
+}
+
+// replayKey returns the key to process when the given item can't keep or
+// receive focus: -1 to repeat the last navigation or Tab if the item's policy
+// doesn't allow this.
+func (f *FormScrollable) replayKey(item FormItem) tcell.Key {
+	if f.finishedKeyPolicy(item).NoReplay {
+		return tcell.KeyTab
+	}
+	return -1
+}
+
+// enterByPolicy processes Enter according to the focused item's policy.
+// Returns whether the key was consumed.
+func (f *FormScrollable) enterByPolicy(setFocus func(p Primitive)) bool {
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	item := f.items[index]
+
+	// Open drop-downs need Enter to select an option.
+	if dropDown, ok := item.(interface{ IsOpen() bool }); ok && dropDown.IsOpen() {
+		return false
+	}
+
+	policy := f.finishedKeyPolicy(item)
+	if policy.Enter != EnterAdvance && policy.Enter != EnterSubmit {
+		return false
+	}
+	if f.itemFinished(item, tcell.KeyEnter) {
+		return true
+	}
+	switch policy.Enter {
+	case EnterAdvance:
+		if handler := item.InputHandler(); handler != nil {
+			handler(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), setFocus)
+		}
+		return true
+	case EnterSubmit:
+		f.validateItem(item)
+		return f.submitForm(setFocus)
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package form
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	. "github.com/rivo/tview"
+)
+
+// Flag describes a command-line flag for BindFlagValues.
+type Flag struct {
+	// The flag's name, used as the label of its item.
+	Name string
+
+	// The flag's usage text, used as the help text of its item (see
+	// SetItemHelp).
+	Usage string
+
+	// The flag's value. If it has a "Type() string" method (like the values of
+	// github.com/spf13/pflag), its result determines the item type, otherwise
+	// the type returned by flag.Getter is used.
+	Value flag.Value
+}
+
+// BindFlags adds a form item for each flag defined in the given flag set (in
+// lexicographical order) and remembers the flags so that Submit can write the
+// values back. See BindFlagValues for the generated items. Only flags whose
+// value changed are set, with fs.Set, so that fs.Visit reports them.
+func (f *FormScrollable) BindFlags(fs *flag.FlagSet) error {
+	var flags []Flag
+	fs.VisitAll(func(fl *flag.Flag) {
+		flags = append(flags, Flag{Name: fl.Name, Usage: fl.Usage, Value: fl.Value})
+	})
+	return f.bindFlags(flags, fs.Set)
+}
+
+// BindFlagValues adds a form item for each of the given flags and remembers the
+// flags so that Submit can write changed values back with their Set methods.
+// It is meant for flag packages other than the standard library's, e.g. for a
+// pflag.FlagSet:
+//
+//	var flags []form.Flag
+//	fs.VisitAll(func(f *pflag.Flag) {
+//		flags = append(flags, form.Flag{Name: f.Name, Usage: f.Usage, Value: f.Value})
+//	})
+//	err := f.BindFlagValues(flags)
+//
+// Boolean flags become checkboxes, numeric flags become input fields which
+// only accept numbers, and duration flags become input fields which only
+// accept durations (e.g. "1m30s"). All other flags become input fields which
+// are validated by their Set method on Submit. The items are initialized with
+// the flags' current values.
+func (f *FormScrollable) BindFlagValues(flags []Flag) error {
+	return f.bindFlags(flags, nil)
+}
+
+// bindFlags binds the given flags. Values are written with the given function
+// or with the flags' Set methods if it is nil.
+func (f *FormScrollable) bindFlags(flags []Flag, set func(name, value string) error) error {
+	for _, fl := range flags {
+		if fl.Value == nil {
+			return fmt.Errorf("flag %s has no value", fl.Name)
+		}
+	}
+	for _, fl := range flags {
+		kind := flagKind(fl.Value)
+		item, validator := newFlagItem(fl, kind)
+		f.AddFormItem(item)
+		index := len(f.items) - 1
+		if validator != nil {
+			f.SetValidator(index, validator)
+		}
+		if fl.Usage != "" {
+			f.SetItemHelp(index, fl.Usage)
+		}
+
+		fl := fl
+		write := fl.Value.Set
+		if set != nil {
+			write = func(value string) error {
+				return set(fl.Name, value)
+			}
+		}
+		f.bindings = append(f.bindings, binding{item: item, parse: func(text string) (func() error, error) {
+			if validator != nil {
+				if err := validator(text); err != nil {
+					return nil, err
+				}
+			}
+			return func() error {
+				if text == fl.Value.String() {
+					return nil
+				}
+				return write(text)
+			}, nil
+		}})
+	}
+	return nil
+}
+
+// flagKind returns the type name of the given flag value, as returned by the
+// Type method of pflag values (e.g. "bool", "int64", "duration"), or an empty
+// string if it is unknown.
+func flagKind(value flag.Value) string {
+	if typed, ok := value.(interface{ Type() string }); ok {
+		return typed.Type()
+	}
+	if getter, ok := value.(flag.Getter); ok {
+		switch getter.Get().(type) {
+		case bool:
+			return "bool"
+		case int:
+			return "int"
+		case int64:
+			return "int64"
+		case uint:
+			return "uint"
+		case uint64:
+			return "uint64"
+		case float64:
+			return "float64"
+		case time.Duration:
+			return "duration"
+		case string:
+			return "string"
+		}
+	}
+	if boolFlag, ok := value.(interface{ IsBoolFlag() bool }); ok && boolFlag.IsBoolFlag() {
+		return "bool"
+	}
+	return ""
+}
+
+// newFlagItem returns a form item for the given flag of the given kind (see
+// flagKind) and the validator for its values, if any.
+func newFlagItem(fl Flag, kind string) (FormItem, func(text string) error) {
+	text := fl.Value.String()
+	switch kind {
+	case "bool":
+		checked, _ := strconv.ParseBool(text)
+		return NewCheckbox().SetLabel(fl.Name).SetChecked(checked), nil
+	case "int", "int8", "int16", "int32", "int64":
+		return NewInputField().SetLabel(fl.Name).SetText(text).SetAcceptanceFunc(InputFieldInteger),
+			func(text string) error {
+				_, err := strconv.ParseInt(text, 0, 64)
+				return flagError(err)
+			}
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return NewInputField().SetLabel(fl.Name).SetText(text).SetAcceptanceFunc(InputFieldInteger),
+			func(text string) error {
+				_, err := strconv.ParseUint(text, 0, 64)
+				return flagError(err)
+			}
+	case "float32", "float64":
+		return NewInputField().SetLabel(fl.Name).SetText(text).SetAcceptanceFunc(InputFieldFloat),
+			func(text string) error {
+				_, err := strconv.ParseFloat(text, 64)
+				return flagError(err)
+			}
+	case "duration":
+		return NewInputField().SetLabel(fl.Name).SetText(text),
+			func(text string) error {
+				_, err := time.ParseDuration(text)
+				return err
+			}
+	}
+	return NewInputField().SetLabel(fl.Name).SetText(text), nil
+}
+
+// flagError strips the function and input from strconv errors.
+func flagError(err error) error {
+	if numErr := (*strconv.NumError)(nil); errors.As(err, &numErr) {
+		return numErr.Err
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package form
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// benchmarkItems is the number of items of the forms used in benchmarks.
+const benchmarkItems = 500
+
+// benchmarkForm returns a focused form with benchmarkItems input fields, the
+// focus on the last one.
+func benchmarkForm() *FormScrollable {
+	f := NewFormScrollable()
+	for i := 0; i < benchmarkItems; i++ {
+		f.AddInputField(fmt.Sprintf("Field %d", i), "value", 20, nil, nil)
+	}
+	f.AddButton("OK", nil)
+	fc := &focuser{}
+	fc.setFocus(f)
+	f.SetFocus(benchmarkItems - 1)
+	fc.setFocus(f)
+	return f
+}
+
+func BenchmarkFocusIndex(b *testing.B) {
+	f := benchmarkForm()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if f.focusIndex() != benchmarkItems-1 {
+			b.Fatal("unexpected focus")
+		}
+	}
+}
+
+func BenchmarkDraw(b *testing.B) {
+	f := benchmarkForm()
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		b.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(80, 25)
+	f.SetRect(0, 0, 80, 25)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Draw(screen)
+	}
+}
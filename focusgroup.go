@@ -0,0 +1,100 @@
+package form
+
+import (
+	. "github.com/rivo/tview"
+)
+
+// FocusGroup chains several forms, e.g. the forms of a left and a right
+// column, into one focus cycle: Tab (or Enter) on the last element of a form
+// moves the focus to the first element of the next form, Backtab on the first
+// element to the last element of the previous form, wrapping around at the
+// ends of the group. Forms without focusable elements are skipped. The form
+// which receives focus scrolls to the focused element. Forms which trap the
+// focus (see FormScrollable.SetFocusTrap) keep cycling through their own
+// elements.
+//
+// A form can only be in one group. Focusing forms of the group by other means
+// (e.g. with the mouse) is not affected.
+type FocusGroup struct {
+	// The forms in focus order.
+	forms []*FormScrollable
+}
+
+// NewFocusGroup returns a new focus group with the given forms in focus
+// order.
+func NewFocusGroup(forms ...*FormScrollable) *FocusGroup {
+	g := &FocusGroup{}
+	for _, form := range forms {
+		g.AddForm(form)
+	}
+	return g
+}
+
+// AddForm adds the given form to the end of the focus order, removing it from
+// the group it was in before.
+func (g *FocusGroup) AddForm(form *FormScrollable) *FocusGroup {
+	if form.focusGroup != nil {
+		form.focusGroup.RemoveForm(form)
+	}
+	form.focusGroup = g
+	g.forms = append(g.forms, form)
+	return g
+}
+
+// RemoveForm removes the given form from the group. Its Tab and Backtab
+// cycle through its own elements again.
+func (g *FocusGroup) RemoveForm(form *FormScrollable) *FocusGroup {
+	for index, f := range g.forms {
+		if f == form {
+			g.forms = append(g.forms[:index], g.forms[index+1:]...)
+			form.focusGroup = nil
+			break
+		}
+	}
+	return g
+}
+
+// GetForms returns the forms of the group in focus order.
+func (g *FocusGroup) GetForms() []*FormScrollable {
+	return append([]*FormScrollable(nil), g.forms...)
+}
+
+// leave moves the focus from the given form to the first element of the next
+// form in the group (forward) or the last element of the previous one. It
+// returns false if the form should keep the focus itself.
+func (g *FocusGroup) leave(form *FormScrollable, forward bool, setFocus func(p Primitive)) bool {
+	if form.focusTrap {
+		return false
+	}
+	index := -1
+	for i, f := range g.forms {
+		if f == form {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+	step := 1
+	if !forward {
+		step = -1
+	}
+	for count := 1; count <= len(g.forms); count++ {
+		next := g.forms[(index+step*count+len(g.forms))%len(g.forms)]
+		if next.GetFormItemCount()+next.GetButtonCount() == 0 {
+			continue
+		}
+		next.trackFocus = true
+		next.focusEdge(forward, setFocus)
+		return true
+	}
+	return false
+}
+
+// leaveGroup passes the focus on to the next (forward) or the previous form
+// of the form's focus group (see FocusGroup). It returns false if the form
+// isn't in a group or should keep the focus.
+func (f *FormScrollable) leaveGroup(forward bool, setFocus func(p Primitive)) bool {
+	return f.focusGroup != nil && f.focusGroup.leave(f, forward, setFocus)
+}
@@ -1,12 +1,23 @@
 package form
 
 import (
+	"errors"
+	"fmt"
 	"image"
+	"math"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	. "github.com/rivo/tview"
 )
 
+// ErrIndexOutOfRange is wrapped by the errors returned by the checked
+// variants of the functions which access buttons and form items by index, such
+// as GetButtonChecked, if there is no element at the given index.
+var ErrIndexOutOfRange = errors.New("index out of range")
+
 // FormScrollable is a form from original tview with two buttons
 // which change elements focus by one item up an down. Also buttons show
 // allowing scalable (will disabled when firs and last element in focus)
@@ -56,12 +67,325 @@ type FormScrollable struct {
 	// such key is known yet.
 	lastFinishedKey tcell.Key
 
-	// An optional function which is called when the user hits Escape.
-	cancel func()
+	// An optional function which is called when the user hits Escape and the
+	// question asked before if the form is dirty (empty if none).
+	cancel        func()
+	cancelConfirm string
 
 	// Scroll buttons
 	upScrollButton   *NoneFocusableButton
 	downScrollButton *NoneFocusableButton
+
+	// The buttons which scroll horizontally scrollable forms.
+	leftScrollButton  *NoneFocusableButton
+	rightScrollButton *NoneFocusableButton
+
+	// The number of columns of vertical layouts.
+	columns int
+
+	// Whether the buttons are pinned to the bottom of the form.
+	buttonsSticky bool
+
+	// Whether text views can receive focus by mouse or scroll buttons.
+	textViewsFocusable bool
+
+	// Whether horizontal layouts scroll instead of wrapping, the horizontal
+	// scroll offset, its maximum as of the last draw, and the visible width.
+	horizontalScrolling bool
+	scrollOffsetX       int
+	maxScrollOffsetX    int
+	pageWidth           int
+
+	// The index of the first button which did not fit into the buttons row and
+	// was collapsed into the overflow menu or -1 if all buttons are shown.
+	overflowIndex int
+
+	// The button which opens the overflow menu with the collapsed buttons.
+	overflowButton *NoneFocusableButton
+
+	// If set to true, items can be reordered by the user by dragging their
+	// handle or by pressing Ctrl+Up/Down.
+	reorderable bool
+
+	// The index of the item which is currently dragged by its handle and the
+	// index it had when dragging started, or -1 if no item is dragged.
+	dragItem, dragFrom int
+
+	// An optional function which is called when the user moved an item.
+	reordered func(from, to int)
+
+	// If set to true, a control to delete the item is shown right of each item.
+	deletable bool
+
+	// An optional function which is called when the user deleted an item.
+	deleted func(index int, item FormItem)
+
+	// If set to true, a control to revert the item is shown right of each
+	// dirty item.
+	revertControls bool
+
+	// An optional function which is called when the form became dirty or
+	// clean again, and whether the form was dirty when it was last called.
+	dirtyChanged func(dirty bool)
+	dirty        bool
+
+	// The changes which can be undone and redone, and the item whose changes
+	// are merged with the last change as long as it keeps focus.
+	undoStack, redoStack []valueChange
+	undoMerge            FormItem
+
+	// The policies for the keys with which the user leaves items, by the
+	// items' types.
+	typePolicies map[reflect.Type]FinishedKeyPolicy
+
+	// The styles of items' labels and fields by the items' types.
+	typeStyles map[reflect.Type]itemStyle
+
+	// The "exit" handlers the app set on buttons (see SetButtonExitFunc),
+	// called after the form's.
+	buttonExits map[*Button]func(tcell.Key)
+
+	// The shortcut letters of buttons and the modifiers which must be held
+	// with shortcut letters (see SetItemShortcut).
+	buttonShortcuts  map[*Button]rune
+	shortcutModifier tcell.ModMask
+
+	// Additional keys and characters which move the focus, submit, or cancel
+	// the form (see SetNavigationKeys), and whether the key such a key stands
+	// for is being processed.
+	nextKeys, prevKeys, submitKeys, cancelKeys []tcell.Key
+	nextRunes, prevRunes                       []rune
+	navigating                                 bool
+
+	// Whether the vi-style navigation mode is on (see SetVimMode), whether
+	// the item with the given index is being edited in it, and whether g was
+	// pressed last.
+	vimMode, vimEditing, vimPrefix bool
+	vimEditIndex                   int
+
+	// The focus group the form is in, nil if none.
+	focusGroup *FocusGroup
+
+	// The number of elements skipped in a row while moving the focus because
+	// they can't receive it.
+	skipped int
+
+	// Where the items' labels are placed, the fixed width of labels left of
+	// the fields (0 for the width of the longest one), and the character which
+	// ends truncated labels (0 for none).
+	labelPlacement  LabelPlacement
+	fixedLabelWidth int
+	labelEllipsis   rune
+
+	// The measured labels of the items, the width of the longest label left
+	// of the fields (see GetMaxLabelWidth), and whether it must be determined
+	// again because that label was shortened or removed.
+	labels        map[FormItem]*itemLabel
+	maxLabelWidth int
+	maxLabelStale bool
+
+	// The text which is appended to the labels of required items.
+	requiredMarker string
+
+	// Whether the help texts of items are shown in a help bar and their
+	// color.
+	helpBar   bool
+	helpColor tcell.Color
+
+	// The style of the placeholders of input fields and text areas, nil if
+	// the items keep their own.
+	placeholderStyle *tcell.Style
+
+	// What happens when the user presses Escape in an item (nil for the
+	// default) and the value of the focused item when it received focus.
+	escapePolicy EscapePolicy
+	focusValue   any
+
+	// The text selection in a read-only item: the index of the item (or -1 if
+	// nothing is selected) and the start and end of the selection relative to
+	// the item's top-left corner. The selection is being extended while
+	// "selecting" is true.
+	selectionItem                int
+	selectionStart, selectionEnd image.Point
+	selecting                    bool
+
+	// The currently selected text, as determined during the last Draw.
+	selectedText string
+
+	// An optional function which receives selected text copied by the user.
+	clipboard func(text string)
+
+	// The vertical scroll offset of the items and buttons. As long as
+	// trackFocus is true, it is adjusted such that the focused element is
+	// visible. Explicit scrolling by the user turns it off until the focus
+	// changes again. The focused element index at the time of the last Draw is
+	// kept in lastFocusIndex.
+	scrollOffset   int
+	trackFocus     bool
+	lastFocusIndex int
+
+	// The index of the element which was last known to have focus (see
+	// focusIndex). It is kept up to date when the form moves the focus so
+	// that the elements don't need to be searched for it.
+	focusHint int
+
+	// The largest possible scroll offset and the top and height of the visible
+	// area, as determined during the last Draw.
+	maxScrollOffset, pageTop, pageHeight int
+
+	// The last scroll key sent to a focused text view and the text view's
+	// scroll offset at that time. If the next key in the same direction
+	// finds the same offset, the text view's edge was reached.
+	pagingItem FormItem
+	pagingDown bool
+	pagingRow  int
+
+	// An optional function which is called when the scroll offset changed and
+	// the offset it was last called with.
+	scrolled       func(offset int)
+	scrolledOffset int
+
+	// The rows of the items' content (see GetItemRow), as determined during
+	// the last Draw.
+	itemRows []int
+
+	// An optional function which is called when the focus moved to another
+	// element and the element it was last called with.
+	focusChanged        func(index int, item FormItem)
+	focusChangedElement Primitive
+
+	// The application this form is running in. It is used to trigger
+	// redraws for animations.
+	app *Application
+
+	// Whether the form is visible, whether it was drawn since the application
+	// last reported a frame (see AfterDraw), and optional functions which are
+	// called when the form becomes visible or invisible.
+	shown, drawnInFrame         bool
+	shownHandler, hiddenHandler func()
+
+	// The primitive which has focus and the primitive which captured the
+	// mouse when events are processed without an application (see
+	// ProcessKey).
+	processFocus, processCapture Primitive
+
+	// If set to true, dragging the form's background with the mouse scrolls
+	// the form. dragMomentum lets the form continue scrolling after the mouse
+	// button was released.
+	dragScrolling, dragMomentum bool
+
+	// Whether dragging the form anywhere with the middle mouse button scrolls
+	// it, without momentum.
+	middleDragScrolling bool
+
+	// The state of dragging the form, with the middle mouse button if middle
+	// is true.
+	pan struct {
+		active      bool
+		middle      bool
+		startY      int
+		startOffset int
+		lastY       int
+		lastTime    time.Time
+		velocity    float64 // In rows per second.
+	}
+
+	// If set to true, the mouse wheel moves the focus like the scroll buttons
+	// instead of scrolling the form.
+	wheelFocus bool
+
+	// The number of rows scrolled per mouse wheel step and whether the wheel
+	// direction is inverted.
+	wheelLines    int
+	wheelInverted bool
+
+	// Closing this channel stops the current momentum scrolling.
+	momentumStop chan struct{}
+
+	// Closed to stop the animation of loading items.
+	loadingStop chan struct{}
+
+	// If set to true, the focus can't leave the form while it has focus.
+	focusTrap bool
+
+	// The scroll bar between the scroll buttons and when it is shown.
+	scrollBar           *scrollBar
+	scrollBarVisibility ScrollBarVisibility
+
+	// The hotkeys registered with RegisterHotkey and whether the form is a
+	// tab of a TabbedFormScrollable, which switches tabs with Ctrl+PgUp/PgDn.
+	hotkeys []Hotkey
+	tabbed  bool
+
+	// Additional settings and state of the form items.
+	itemStates map[FormItem]*itemState
+
+	// The color of labels and messages of items which failed validation.
+	errorColor tcell.Color
+
+	// The conflicting values of items keyed by their labels, an optional
+	// function which is called when the user resolved a conflict, and the
+	// color of conflict messages.
+	conflicts        map[string]Conflict
+	conflictResolved func(label string, resolution ConflictResolution, value string)
+	conflictColor    tcell.Color
+
+	// The values items are compared with to find modified items, the label
+	// color of modified items, and the marker shown left of them.
+	baseline       map[string]string
+	modifiedColor  tcell.Color
+	modifiedMarker rune
+
+	// The colors of item notices by level and the time after which they
+	// disappear.
+	noticeColors  [3]tcell.Color
+	noticeTimeout time.Duration
+
+	// The buttons which are disabled as long as the form doesn't validate.
+	validButtons map[*Button]bool
+
+	// An optional function which is called when the user presses Enter in a
+	// single-line input field and the button which is pressed instead, if any.
+	submit       func()
+	submitButton *Button
+
+	// The struct fields, flags, and configuration keys bound to form items
+	// with BindStruct, BindFlags, and BindConfig.
+	bindings []binding
+
+	// The configuration stores bound with BindConfig and whether they are
+	// saved on Submit.
+	configStores   []ConfigStore
+	configAutoSave bool
+
+	// The provider secret items are loaded from and saved to, if any.
+	secretProvider SecretProvider
+
+	// The dependencies between drop-downs created with LinkDropDowns.
+	links []*dropDownLink
+
+	// An optional function which is called before and after an item is drawn.
+	itemDrawHook func(screen tcell.Screen, index int, rect Rect, phase DrawPhase)
+
+	// The toolbars attached above and below the items.
+	topToolbar    []*ToolButton
+	bottomToolbar []*ToolButton
+
+	// The lines of the banners shown above the items (see AddBanner).
+	banners []string
+
+	// The popup (e.g. the overflow menu) which is drawn above the form or nil
+	// if there is none. As long as it is shown, it receives all key and mouse
+	// events.
+	popup Primitive
+
+	// The settings of the low-bandwidth mode and the state of the redraws the
+	// form triggers on its own.
+	redraws *redrawLimiter
+
+	// The coordinator which limits the redraws the form triggers on its own,
+	// nil if there is none.
+	coordinator *RedrawCoordinator
 }
 
 // NewFormScrollable returns a new form.
@@ -79,8 +403,36 @@ func NewFormScrollable() *FormScrollable {
 		buttonDisabledStyle:  tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
 		lastFinishedKey:      tcell.KeyTab, // To skip over inactive elements at the beginning of the form.
 
-		downScrollButton: NewNoneFocusableButton("\u2193"),
-		upScrollButton:   NewNoneFocusableButton("\u2191"),
+		downScrollButton:  NewNoneFocusableButton("\u2193"),
+		upScrollButton:    NewNoneFocusableButton("\u2191"),
+		leftScrollButton:  NewNoneFocusableButton("\u2190"),
+		rightScrollButton: NewNoneFocusableButton("\u2192"),
+
+		columns:          1,
+		trackFocus:       true,
+		lastFocusIndex:   -1,
+		focusHint:        -1,
+		dragScrolling:    true,
+		wheelLines:       1,
+		dragItem:         -1,
+		selectionItem:    -1,
+		overflowIndex:    -1,
+		scrollBar:        newScrollBar(),
+		itemStates:       make(map[FormItem]*itemState),
+		labels:           make(map[FormItem]*itemLabel),
+		requiredMarker:   defaultRequiredMarker,
+		helpColor:        Styles.PrimaryTextColor,
+		buttonExits:      make(map[*Button]func(tcell.Key)),
+		buttonShortcuts:  make(map[*Button]rune),
+		shortcutModifier: tcell.ModAlt,
+		errorColor:       tcell.ColorRed,
+		noticeColors:     [3]tcell.Color{tcell.ColorSkyblue, tcell.ColorYellow, tcell.ColorRed},
+		noticeTimeout:    defaultNoticeTimeout,
+		redraws:          &redrawLimiter{interval: time.Second / defaultMaxRedrawRate},
+		modifiedColor:    tcell.ColorYellow,
+		conflictColor:    tcell.ColorOrange,
+		modifiedMarker:   '\u2022',
+		overflowButton:   NewNoneFocusableButton("\u22ef"),
 	}
 
 	onNext := func() {
@@ -100,7 +452,7 @@ func NewFormScrollable() *FormScrollable {
 			}
 
 			if next < len(f.items) {
-				if _, ok := f.GetFormItem(next).(*TextView); ok {
+				if f.isSkippedTextView(next) || f.IsItemDisabled(next) || !f.IsItemVisible(next) {
 					nn(next + 1)
 					return
 				}
@@ -129,7 +481,7 @@ func NewFormScrollable() *FormScrollable {
 			f.downScrollButton.SetDisabled(false)
 
 			if prev < len(f.items) {
-				if _, ok := f.GetFormItem(prev).(*TextView); ok {
+				if f.isSkippedTextView(prev) || f.IsItemDisabled(prev) || !f.IsItemVisible(prev) {
 					bb(prev - 1)
 					return
 				}
@@ -144,6 +496,15 @@ func NewFormScrollable() *FormScrollable {
 
 	f.upScrollButton.SetFocusable(f).SetClick(onBack).SetDisabled(true)
 
+	f.overflowButton.SetFocusable(f).SetClick(f.showOverflowMenu)
+
+	f.leftScrollButton.SetFocusable(f).SetClick(func() {
+		f.ScrollHorizontallyTo(f.scrollOffsetX - max(f.pageWidth/2, 1))
+	})
+	f.rightScrollButton.SetFocusable(f).SetClick(func() {
+		f.ScrollHorizontallyTo(f.scrollOffsetX + max(f.pageWidth/2, 1))
+	})
+
 	return f
 }
 
@@ -164,6 +525,344 @@ func (f *FormScrollable) SetHorizontal(horizontal bool) *FormScrollable {
 	return f
 }
 
+// SetHorizontalScrolling sets whether horizontal layouts (see SetHorizontal)
+// keep all items and buttons in a single row which scrolls left and right
+// instead of wrapping them into multiple rows. The form follows the focused
+// element; the scroll buttons in the bottom corners and horizontal mouse wheel
+// events scroll explicitly. Elements are only drawn if they fit horizontally.
+func (f *FormScrollable) SetHorizontalScrolling(enabled bool) *FormScrollable {
+	f.horizontalScrolling = enabled
+	return f
+}
+
+// ScrollHorizontallyTo scrolls a horizontally scrollable form to the given
+// column offset. The offset is limited to the scrollable range when the form
+// is drawn.
+func (f *FormScrollable) ScrollHorizontallyTo(column int) *FormScrollable {
+	f.scrollOffsetX = clamp(column, 0, f.maxScrollOffsetX)
+	f.trackFocus = false
+	return f
+}
+
+// GetHorizontalScrollOffset returns the number of columns a horizontally
+// scrollable form is currently scrolled to the right.
+func (f *FormScrollable) GetHorizontalScrollOffset() int {
+	return f.scrollOffsetX
+}
+
+// SetItemDisabled sets whether the form item at the given index is disabled.
+// Disabled items are drawn with a greyed-out label (the foreground color of the
+// button disabled style), are skipped when moving the focus with the keyboard
+// or the scroll buttons, and can't be focused with the mouse. If the item has
+// focus and an application was set with SetApplication, the focus moves on to
+// the next element.
+func (f *FormScrollable) SetItemDisabled(index int, disabled bool) *FormScrollable {
+	item := f.items[index]
+	state := f.state(item)
+	state.disabled = disabled
+	item.SetDisabled(disabled || state.loading)
+	if disabled && item.HasFocus() && f.app != nil {
+		// Move on to the next element (see SetApplication).
+		f.focusedElement, f.lastFinishedKey = index, tcell.KeyTab
+		f.app.SetFocus(f)
+	}
+	return f
+}
+
+// IsItemDisabled returns whether the form item at the given index was disabled
+// with SetItemDisabled.
+func (f *FormScrollable) IsItemDisabled(index int) bool {
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	state, ok := f.itemStates[f.items[index]]
+	return ok && state.disabled
+}
+
+// SetItemVisible sets whether the form item at the given index is shown.
+// Hidden items keep their values and their position in the form but take no
+// space, can't receive focus, and are not validated. If a hidden item has
+// focus and an application was set with SetApplication, the focus moves on to
+// the next element.
+func (f *FormScrollable) SetItemVisible(index int, visible bool) *FormScrollable {
+	item := f.items[index]
+	f.state(item).hidden = !visible
+	if !visible {
+		item.SetRect(0, 0, 0, 0)
+		if item.HasFocus() && f.app != nil {
+			f.focusedElement, f.lastFinishedKey = index, tcell.KeyTab
+			f.app.SetFocus(f)
+		}
+	}
+	return f
+}
+
+// IsItemVisible returns whether the form item at the given index is shown,
+// see SetItemVisible.
+func (f *FormScrollable) IsItemVisible(index int) bool {
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	state, ok := f.itemStates[f.items[index]]
+	return !ok || !state.hidden
+}
+
+// SetTextViewsFocusable sets whether TextView items receive focus when they
+// are clicked and when the scroll buttons move focus, so that their text can
+// be scrolled with the keyboard. Text views which are not scrollable (see
+// AddTextView) are always skipped. By default, text views are skipped by the
+// mouse and the scroll buttons. Tab and Backtab reach scrollable text views
+// either way. A focused text view scrolls with the arrow keys, PgUp, and PgDn
+// until its text ends, then the focus moves on.
+func (f *FormScrollable) SetTextViewsFocusable(focusable bool) *FormScrollable {
+	f.textViewsFocusable = focusable
+	return f
+}
+
+// isSkippedTextView returns whether the item at the given index is a text view
+// which must not receive focus by mouse or scroll button navigation.
+func (f *FormScrollable) isSkippedTextView(index int) bool {
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	item := f.items[index]
+	if _, ok := item.(*TextView); !ok {
+		return false
+	}
+	if !f.textViewsFocusable {
+		return true
+	}
+	state, ok := f.itemStates[item]
+	return ok && state.fixed
+}
+
+// SetColumns sets the number of columns of vertical layouts. With more than
+// one column, items are placed in a grid, filling rows from left to right.
+// The labels of each column are right-aligned so that its fields align. The
+// default is 1.
+func (f *FormScrollable) SetColumns(columns int) *FormScrollable {
+	f.columns = max(columns, 1)
+	return f
+}
+
+// SetButtonsSticky sets whether the buttons of vertical layouts are pinned to
+// the bottom of the form while the items above them scroll. This only applies
+// if all buttons fit into one row; the others are collapsed into the overflow
+// menu.
+func (f *FormScrollable) SetButtonsSticky(sticky bool) *FormScrollable {
+	f.buttonsSticky = sticky
+	return f
+}
+
+// SetItemsReorderable sets whether the user may reorder the form items. If set
+// to true, a drag handle is shown left of each item. Items are moved by
+// dragging their handle with the mouse or by pressing Ctrl+Up/Ctrl+Down while
+// they have focus.
+func (f *FormScrollable) SetItemsReorderable(reorderable bool) *FormScrollable {
+	f.reorderable = reorderable
+	return f
+}
+
+// SetReorderedFunc sets a handler which is called when the user moved the item
+// at index "from" to index "to".
+func (f *FormScrollable) SetReorderedFunc(handler func(from, to int)) *FormScrollable {
+	f.reordered = handler
+	return f
+}
+
+// SetItemsDeletable sets whether the user may delete form items. If set to
+// true, a small control is shown right of each item which removes the item
+// when clicked.
+func (f *FormScrollable) SetItemsDeletable(deletable bool) *FormScrollable {
+	f.deletable = deletable
+	return f
+}
+
+// SetItemDeletedFunc sets a handler which is called after the user deleted
+// the given item which was located at the given index.
+func (f *FormScrollable) SetItemDeletedFunc(handler func(index int, item FormItem)) *FormScrollable {
+	f.deleted = handler
+	return f
+}
+
+// SetClipboardFunc sets a handler which receives text copied by the user.
+// Text in read-only items (e.g. text views) can be selected by dragging the
+// mouse over it. It is copied with a right click or with Ctrl+C (note that the
+// application stops on Ctrl+C unless it is forwarded, see
+// [Application.SetInputCapture]).
+func (f *FormScrollable) SetClipboardFunc(handler func(text string)) *FormScrollable {
+	f.clipboard = handler
+	return f
+}
+
+// GetSelectedText returns the text currently selected with the mouse in a
+// read-only item or an empty string if there is no selection.
+func (f *FormScrollable) GetSelectedText() string {
+	if f.selectionItem < 0 {
+		return ""
+	}
+	return f.selectedText
+}
+
+// CopySelection passes the currently selected text to the clipboard function
+// (see SetClipboardFunc). It returns false if there is nothing to copy.
+func (f *FormScrollable) CopySelection() bool {
+	text := f.GetSelectedText()
+	if text == "" || f.clipboard == nil {
+		return false
+	}
+	f.clipboard(text)
+	return true
+}
+
+// SetApplication sets the application this form is running in. It is needed
+// for features which redraw the form on their own, e.g. momentum scrolling
+// (see also SetLowBandwidthMode).
+func (f *FormScrollable) SetApplication(app *Application) *FormScrollable {
+	f.app = app
+	return f
+}
+
+// SetDragScrolling sets whether the form can be scrolled by dragging its
+// background (an area not covered by any item or button) with the mouse. This
+// is enabled by default. If momentum is true, the form keeps scrolling for a
+// moment after the mouse button was released, slowing down gradually. This
+// requires the application to be set, see SetApplication.
+func (f *FormScrollable) SetDragScrolling(enabled, momentum bool) *FormScrollable {
+	f.dragScrolling = enabled
+	f.dragMomentum = momentum
+	if !enabled || !momentum {
+		f.stopMomentum()
+	}
+	return f
+}
+
+// SetMiddleDragScrolling sets whether the form can be scrolled by dragging it
+// with the middle mouse button, also over its items and buttons, which don't
+// receive middle mouse button events then. The form follows the mouse while
+// the button is held and stops when it is released, without momentum. This
+// is disabled by default.
+func (f *FormScrollable) SetMiddleDragScrolling(enabled bool) *FormScrollable {
+	f.middleDragScrolling = enabled
+	return f
+}
+
+// ScrollTo scrolls the form such that the given row of its content (0 being the
+// first row of the first item) is at the top of the visible area. The focus
+// doesn't change. The form keeps this position until the user moves the focus
+// or enters text.
+func (f *FormScrollable) ScrollTo(row int) *FormScrollable {
+	f.scrollOffset = row
+	f.trackFocus = false
+	return f
+}
+
+// ScrollToBeginning scrolls the form to the top without changing the focus.
+func (f *FormScrollable) ScrollToBeginning() *FormScrollable {
+	return f.ScrollTo(0)
+}
+
+// ScrollToEnd scrolls the form to the bottom without changing the focus.
+func (f *FormScrollable) ScrollToEnd() *FormScrollable {
+	return f.ScrollTo(math.MaxInt32) // Limited during the next Draw.
+}
+
+// GetScrollOffset returns the number of rows the form's content is currently
+// scrolled down.
+func (f *FormScrollable) GetScrollOffset() int {
+	return clamp(f.scrollOffset, 0, f.maxScrollOffset)
+}
+
+// GetItemRow returns the row of the form's content (see ScrollTo) at which the
+// form item with the given index starts, as determined during the last Draw,
+// or -1 if the item is hidden or the form wasn't drawn since it was added.
+func (f *FormScrollable) GetItemRow(index int) int {
+	if index < 0 || index >= len(f.itemRows) {
+		return -1
+	}
+	return f.itemRows[index]
+}
+
+// SetScrolledFunc sets a handler which is called with the new scroll offset
+// (see GetScrollOffset) whenever the form was scrolled, be it by the user or
+// because the focus moved.
+func (f *FormScrollable) SetScrolledFunc(handler func(offset int)) *FormScrollable {
+	f.scrolled = handler
+	return f
+}
+
+// SetFocusChangedFunc sets a handler which is called whenever the focus moved
+// to another item or button, be it by keyboard, mouse, or the scroll buttons.
+// The index counts items first and buttons last (see SetFocus). For buttons,
+// the item is nil.
+func (f *FormScrollable) SetFocusChangedFunc(handler func(index int, item FormItem)) *FormScrollable {
+	f.focusChanged = handler
+	return f
+}
+
+// SetWheelFocus sets whether the mouse wheel moves the focus to the previous
+// or next element (like the scroll buttons) instead of scrolling the form
+// without changing the focus (the default).
+func (f *FormScrollable) SetWheelFocus(moveFocus bool) *FormScrollable {
+	f.wheelFocus = moveFocus
+	return f
+}
+
+// SetWheelScrollLines sets the number of rows the form scrolls per mouse wheel
+// step. The default is 1. Values smaller than 1 are ignored.
+func (f *FormScrollable) SetWheelScrollLines(lines int) *FormScrollable {
+	if lines >= 1 {
+		f.wheelLines = lines
+	}
+	return f
+}
+
+// SetWheelInverted sets whether the direction of the mouse wheel is inverted
+// ("natural scrolling").
+func (f *FormScrollable) SetWheelInverted(inverted bool) *FormScrollable {
+	f.wheelInverted = inverted
+	return f
+}
+
+// SetFocusTrap sets whether the focus is trapped inside the form while it has
+// focus. This is useful when the form is shown like a modal dialog without
+// being wrapped in a [Modal]. Tab and Backtab always cycle through the form's
+// elements. With the trap enabled, mouse clicks outside of the form which
+// reach the form's mouse handler are swallowed and attempts of children to
+// move the focus elsewhere are reverted. Note that the application itself
+// (see [Application.SetFocus]) can still move the focus.
+func (f *FormScrollable) SetFocusTrap(trap bool) *FormScrollable {
+	f.focusTrap = trap
+	return f
+}
+
+// SetScrollBarVisibility sets when the vertical scroll bar between the scroll
+// buttons is shown. The scroll bar's thumb shows the position and the size of
+// the visible portion of the form. Clicking the track scrolls by a page,
+// dragging the thumb scrolls the form accordingly. The default is
+// ScrollBarAuto.
+func (f *FormScrollable) SetScrollBarVisibility(visibility ScrollBarVisibility) *FormScrollable {
+	f.scrollBarVisibility = visibility
+	return f
+}
+
+// SetScrollBarStyle sets the styles of the scroll bar's track and thumb.
+func (f *FormScrollable) SetScrollBarStyle(track, thumb tcell.Style) *FormScrollable {
+	f.scrollBar.trackStyle = track
+	f.scrollBar.thumbStyle = thumb
+	return f
+}
+
+// SetItemDrawHook sets a function which is called right before (phase
+// DrawBefore) and right after (phase DrawAfter) the form item at the given
+// index is drawn. The rectangle is the item's position on screen, taking the
+// scroll offset into account. Only visible items are drawn. This can be used to
+// paint custom adornments aligned with the items.
+func (f *FormScrollable) SetItemDrawHook(hook func(screen tcell.Screen, index int, rect Rect, phase DrawPhase)) *FormScrollable {
+	f.itemDrawHook = hook
+	return f
+}
+
 // SetLabelColor sets the color of the labels.
 func (f *FormScrollable) SetLabelColor(color tcell.Color) *FormScrollable {
 	f.labelColor = color
@@ -223,26 +922,40 @@ func (f *FormScrollable) SetButtonDisabledStyle(style tcell.Style) *FormScrollab
 	return f
 }
 
+// SetToolbar sets the buttons of the toolbar at the given position, replacing
+// any buttons set before. Toolbars are rows of small buttons which are attached
+// to the top or the bottom of the form and which don't scroll with the items.
+// Calling this function without buttons removes the toolbar.
+func (f *FormScrollable) SetToolbar(position ToolbarPosition, buttons ...*ToolButton) *FormScrollable {
+	for _, button := range buttons {
+		button.SetFocusable(f)
+	}
+	if position == ToolbarBottom {
+		f.bottomToolbar = buttons
+	} else {
+		f.topToolbar = buttons
+	}
+	return f
+}
+
+// GetToolbar returns the buttons of the toolbar at the given position.
+func (f *FormScrollable) GetToolbar(position ToolbarPosition) []*ToolButton {
+	if position == ToolbarBottom {
+		return f.bottomToolbar
+	}
+	return f.topToolbar
+}
+
 // SetFocus shifts the focus to the form element with the given index, counting
 // non-button items first and buttons last. Note that this index is only used
 // when the form itself receives focus.
 func (f *FormScrollable) SetFocus(index int) *FormScrollable {
 	var current, future int
-	for itemIndex, item := range f.items {
-		if itemIndex == index {
-			future = itemIndex
-		}
-		if item.HasFocus() {
-			current = itemIndex
-		}
+	if index >= 0 && index < len(f.items)+len(f.buttons) {
+		future = index
 	}
-	for buttonIndex, button := range f.buttons {
-		if buttonIndex+len(f.items) == index {
-			future = buttonIndex + len(f.items)
-		}
-		if button.HasFocus() {
-			current = buttonIndex + len(f.items)
-		}
+	if focused := f.focusIndex(); focused >= 0 {
+		current = focused
 	}
 	var focus func(p Primitive)
 	focus = func(p Primitive) {
@@ -259,8 +972,10 @@ func (f *FormScrollable) SetFocus(index int) *FormScrollable {
 		} else if future >= len(f.items) && future < len(f.items)+len(f.buttons) {
 			focus(f.buttons[future-len(f.items)])
 		}
+		f.focusHint = future
 	}
 	f.focusedElement = future
+	f.notifyFocusChanged()
 	return f
 }
 
@@ -290,7 +1005,7 @@ func (f *FormScrollable) AddTextArea(label, text string, fieldWidth, fieldHeight
 			changed(textArea.GetText())
 		})
 	}
-	f.items = append(f.items, textArea)
+	f.addItem(textArea)
 	return f
 }
 
@@ -310,7 +1025,8 @@ func (f *FormScrollable) AddTextView(label, text string, fieldWidth, fieldHeight
 		SetDynamicColors(dynamicColors).
 		SetScrollable(scrollable).
 		SetText(text)
-	f.items = append(f.items, textArea)
+	f.addItem(textArea)
+	f.state(textArea).fixed = !scrollable
 	return f
 }
 
@@ -320,7 +1036,7 @@ func (f *FormScrollable) AddTextView(label, text string, fieldWidth, fieldHeight
 // accept any text), and an (optional) callback function which is invoked when
 // the input field's text has changed.
 func (f *FormScrollable) AddInputField(label, value string, fieldWidth int, accept func(textToCheck string, lastChar rune) bool, changed func(text string)) *FormScrollable {
-	f.items = append(f.items, NewInputField().
+	f.addItem(NewInputField().
 		SetLabel(label).
 		SetText(value).
 		SetFieldWidth(fieldWidth).
@@ -339,12 +1055,14 @@ func (f *FormScrollable) AddPasswordField(label, value string, fieldWidth int, m
 	if mask == 0 {
 		mask = '*'
 	}
-	f.items = append(f.items, NewInputField().
+	inputField := NewInputField().
 		SetLabel(label).
 		SetText(value).
 		SetFieldWidth(fieldWidth).
 		SetMaskCharacter(mask).
-		SetChangedFunc(changed))
+		SetChangedFunc(changed)
+	f.addItem(inputField)
+	f.state(inputField).secret = true
 	return f
 }
 
@@ -353,7 +1071,7 @@ func (f *FormScrollable) AddPasswordField(label, value string, fieldWidth int, m
 // selected. The initial option may be a negative value to indicate that no
 // option is currently selected.
 func (f *FormScrollable) AddDropDown(label string, options []string, initialOption int, selected func(option string, optionIndex int)) *FormScrollable {
-	f.items = append(f.items, NewDropDown().
+	f.addItem(NewDropDown().
 		SetLabel(label).
 		SetOptions(options, selected).
 		SetCurrentOption(initialOption))
@@ -364,7 +1082,7 @@ func (f *FormScrollable) AddDropDown(label string, options []string, initialOpti
 // and an (optional) callback function which is invoked when the state of the
 // checkbox was changed by the user.
 func (f *FormScrollable) AddCheckbox(label string, checked bool, changed func(checked bool)) *FormScrollable {
-	f.items = append(f.items, NewCheckbox().
+	f.addItem(NewCheckbox().
 		SetLabel(label).
 		SetChecked(checked).
 		SetChangedFunc(changed))
@@ -377,7 +1095,7 @@ func (f *FormScrollable) AddCheckbox(label string, checked bool, changed func(ch
 // interactive and are skipped over in a form. The "width" value may be 0
 // (adjust dynamically) but "height" should generally be a positive value.
 func (f *FormScrollable) AddImage(label string, image image.Image, width, height, colors int) *FormScrollable {
-	f.items = append(f.items, NewImage().
+	f.addItem(NewImage().
 		SetLabel(label).
 		SetImage(image).
 		SetSize(height, width).
@@ -393,6 +1111,20 @@ func (f *FormScrollable) AddButton(label string, selected func()) *FormScrollabl
 	return f
 }
 
+// SetButtonExitFunc sets a handler which is called when the user leaves the
+// button at the given index with a key (e.g. Tab, Backtab, or Escape), after
+// the form moved the focus. Unlike SetExitFunc on the button itself, the
+// handler isn't replaced when the form receives focus. A nil handler removes
+// it.
+func (f *FormScrollable) SetButtonExitFunc(index int, handler func(key tcell.Key)) *FormScrollable {
+	if handler == nil {
+		delete(f.buttonExits, f.buttons[index])
+	} else {
+		f.buttonExits[f.buttons[index]] = handler
+	}
+	return f
+}
+
 // GetButton returns the button at the specified 0-based index. Note that
 // buttons have been specially prepared for this form and modifying some of
 // their attributes may have unintended side effects.
@@ -400,13 +1132,40 @@ func (f *FormScrollable) GetButton(index int) *Button {
 	return f.buttons[index]
 }
 
+// GetButtonChecked is like GetButton but returns an error wrapping
+// ErrIndexOutOfRange instead of panicking if there is no button at the given
+// index.
+func (f *FormScrollable) GetButtonChecked(index int) (*Button, error) {
+	if err := checkIndex("button", index, len(f.buttons)); err != nil {
+		return nil, err
+	}
+	return f.buttons[index], nil
+}
+
 // RemoveButton removes the button at the specified position, starting with 0
 // for the button that was added first.
 func (f *FormScrollable) RemoveButton(index int) *FormScrollable {
+	delete(f.validButtons, f.buttons[index])
+	delete(f.buttonExits, f.buttons[index])
+	delete(f.buttonShortcuts, f.buttons[index])
+	if f.buttons[index] == f.submitButton {
+		f.submitButton = nil
+	}
 	f.buttons = append(f.buttons[:index], f.buttons[index+1:]...)
 	return f
 }
 
+// RemoveButtonChecked is like RemoveButton but returns an error wrapping
+// ErrIndexOutOfRange instead of panicking if there is no button at the given
+// index.
+func (f *FormScrollable) RemoveButtonChecked(index int) error {
+	if err := checkIndex("button", index, len(f.buttons)); err != nil {
+		return err
+	}
+	f.RemoveButton(index)
+	return nil
+}
+
 // GetButtonCount returns the number of buttons in this form.
 func (f *FormScrollable) GetButtonCount() int {
 	return len(f.buttons)
@@ -428,6 +1187,14 @@ func (f *FormScrollable) GetButtonIndex(label string) int {
 // specified.
 func (f *FormScrollable) Clear(includeButtons bool) *FormScrollable {
 	f.items = nil
+	f.itemStates = make(map[FormItem]*itemState)
+	f.labels = make(map[FormItem]*itemLabel)
+	f.maxLabelWidth, f.maxLabelStale = 0, false
+	f.bindings = nil
+	f.configStores = nil
+	f.links = nil
+	f.ClearUndoHistory()
+	f.stopLoadingAnimation()
 	if includeButtons {
 		f.ClearButtons()
 	}
@@ -438,6 +1205,10 @@ func (f *FormScrollable) Clear(includeButtons bool) *FormScrollable {
 // ClearButtons removes all buttons from the form.
 func (f *FormScrollable) ClearButtons() *FormScrollable {
 	f.buttons = nil
+	f.validButtons = nil
+	f.buttonExits = make(map[*Button]func(tcell.Key))
+	f.buttonShortcuts = make(map[*Button]rune)
+	f.submitButton = nil
 	return f
 }
 
@@ -451,8 +1222,14 @@ func (f *FormScrollable) ClearButtons() *FormScrollable {
 //   - The background color
 //   - The field text color
 //   - The field background color
+//
+// The item's "finished" handler is replaced by the form's when the form is
+// focused. Use SetItemFinishedFunc to handle the keys with which the user
+// leaves the item before the form does, SetItemExitFunc to handle them after
+// the form moved the focus, and SetButtonExitFunc for the "exit" handlers of
+// buttons.
 func (f *FormScrollable) AddFormItem(item FormItem) *FormScrollable {
-	f.items = append(f.items, item)
+	f.addItem(item)
 	return f
 }
 
@@ -469,14 +1246,98 @@ func (f *FormScrollable) GetFormItem(index int) FormItem {
 	return f.items[index]
 }
 
+// GetFormItemChecked is like GetFormItem but returns an error wrapping
+// ErrIndexOutOfRange instead of panicking if there is no item at the given
+// index.
+func (f *FormScrollable) GetFormItemChecked(index int) (FormItem, error) {
+	if err := checkIndex("form item", index, len(f.items)); err != nil {
+		return nil, err
+	}
+	return f.items[index], nil
+}
+
 // RemoveFormItem removes the form element at the given position, starting with
 // index 0. Elements are referenced in the order they were added. Buttons are
 // not included.
 func (f *FormScrollable) RemoveFormItem(index int) *FormScrollable {
+	delete(f.itemStates, f.items[index])
+	f.forgetLabel(f.items[index])
+	f.unbindItem(f.items[index])
+	f.unlinkItem(f.items[index])
+	if !f.isLoading() {
+		f.stopLoadingAnimation()
+	}
 	f.items = append(f.items[:index], f.items[index+1:]...)
+	if f.focusedElement > index {
+		f.focusedElement--
+	}
+	return f
+}
+
+// RemoveFormItemChecked is like RemoveFormItem but returns an error wrapping
+// ErrIndexOutOfRange instead of panicking if there is no item at the given
+// index.
+func (f *FormScrollable) RemoveFormItemChecked(index int) error {
+	if err := checkIndex("form item", index, len(f.items)); err != nil {
+		return err
+	}
+	f.RemoveFormItem(index)
+	return nil
+}
+
+// checkIndex returns an error wrapping ErrIndexOutOfRange if the given index of
+// an element of the given kind is not within [0, count).
+func checkIndex(kind string, index, count int) error {
+	if index < 0 || index >= count {
+		return fmt.Errorf("%w: no %s at index %d (count %d)", ErrIndexOutOfRange, kind, index, count)
+	}
+	return nil
+}
+
+// MoveFormItem moves the form item at index "from" to index "to", shifting the
+// items in between. Buttons are not included.
+func (f *FormScrollable) MoveFormItem(from, to int) *FormScrollable {
+	if from == to {
+		return f
+	}
+	item := f.items[from]
+	f.items = append(f.items[:from], f.items[from+1:]...)
+	f.items = append(f.items[:to], append([]FormItem{item}, f.items[to:]...)...)
+
+	// The focus stays with the same element.
+	switch {
+	case f.focusedElement == from:
+		f.focusedElement = to
+	case from < f.focusedElement && to >= f.focusedElement:
+		f.focusedElement--
+	case from > f.focusedElement && to <= f.focusedElement && f.focusedElement < len(f.items):
+		f.focusedElement++
+	}
 	return f
 }
 
+// GetMaxLabelWidth returns the screen width of the longest label of all form
+// items whose labels are shown left of their fields (see SetLabelPlacement),
+// not including the space between labels and fields, or the fixed label width
+// if it was set with SetLabelWidth and there is such a label. In a single
+// column, the fields start this many cells plus one right of the labels,
+// e.g. for aligning adjacent panels with the form's label column. The width is
+// kept up to date when items are added, removed, or relabelled by the form.
+// Labels changed with the items' own SetLabel functions are measured when the
+// form is drawn next.
+func (f *FormScrollable) GetMaxLabelWidth() int {
+	if f.maxLabelStale {
+		f.maxLabelWidth, f.maxLabelStale = 0, false
+		for _, item := range f.items {
+			f.maxLabelWidth = max(f.maxLabelWidth, f.measureLabel(item).columnWidth)
+		}
+	}
+	if f.maxLabelWidth > 0 && f.fixedLabelWidth > 0 {
+		return f.fixedLabelWidth
+	}
+	return f.maxLabelWidth
+}
+
 // GetFormItemByLabel returns the first form element with the given label. If
 // no such element is found, nil is returned. Buttons are not searched and will
 // therefore not be returned.
@@ -501,6 +1362,17 @@ func (f *FormScrollable) GetFormItemIndex(label string) int {
 	return -1
 }
 
+// itemIndex returns the index of the given form item or -1 if the item is not
+// part of the form.
+func (f *FormScrollable) itemIndex(item FormItem) int {
+	for index, formItem := range f.items {
+		if formItem == item {
+			return index
+		}
+	}
+	return -1
+}
+
 // GetFocusedItemIndex returns the indices of the form element or button which
 // currently has focus. If they don't, -1 is returned respectively.
 func (f *FormScrollable) GetFocusedItemIndex() (formItem, button int) {
@@ -521,101 +1393,277 @@ func (f *FormScrollable) SetCancelFunc(callback func()) *FormScrollable {
 	return f
 }
 
+// cancelForm calls the cancel handler, after asking for confirmation if
+// necessary (see SetCancelConfirm).
+func (f *FormScrollable) cancelForm() {
+	switch {
+	case f.cancel == nil:
+	case f.cancelConfirm != "" && f.IsDirty():
+		f.showConfirm(f.cancelConfirm, "Discard", "Keep editing", f.cancel)
+	default:
+		f.cancel()
+	}
+}
+
+// SetCancelConfirm sets a question, e.g. "Discard changes?", which is shown in
+// a modal dialog when the user hits Escape while the form is dirty (see
+// IsDirty). The handler set with SetCancelFunc is only called if the user
+// confirms. An empty message disables the confirmation, which is the default.
+func (f *FormScrollable) SetCancelConfirm(message string) *FormScrollable {
+	f.cancelConfirm = message
+	return f
+}
+
+// columnGap is the number of cells between the columns of grid layouts.
+const columnGap = 2
+
 // Draw draws this primitive onto the screen.
 func (f *FormScrollable) Draw(screen tcell.Screen) {
 	f.Box.DrawForSubclass(screen, f)
+	f.markDrawn()
+
+	// The popup is drawn above everything else, including the focused item
+	// which is drawn last.
+	defer func() {
+		f.formattersDrawn()
+		if f.popup != nil {
+			f.popup.Draw(screen)
+		}
+	}()
 
 	// Determine the actual item that has focus.
 	if index := f.focusIndex(); index >= 0 {
 		f.focusedElement = index
 	}
+	f.notifyFocusChanged()
+	f.updateLinks()
+	f.captureMissingDefaults()
+	f.notifyDirtyChanged()
 
 	// Determine the dimensions.
 	x, y, width, height := f.GetInnerRect()
+
+	// Toolbars take a row each and don't scroll.
+	if len(f.topToolbar) > 0 {
+		drawToolbar(screen, f.topToolbar, x, y, width)
+		y++
+		height--
+	}
+	if len(f.bottomToolbar) > 0 {
+		drawToolbar(screen, f.bottomToolbar, x, y+height-1, width)
+		height--
+	}
+
+	// Banners take the rows below the top toolbar.
+	if rows := f.drawBanners(screen, x, y, width, height/2); rows > 0 {
+		y += rows
+		height -= rows
+	}
+
+	// The help bar takes the row above the bottom toolbar.
+	if f.helpBar {
+		f.drawHelpBar(screen, x, y+height-1, width)
+		height--
+	}
+
+	// Sticky buttons take the bottom row, separated by an empty row, and
+	// don't scroll.
+	sticky := f.buttonsSticky && !f.horizontal && len(f.buttons) > 0
+	stickyY := y + height - 1
+	if sticky {
+		height -= 2
+	}
+
 	topLimit := y
 	bottomLimit := y + height
 	rightLimit := x + width
 	startX := x
 
-	// Find the longest label.
-	var maxLabelWidth int
+	// Find the longest label, measuring the labels which were changed with
+	// the items' own SetLabel functions.
 	for _, item := range f.items {
-		labelWidth := TaggedStringWidth(item.GetLabel())
-		if labelWidth > maxLabelWidth {
-			maxLabelWidth = labelWidth
-		}
+		f.measureLabel(item)
+	}
+	maxLabelWidth := f.GetMaxLabelWidth() + 1 // Add one space.
+
+	// Reorderable items have a drag handle in a gutter left of them, deletable
+	// items have a delete control right of them, after the revert control of
+	// dirty items and before the secret control of secret items.
+	var gutter, controls int
+	if f.reorderable {
+		gutter = 2
+	}
+	if f.revertControls {
+		controls += 2
+	}
+	if f.deletable {
+		controls += 2
+	}
+	if f.secretProvider != nil {
+		controls += 2
 	}
-	maxLabelWidth++ // Add one space.
 
-	// Calculate positions of form items.
-	type position struct{ x, y, width, height int }
+	// Calculate positions of form items. Messages (e.g. validation errors) are
+	// shown in rows below an item's field.
+	type position struct {
+		x, y, width, height int
+		labelWidth          int
+		labelAbove          bool // The first row is the label's.
+		messages            []itemMessage
+	}
 	positions := make([]position, len(f.items)+len(f.buttons))
 	var (
 		focusedPosition position
 		lineHeight      = 1
 	)
+	horizontalScroll := f.horizontal && f.horizontalScrolling
+
+	// In grid layouts, items are placed in columns. Labels are right-aligned
+	// per column so that the fields of a column align.
+	grid := !f.horizontal && f.columns > 1
+	var (
+		columnWidth, column, rowHeight int
+		columnLabelWidths              []int
+	)
+	if grid {
+		columnWidth = (width - (f.columns-1)*columnGap) / f.columns
+		columnLabelWidths = make([]int, f.columns)
+		var placed int
+		for index, item := range f.items {
+			if !f.IsItemVisible(index) {
+				continue
+			}
+			if labelWidth := f.leftLabelWidth(item); labelWidth > 0 {
+				columnLabelWidths[placed%f.columns] = max(columnLabelWidths[placed%f.columns], labelWidth+1)
+			}
+			placed++
+		}
+	}
+
 	for index, item := range f.items {
-		// Calculate the space needed.
-		labelWidth := TaggedStringWidth(item.GetLabel())
+		// Hidden items take no space.
+		if !f.IsItemVisible(index) {
+			continue
+		}
+
+		// Calculate the space needed. Fields whose labels are not left of
+		// them start where the labels would.
+		placement := f.itemLabelPlacement(item)
+		labelWidth := f.labelWidth(item)
+		if f.fixedLabelWidth > 0 {
+			labelWidth = min(labelWidth, f.fixedLabelWidth)
+		}
 		var itemWidth int
 		if f.horizontal {
 			fieldWidth := item.GetFieldWidth()
 			if fieldWidth <= 0 {
 				fieldWidth = DefaultFormFieldWidth
 			}
-			labelWidth++
-			itemWidth = labelWidth + fieldWidth
+			switch placement {
+			case LabelLeft:
+				labelWidth++
+				itemWidth = labelWidth + fieldWidth
+			case LabelAbove:
+				itemWidth = max(labelWidth, fieldWidth)
+				labelWidth = 0
+			default:
+				itemWidth, labelWidth = fieldWidth, 0
+			}
+		} else if grid {
+			var shift int
+			if placement == LabelLeft {
+				labelWidth++
+				shift = columnLabelWidths[column] - labelWidth
+			} else {
+				labelWidth = 0
+			}
+			x = startX + column*(columnWidth+columnGap) + shift
+			itemWidth = columnWidth - gutter - controls - shift
 		} else {
 			// We want all fields to align vertically.
 			labelWidth = maxLabelWidth
-			itemWidth = width
+			if placement != LabelLeft {
+				labelWidth = 0
+			}
+			itemWidth = width - gutter - controls
 		}
 		itemHeight := item.GetFieldHeight()
 		if itemHeight <= 0 {
 			itemHeight = DefaultFormFieldHeight
 		}
+		labelAbove := placement == LabelAbove
+		if labelAbove {
+			itemHeight++
+		}
+		messages := f.itemMessages(item)
+		rowsHeight := itemHeight + len(messages)
 
 		// Advance to next line if there is no space.
-		if f.horizontal && x+labelWidth+1 >= rightLimit {
+		if f.horizontal && !horizontalScroll && x+gutter+labelWidth+controls+1 >= rightLimit {
 			x = startX
 			y += lineHeight + 1
-			lineHeight = itemHeight
+			lineHeight = rowsHeight
 		}
 
 		// Update line height.
-		if itemHeight > lineHeight {
-			lineHeight = itemHeight
+		if rowsHeight > lineHeight {
+			lineHeight = rowsHeight
 		}
 
 		// Adjust the item's attributes.
-		if x+itemWidth >= rightLimit {
-			itemWidth = rightLimit - x
+		if !horizontalScroll && x+gutter+itemWidth+controls >= rightLimit {
+			itemWidth = rightLimit - x - gutter - controls
 		}
-		item.SetFormAttributes(
-			labelWidth,
-			f.labelColor,
-			f.GetBackgroundColor(),
-			f.fieldTextColor,
-			f.fieldBackgroundColor,
-		)
+		labelColor, fieldTextColor, fieldBackgroundColor := f.itemColors(item)
+		f.layoutLabel(item, labelWidth)
+		f.withShownLabel(item, func() {
+			item.SetFormAttributes(
+				labelWidth,
+				labelColor,
+				f.GetBackgroundColor(),
+				fieldTextColor,
+				fieldBackgroundColor,
+			)
+		})
+		f.applyItemStyle(item, labelColor, fieldTextColor, fieldBackgroundColor)
+		f.applyPlaceholderStyle(item, fieldBackgroundColor)
 
 		// Save position.
-		positions[index].x = x
+		positions[index].x = x + gutter
 		positions[index].y = y
 		positions[index].width = itemWidth
 		positions[index].height = itemHeight
+		positions[index].labelWidth = labelWidth
+		positions[index].labelAbove = labelAbove
+		positions[index].messages = messages
 		if item.HasFocus() {
 			focusedPosition = positions[index]
 		}
 
 		// Advance to next item.
 		if f.horizontal {
-			x += itemWidth + f.itemPadding
+			x += gutter + itemWidth + controls + f.itemPadding
+		} else if grid {
+			rowHeight = max(rowHeight, rowsHeight)
+			column++
+			if column == f.columns {
+				y += rowHeight + f.itemPadding
+				column, rowHeight = 0, 0
+			}
 		} else {
-			y += itemHeight + f.itemPadding
+			y += rowsHeight + f.itemPadding
+		}
+	}
+	if grid {
+		if column > 0 {
+			y += rowHeight + f.itemPadding
 		}
+		x = startX
 	}
 
+	// Buttons may only be enabled if the form is valid.
+	formValid := f.isValid()
+
 	// How wide are the buttons?
 	buttonWidths := make([]int, len(f.buttons))
 	buttonsWidth := 0
@@ -626,6 +1674,26 @@ func (f *FormScrollable) Draw(screen tcell.Screen) {
 	}
 	buttonsWidth--
 
+	// In vertical layouts, buttons which don't fit into the row are collapsed
+	// into the overflow menu.
+	f.overflowIndex = -1
+	var overflowPosition position
+	if !f.horizontal && len(f.buttons) > 1 && x+buttonsWidth >= rightLimit {
+		overflowWidth := TaggedStringWidth(f.overflowButton.GetLabel()) + 4
+		f.overflowIndex = 0
+		buttonsWidth = overflowWidth
+		for index, w := range buttonWidths {
+			if x+buttonsWidth+w+1 >= rightLimit {
+				break
+			}
+			f.overflowIndex = index + 1
+			buttonsWidth += w + 1
+		}
+		if f.overflowIndex >= len(f.buttons) {
+			f.overflowIndex = -1 // Can't happen, but be safe.
+		}
+	}
+
 	// Where do we place them?
 	if !f.horizontal && x+buttonsWidth < rightLimit {
 		if f.buttonsAlign == AlignRight {
@@ -639,12 +1707,20 @@ func (f *FormScrollable) Draw(screen tcell.Screen) {
 			y++
 		}
 	}
+	if sticky {
+		y = stickyY
+	}
 
 	// Calculate positions of buttons.
 	for index, button := range f.buttons {
+		if f.isButtonCollapsed(index) {
+			break // The rest is in the overflow menu.
+		}
 		space := rightLimit - x
 		buttonWidth := buttonWidths[index]
-		if f.horizontal {
+		if horizontalScroll {
+			space = buttonWidth
+		} else if f.horizontal {
 			if space < buttonWidth-4 {
 				x = startX
 				y += lineHeight + 1
@@ -659,6 +1735,9 @@ func (f *FormScrollable) Draw(screen tcell.Screen) {
 		if buttonWidth > space {
 			buttonWidth = space
 		}
+		if f.buttonRequiresValid(button) {
+			button.SetDisabled(!formValid)
+		}
 		button.SetStyle(f.buttonStyle).
 			SetActivatedStyle(f.buttonActivatedStyle).
 			SetDisabledStyle(f.buttonDisabledStyle)
@@ -669,69 +1748,310 @@ func (f *FormScrollable) Draw(screen tcell.Screen) {
 		positions[buttonIndex].width = buttonWidth
 		positions[buttonIndex].height = 1
 
-		if button.HasFocus() {
+		if button.HasFocus() && !sticky {
 			focusedPosition = positions[buttonIndex]
 		}
 
 		x += buttonWidth + 1
 	}
-
-	// Determine vertical offset based on the position of the focused item.
-	var offset int
-	if focusedPosition.y+focusedPosition.height > bottomLimit {
-		offset = focusedPosition.y + focusedPosition.height - bottomLimit
-		if focusedPosition.y-offset < topLimit {
-			offset = focusedPosition.y - topLimit
+	if f.overflowIndex >= 0 {
+		overflowPosition = position{x: x, y: y, width: TaggedStringWidth(f.overflowButton.GetLabel()) + 4, height: 1}
+		if f.isButtonCollapsed(f.focusIndex()-len(f.items)) && !sticky {
+			focusedPosition = overflowPosition
 		}
 	}
 
+	// Determine vertical offset. Unless the user scrolled explicitly, it
+	// follows the focused element.
+	if index := f.focusIndex(); index != f.lastFocusIndex {
+		f.lastFocusIndex = index
+		f.trackFocus = true
+	}
+	if f.trackFocus && focusedPosition.height > bottomLimit-topLimit {
+		// Elements taller than the visible area keep filling it.
+		f.scrollOffset = clamp(f.scrollOffset, focusedPosition.y-topLimit, focusedPosition.y+focusedPosition.height-bottomLimit)
+	} else if f.trackFocus && focusedPosition.height > 0 {
+		if focusedPosition.y+focusedPosition.height-f.scrollOffset > bottomLimit {
+			f.scrollOffset = focusedPosition.y + focusedPosition.height - bottomLimit
+		}
+		if focusedPosition.y-f.scrollOffset < topLimit {
+			f.scrollOffset = focusedPosition.y - topLimit
+		}
+	}
+	contentBottom := overflowPosition.y + overflowPosition.height
+	if sticky {
+		contentBottom = 0
+	}
+	for index, p := range positions {
+		if sticky && index >= len(f.items) {
+			break
+		}
+		if p.height > 0 && p.y+p.height+len(p.messages) > contentBottom {
+			contentBottom = p.y + p.height + len(p.messages)
+		}
+	}
+	f.itemRows = f.itemRows[:0]
+	for index := range f.items {
+		row := -1
+		if positions[index].height > 0 {
+			row = positions[index].y - topLimit
+		}
+		f.itemRows = append(f.itemRows, row)
+	}
+	f.maxScrollOffset = max(contentBottom-bottomLimit, 0)
+	f.pageTop, f.pageHeight = topLimit, bottomLimit-topLimit
+	f.scrollOffset = clamp(f.scrollOffset, 0, f.maxScrollOffset)
+	offset := f.scrollOffset
+	if offset != f.scrolledOffset {
+		f.scrolledOffset = offset
+		if f.scrolled != nil {
+			f.scrolled(offset)
+		}
+	}
+
+	// Determine horizontal offset. Like the vertical one, it follows the
+	// focused element unless the user scrolled explicitly.
+	if horizontalScroll {
+		if f.trackFocus && focusedPosition.height > 0 {
+			if focusedPosition.x+focusedPosition.width+controls-f.scrollOffsetX > rightLimit {
+				f.scrollOffsetX = focusedPosition.x + focusedPosition.width + controls - rightLimit
+			}
+			if focusedPosition.x-gutter-f.scrollOffsetX < startX {
+				f.scrollOffsetX = focusedPosition.x - gutter - startX
+			}
+		}
+		contentRight := overflowPosition.x + overflowPosition.width
+		for _, p := range positions {
+			if p.height > 0 && p.x+p.width+controls > contentRight {
+				contentRight = p.x + p.width + controls
+			}
+		}
+		f.maxScrollOffsetX = max(contentRight-rightLimit, 0)
+		f.pageWidth = rightLimit - startX
+		f.scrollOffsetX = clamp(f.scrollOffsetX, 0, f.maxScrollOffsetX)
+		for index := range positions {
+			positions[index].x -= f.scrollOffsetX
+		}
+		overflowPosition.x -= f.scrollOffsetX
+	} else {
+		f.scrollOffsetX, f.maxScrollOffsetX = 0, 0
+	}
+
+	// isHidden returns whether an element at the given position doesn't fit
+	// horizontally into the visible area.
+	isHidden := func(p position, gutter, controls int) bool {
+		return horizontalScroll && (p.x-gutter < startX || p.x+p.width+controls > rightLimit)
+	}
+
+	// Draw buttons. Sticky buttons don't scroll and are drawn after all items
+	// (including the focused one) so that no item covers them.
+	buttonsOffset, buttonsTop, buttonsBottom := offset, topLimit, bottomLimit
+	if sticky {
+		buttonsOffset, buttonsTop, buttonsBottom = 0, stickyY, stickyY+1
+	}
+	drawButtons := func() {
+		if sticky {
+			style := tcell.StyleDefault.Background(f.GetBackgroundColor())
+			for row := stickyY - 1; row <= stickyY; row++ {
+				for column := startX; column < rightLimit; column++ {
+					screen.SetContent(column, row, ' ', nil, style)
+				}
+			}
+		}
+		for index, button := range f.buttons {
+			// Set position.
+			buttonIndex := index + len(f.items)
+			y := positions[buttonIndex].y - buttonsOffset
+			height := positions[buttonIndex].height
+			button.SetRect(positions[buttonIndex].x, y, positions[buttonIndex].width, height)
+
+			// Is this button visible?
+			if f.isButtonCollapsed(index) || y+height <= buttonsTop || y >= buttonsBottom || isHidden(positions[buttonIndex], 0, 0) {
+				continue
+			}
+
+			// Draw button.
+			f.withShortcutLabel(button, func() { button.Draw(screen) })
+		}
+
+		// Draw the overflow menu button. It looks focused when one of the
+		// collapsed buttons has focus.
+		if f.overflowIndex >= 0 {
+			y := overflowPosition.y - buttonsOffset
+			f.overflowButton.SetRect(overflowPosition.x, y, overflowPosition.width, overflowPosition.height)
+			style := f.buttonStyle
+			if f.isButtonCollapsed(f.focusIndex() - len(f.items)) {
+				style = f.buttonActivatedStyle
+			}
+			f.overflowButton.SetStyle(style)
+			if y+overflowPosition.height > buttonsTop && y < buttonsBottom {
+				f.overflowButton.Draw(screen)
+			}
+		} else {
+			f.overflowButton.SetRect(0, 0, 0, 0)
+		}
+	}
+	if sticky {
+		defer drawButtons()
+	}
+
+	// Highlight the selected text after all items were drawn.
+	defer f.drawSelection(screen, topLimit, bottomLimit)
+
 	// Draw items.
 	for index, item := range f.items {
-		// Set position.
+		// Draw the label above the item.
 		y := positions[index].y - offset
 		height := positions[index].height
+		if positions[index].labelAbove {
+			if y >= topLimit && y < bottomLimit && !isHidden(positions[index], gutter, controls) {
+				labelColor, _, _ := f.itemColors(item)
+				Print(screen, underlineShortcut(item.GetLabel(), f.itemShortcut(item))+f.requiredMarkerOf(item), positions[index].x, y, positions[index].width, AlignLeft, labelColor)
+			}
+			y++
+			height--
+		}
+
+		// Set position.
 		item.SetRect(positions[index].x, y, positions[index].width, height)
+		if item, ok := item.(viewportItem); ok {
+			item.setViewport(topLimit, bottomLimit)
+		}
+
+		// Draw the item's messages.
+		messageX := positions[index].x
+		if !f.horizontal {
+			messageX += positions[index].labelWidth
+		}
+		for row, message := range positions[index].messages {
+			if messageY := y + height + row; messageY >= topLimit && messageY < bottomLimit {
+				style := tcell.StyleDefault.Background(f.GetBackgroundColor()).Foreground(message.color).Dim(message.dim)
+				printStyled(screen, Escape(message.text), messageX, messageY, positions[index].x+positions[index].width-messageX, AlignLeft, style)
+			}
+		}
 
 		// Is this item visible?
-		if y+height <= topLimit || y >= bottomLimit {
+		if height == 0 || y+height <= topLimit || y >= bottomLimit || isHidden(positions[index], gutter, controls) {
 			continue
 		}
 
-		// Draw items with focus last (in case of overlaps).
-		if item.HasFocus() {
-			defer item.Draw(screen)
-		} else {
-			item.Draw(screen)
+		// Draw the drag handle.
+		if f.reorderable && y >= topLimit {
+			style := tcell.StyleDefault.Background(f.GetBackgroundColor()).Foreground(f.labelColor)
+			if index == f.dragItem {
+				style = style.Reverse(true)
+			}
+			screen.SetContent(positions[index].x-gutter, y, '\u2261', nil, style)
 		}
-	}
 
-	// Draw buttons.
-	for index, button := range f.buttons {
-		// Set position.
-		buttonIndex := index + len(f.items)
-		y := positions[buttonIndex].y - offset
-		height := positions[buttonIndex].height
-		button.SetRect(positions[buttonIndex].x, y, positions[buttonIndex].width, height)
+		// Draw the marker of modified items.
+		if f.modifiedMarker != 0 && y >= topLimit && f.isModified(item) {
+			style := tcell.StyleDefault.Background(f.GetBackgroundColor()).Foreground(f.modifiedColor)
+			screen.SetContent(positions[index].x-1, y, f.modifiedMarker, nil, style)
+		}
 
-		// Is this button visible?
-		if y+height <= topLimit || y >= bottomLimit {
-			continue
+		// Draw the revert, delete, and secret controls.
+		controlX := positions[index].x + positions[index].width + 1
+		if f.revertControls && y >= topLimit {
+			if f.isDirty(item) {
+				style := tcell.StyleDefault.Background(f.GetBackgroundColor()).Foreground(f.labelColor)
+				screen.SetContent(controlX, y, '\u21ba', nil, style)
+			}
+			controlX += 2
+		}
+		if f.deletable && y >= topLimit {
+			style := tcell.StyleDefault.Background(f.GetBackgroundColor()).Foreground(f.labelColor)
+			screen.SetContent(controlX, y, '\u2715', nil, style)
+			controlX += 2
+		}
+		if f.secretProvider != nil && y >= topLimit && f.isSecret(item) {
+			style := tcell.StyleDefault.Background(f.GetBackgroundColor()).Foreground(f.labelColor)
+			screen.SetContent(controlX, y, '\u26bf', nil, style)
 		}
 
-		// Draw button.
-		button.Draw(screen)
+		// Draw items with focus last (in case of overlaps).
+		rect := Rect{X: positions[index].x, Y: y, Width: positions[index].width, Height: height}
+		if state, ok := f.itemStates[item]; ok && state.loading {
+			f.drawLoading(screen, item, rect, positions[index].labelWidth)
+		} else if item.HasFocus() {
+			defer f.drawItem(screen, index, item, rect)
+		} else {
+			f.drawItem(screen, index, item, rect)
+		}
+	}
+
+	if !sticky {
+		drawButtons()
 	}
 
 	const scrollBtnWidth = 1
 	const scrollBtnHeight = 1
 
-	_, _, ww, hh := f.GetRect()
+	xx, yy, ww, hh := f.GetRect()
 
-	f.upScrollButton.SetRect(ww-scrollBtnWidth, 0, scrollBtnWidth, scrollBtnHeight)
+	f.upScrollButton.SetRect(xx+ww-scrollBtnWidth, yy, scrollBtnWidth, scrollBtnHeight)
 	f.upScrollButton.Draw(screen)
 
-	f.downScrollButton.SetRect(ww-scrollBtnWidth, hh-1, scrollBtnWidth, scrollBtnHeight)
+	f.downScrollButton.SetRect(xx+ww-scrollBtnWidth, yy+hh-1, scrollBtnWidth, scrollBtnHeight)
 	f.downScrollButton.Draw(screen)
+
+	// Horizontally scrollable forms have left and right scroll buttons in the
+	// bottom corners.
+	if horizontalScroll && f.maxScrollOffsetX > 0 {
+		f.leftScrollButton.SetDisabled(f.scrollOffsetX == 0)
+		f.leftScrollButton.SetRect(xx, yy+hh-1, scrollBtnWidth, scrollBtnHeight)
+		f.leftScrollButton.Draw(screen)
+		f.rightScrollButton.SetDisabled(f.scrollOffsetX >= f.maxScrollOffsetX)
+		f.rightScrollButton.SetRect(xx+ww-2*scrollBtnWidth, yy+hh-1, scrollBtnWidth, scrollBtnHeight)
+		f.rightScrollButton.Draw(screen)
+	} else {
+		f.leftScrollButton.SetRect(0, 0, 0, 0)
+		f.rightScrollButton.SetRect(0, 0, 0, 0)
+	}
+
+	// Draw the scroll bar between the scroll buttons.
+	visibleHeight := bottomLimit - topLimit
+	f.scrollBar.update(xx+ww-scrollBtnWidth, yy+scrollBtnHeight, hh-2*scrollBtnHeight, contentBottom-topLimit, visibleHeight, offset)
+	switch f.scrollBarVisibility {
+	case ScrollBarAlways:
+		f.scrollBar.draw(screen)
+	case ScrollBarAuto:
+		if f.scrollBar.scrollable > 0 {
+			f.scrollBar.draw(screen)
+		} else {
+			f.scrollBar.height = 0 // Hidden, don't react to the mouse.
+		}
+	default:
+		f.scrollBar.height = 0
+	}
+}
+
+// drawToolbar draws the given tool buttons from left to right into the row at
+// the given position. Buttons which don't fit are hidden.
+func drawToolbar(screen tcell.Screen, buttons []*ToolButton, x, y, width int) {
+	rightLimit := x + width
+	for _, button := range buttons {
+		buttonWidth := button.GetWidth()
+		if x+buttonWidth > rightLimit {
+			x = rightLimit
+			button.SetRect(0, 0, 0, 0)
+			continue
+		}
+		button.SetRect(x, y, buttonWidth, 1)
+		button.Draw(screen)
+		x += buttonWidth + 1
+	}
+}
+
+// drawItem draws the given item, surrounded by calls to the item draw hook.
+func (f *FormScrollable) drawItem(screen tcell.Screen, index int, item FormItem, rect Rect) {
+	if f.itemDrawHook != nil {
+		f.itemDrawHook(screen, index, rect, DrawBefore)
+	}
+	f.withShownLabel(item, func() { item.Draw(screen) })
+	if f.itemDrawHook != nil {
+		f.itemDrawHook(screen, index, rect, DrawAfter)
+	}
 }
 
 // Focus is called by the application when the primitive receives focus.
@@ -751,6 +2071,9 @@ func (f *FormScrollable) Focus(delegate func(p Primitive)) {
 		switch key {
 		case tcell.KeyTab, tcell.KeyEnter:
 			f.focusedElement++
+			if f.focusedElement >= len(f.items)+len(f.buttons) && f.leaveGroup(true, delegate) {
+				return
+			}
 			if f.focusedElement >= len(f.items)+len(f.buttons)-1 {
 				f.downScrollButton.SetDisabled(true)
 			}
@@ -758,6 +2081,9 @@ func (f *FormScrollable) Focus(delegate func(p Primitive)) {
 			f.Focus(delegate)
 		case tcell.KeyBacktab:
 			f.focusedElement--
+			if f.focusedElement < 0 && f.leaveGroup(false, delegate) {
+				return
+			}
 			if f.focusedElement == 0 {
 				f.upScrollButton.SetDisabled(true)
 				if f.GetFormItemCount() == 1 {
@@ -773,8 +2099,11 @@ func (f *FormScrollable) Focus(delegate func(p Primitive)) {
 			}
 			f.Focus(delegate)
 		case tcell.KeyEscape:
+			if f.escapeByPolicy() {
+				return
+			}
 			if f.cancel != nil {
-				f.cancel()
+				f.cancelForm()
 			} else {
 				f.focusedElement = 0
 				f.upScrollButton.SetDisabled(true)
@@ -782,9 +2111,14 @@ func (f *FormScrollable) Focus(delegate func(p Primitive)) {
 				f.Focus(delegate)
 			}
 		default:
-			if key < 0 && f.lastFinishedKey >= 0 {
-				// Repeat the last action.
-				handler(f.lastFinishedKey)
+			if key < 0 {
+				// Continue in the direction of the last navigation. Other keys
+				// are not repeated, Escape would e.g. cancel the form again.
+				if f.lastFinishedKey == tcell.KeyBacktab {
+					handler(tcell.KeyBacktab)
+				} else {
+					handler(tcell.KeyTab)
+				}
 			}
 		}
 	}
@@ -794,28 +2128,64 @@ func (f *FormScrollable) Focus(delegate func(p Primitive)) {
 
 	// Set the handler and focus for all items and buttons.
 	for index, button := range f.buttons {
-		button.SetExitFunc(handler)
+		button := button
+		button.SetExitFunc(func(key tcell.Key) {
+			handler(key)
+			if exit := f.buttonExits[button]; exit != nil {
+				exit(key)
+			}
+		})
 		if f.focusedElement == index+len(f.items) {
-			if button.IsDisabled() {
-				f.focusedElement++
-				if f.focusedElement >= len(f.items)+len(f.buttons) {
-					f.focusedElement = 0
-					f.upScrollButton.SetDisabled(true)
-					f.downScrollButton.SetDisabled(f.GetFormItemCount() == 1)
+			// Disabled buttons are skipped. Of the collapsed buttons, only the
+			// first enabled one takes part in the focus cycle, it represents
+			// the overflow menu.
+			if button.IsDisabled() || (f.isButtonCollapsed(index) && index != f.overflowFocusIndex()) {
+				// Continue in the direction of the last navigation.
+				if f.skip(delegate) {
+					handler(-1)
 				}
-				continue
+				return
 			}
 
 			itemFocused = true
+			f.skipped = 0
+			f.focusHint = f.focusedElement
 			func(b *Button) { // Wrapping might not be necessary anymore in future Go versions.
 				defer delegate(b)
 			}(button)
 		}
 	}
 	for index, item := range f.items {
-		item.SetFinishedFunc(handler)
+		item := item
+		item.SetFinishedFunc(func(key tcell.Key) {
+			if key >= 0 && f.itemFinished(item, key) {
+				return
+			}
+			if key >= 0 {
+				f.validateItem(item)
+				defer f.itemExited(item, key)
+			} else if f.itemIndex(item) != f.focusedElement {
+				return // Disabling an item which is not focused.
+			} else {
+				key = f.replayKey(item)
+			}
+			if key == tcell.KeyEnter && f.submitOnEnter(item, delegate) {
+				return
+			}
+			handler(key)
+		})
 		if f.focusedElement == index {
+			// Hidden items are skipped.
+			if !f.IsItemVisible(index) {
+				if f.skip(delegate) {
+					handler(f.replayKey(item))
+				}
+				return
+			}
+
 			itemFocused = true
+			f.skipped = 0
+			f.focusHint = f.focusedElement
 			func(i FormItem) { // Wrapping might not be necessary anymore in future Go versions.
 				defer delegate(i)
 			}(item)
@@ -826,6 +2196,31 @@ func (f *FormScrollable) Focus(delegate func(p Primitive)) {
 	if !itemFocused {
 		f.Box.Focus(delegate)
 	}
+	f.notifyFocusChanged()
+}
+
+// skip records that an element was skipped while moving the focus. It returns
+// false if all elements were skipped, in which case the form itself receives
+// focus.
+func (f *FormScrollable) skip(delegate func(p Primitive)) bool {
+	f.skipped++
+	if f.skipped <= len(f.items)+len(f.buttons) {
+		return true
+	}
+	f.skipped = 0
+	f.Box.Focus(delegate)
+	return false
+}
+
+// trapFocus wraps the given focus function such that the focus is returned to
+// the form if it was moved outside of it.
+func (f *FormScrollable) trapFocus(setFocus func(p Primitive)) func(p Primitive) {
+	return func(p Primitive) {
+		setFocus(p)
+		if !f.HasFocus() {
+			f.Focus(setFocus)
+		}
+	}
 }
 
 // HasFocus returns whether or not this primitive has focus.
@@ -838,24 +2233,689 @@ func (f *FormScrollable) HasFocus() bool {
 
 // focusIndex returns the index of the currently focused item, counting form
 // items first, then buttons. A negative value indicates that no containeed item
-// has focus.
+// has focus. The element which was last known to have focus is checked first,
+// all elements are only searched if the focus was moved elsewhere without the
+// form's involvement, e.g. with tview.Application.SetFocus.
 func (f *FormScrollable) focusIndex() int {
+	if index := f.focusHint; index >= 0 {
+		if index < len(f.items) && f.items[index].HasFocus() {
+			return index
+		}
+		if index >= len(f.items) && index < len(f.items)+len(f.buttons) && f.buttons[index-len(f.items)].HasFocus() {
+			return index
+		}
+	}
+	f.focusHint = -1
 	for index, item := range f.items {
 		if item.HasFocus() {
+			f.focusHint = index
 			return index
 		}
 	}
 	for index, button := range f.buttons {
 		if button.HasFocus() {
-			return len(f.items) + index
+			f.focusHint = len(f.items) + index
+			return f.focusHint
 		}
 	}
 	return -1
 }
 
+// notifyFocusChanged calls the "focus changed" handler if the focused element
+// differs from the one it was last called with. Before, the items' formatters
+// are applied (see SetItemFormatter).
+func (f *FormScrollable) notifyFocusChanged() {
+	f.applyFormatters()
+	index := f.focusIndex()
+	if index < 0 {
+		return
+	}
+	var (
+		element Primitive
+		item    FormItem
+	)
+	if index < len(f.items) {
+		item = f.items[index]
+		element = item
+	} else {
+		element = f.buttons[index-len(f.items)]
+	}
+	if element == f.focusChangedElement {
+		return
+	}
+	f.focusChangedElement = element
+	f.undoMerge = nil
+	f.focusValue, _ = f.snapshot(item)
+	if f.focusChanged != nil {
+		f.focusChanged(index, item)
+	}
+}
+
+// scrollWheel scrolls the form (or moves the focus, see SetWheelFocus) one
+// step up or down in response to the mouse wheel.
+func (f *FormScrollable) scrollWheel(up bool) {
+	if f.wheelInverted {
+		up = !up
+	}
+	if f.wheelFocus {
+		if up {
+			f.upScrollButton.click()
+		} else {
+			f.downScrollButton.click()
+		}
+		return
+	}
+	if up {
+		f.scrollOffset -= f.wheelLines
+	} else {
+		f.scrollOffset += f.wheelLines
+	}
+	f.trackFocus = false
+}
+
+// Momentum scrolling parameters.
+const (
+	momentumInterval    = 40 * time.Millisecond
+	momentumFriction    = 0.85 // Velocity factor per interval.
+	momentumMinVelocity = 2.0  // Rows per second.
+)
+
+// panMouse processes mouse events while the form's background is dragged.
+func (f *FormScrollable) panMouse(action MouseAction, event *tcell.EventMouse) {
+	_, y := event.Position()
+	switch action {
+	case MouseMove:
+		now := time.Now()
+		if elapsed := now.Sub(f.pan.lastTime).Seconds(); elapsed > 0 {
+			f.pan.velocity = float64(f.pan.lastY-y) / elapsed
+		}
+		f.pan.lastY, f.pan.lastTime = y, now
+		f.scrollOffset = f.pan.startOffset + f.pan.startY - y
+		f.trackFocus = false
+	case MouseLeftUp, MouseMiddleUp:
+		if (action == MouseMiddleUp) != f.pan.middle {
+			return // Another button was released.
+		}
+		f.pan.active = false
+		if f.dragMomentum && !f.pan.middle && time.Since(f.pan.lastTime) < 100*time.Millisecond {
+			f.startMomentum(f.pan.velocity)
+		}
+	}
+}
+
+// startPan starts dragging the form at the position of the given mouse event,
+// with the middle mouse button if middle is true.
+func (f *FormScrollable) startPan(event *tcell.EventMouse, middle bool) {
+	_, y := event.Position()
+	f.pan.active, f.pan.middle = true, middle
+	f.pan.startY, f.pan.startOffset = y, f.scrollOffset
+	f.pan.lastY, f.pan.lastTime = y, time.Now()
+	f.pan.velocity = 0
+}
+
+// startMomentum keeps scrolling the form with the given initial velocity (in
+// rows per second), slowing down until it stops. This only works if the
+// application was set.
+func (f *FormScrollable) startMomentum(velocity float64) {
+	f.stopMomentum()
+	if f.app == nil || f.IsLowBandwidthMode() || math.Abs(velocity) < momentumMinVelocity {
+		return
+	}
+	stop := make(chan struct{})
+	f.momentumStop = stop
+	app := f.app
+	go func() {
+		ticker := time.NewTicker(momentumInterval)
+		defer ticker.Stop()
+		var rows float64
+		for math.Abs(velocity) >= momentumMinVelocity {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			rows += velocity * momentumInterval.Seconds()
+			velocity *= momentumFriction
+			if step := int(rows); step != 0 {
+				rows -= float64(step)
+				app.QueueUpdateDraw(func() {
+					select {
+					case <-stop:
+					default:
+						f.scrollOffset += step
+						f.trackFocus = false
+					}
+				})
+			}
+		}
+	}()
+}
+
+// stopMomentum stops any ongoing momentum scrolling.
+func (f *FormScrollable) stopMomentum() {
+	if f.momentumStop != nil {
+		close(f.momentumStop)
+		f.momentumStop = nil
+	}
+}
+
+// readOnlyItemAt returns the index of the read-only item at the given screen
+// position or -1 if there is none. Read-only items don't receive mouse focus,
+// text in them can be selected instead.
+func (f *FormScrollable) readOnlyItemAt(x, y int) int {
+	for index, item := range f.items {
+		if textView, ok := item.(*TextView); ok && textView.InRect(x, y) {
+			return index
+		}
+	}
+	return -1
+}
+
+// selectMouse processes mouse events while text is selected.
+func (f *FormScrollable) selectMouse(action MouseAction, event *tcell.EventMouse) {
+	if f.selectionItem < 0 || f.selectionItem >= len(f.items) {
+		f.selecting = false
+		return
+	}
+	x, y := event.Position()
+	itemX, itemY, itemWidth, itemHeight := f.items[f.selectionItem].GetRect()
+	x = clamp(x-itemX, 0, itemWidth-1)
+	y = clamp(y-itemY, 0, itemHeight-1)
+	switch action {
+	case MouseMove:
+		f.selectionEnd = image.Pt(x, y)
+	case MouseLeftUp:
+		f.selectionEnd = image.Pt(x, y)
+		f.selecting = false
+		if f.selectionEnd == f.selectionStart {
+			f.selectionItem = -1 // A simple click.
+		}
+	}
+}
+
+// drawSelection highlights the selected text in a read-only item and stores
+// it for copying. Only the visible part of the selection is considered.
+func (f *FormScrollable) drawSelection(screen tcell.Screen, topLimit, bottomLimit int) {
+	f.selectedText = ""
+	if f.selectionItem < 0 || f.selectionItem >= len(f.items) {
+		return
+	}
+	start, end := f.selectionStart, f.selectionEnd
+	if end.Y < start.Y || end.Y == start.Y && end.X < start.X {
+		start, end = end, start
+	}
+	x, y, width, _ := f.items[f.selectionItem].GetRect()
+	var lines []string
+	for row := start.Y; row <= end.Y; row++ {
+		if y+row < topLimit || y+row >= bottomLimit {
+			continue
+		}
+		from, to := 0, width-1
+		if row == start.Y {
+			from = start.X
+		}
+		if row == end.Y {
+			to = end.X
+		}
+		var line strings.Builder
+		for column := from; column <= to; column++ {
+			mainc, combc, style, w := screen.GetContent(x+column, y+row)
+			screen.SetContent(x+column, y+row, mainc, combc, style.Reverse(true))
+			line.WriteRune(mainc)
+			line.WriteString(string(combc))
+			if w > 1 {
+				column += w - 1
+			}
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	f.selectedText = strings.Join(lines, "\n")
+}
+
+// handleIndexAt returns the index of the item whose drag handle is at the
+// given screen position or -1 if there is none.
+func (f *FormScrollable) handleIndexAt(x, y int) int {
+	for index, item := range f.items {
+		itemX, itemY, _, itemHeight := item.GetRect()
+		if x >= itemX-2 && x < itemX && y >= itemY && y < itemY+itemHeight {
+			return index
+		}
+	}
+	return -1
+}
+
+// messageAt returns the item message at the given screen position and its
+// screen row. The message is empty if there is none.
+func (f *FormScrollable) messageAt(x, y int) (itemMessage, int) {
+	for _, item := range f.items {
+		itemX, itemY, itemWidth, itemHeight := item.GetRect()
+		if x < itemX || x >= itemX+itemWidth || y < itemY+itemHeight {
+			continue
+		}
+		if messages := f.itemMessages(item); y < itemY+itemHeight+len(messages) {
+			return messages[y-itemY-itemHeight], y
+		}
+	}
+	return itemMessage{}, y
+}
+
+// deleteControlIndexAt returns the index of the item whose delete control is
+// at the given screen position or -1 if there is none.
+func (f *FormScrollable) deleteControlIndexAt(x, y int) int {
+	for index, item := range f.items {
+		itemX, itemY, itemWidth, _ := item.GetRect()
+		if f.revertControls {
+			itemX += 2
+		}
+		if x == itemX+itemWidth+1 && y == itemY {
+			return index
+		}
+	}
+	return -1
+}
+
+// deleteItem removes the item at the given index on behalf of the user. If the
+// item had focus, the focus moves on to the next element.
+func (f *FormScrollable) deleteItem(index int, setFocus func(p Primitive)) {
+	item, err := f.GetFormItemChecked(index)
+	if err != nil {
+		return
+	}
+	hadFocus := item.HasFocus()
+	f.RemoveFormItem(index)
+	if hadFocus {
+		item.Blur()
+		f.focusedElement = index
+		f.Focus(setFocus)
+	}
+	if f.deleted != nil {
+		f.deleted(index, item)
+	}
+}
+
+// dragMouse processes mouse events while an item is dragged by its handle.
+func (f *FormScrollable) dragMouse(action MouseAction, event *tcell.EventMouse) {
+	_, y := event.Position()
+	switch action {
+	case MouseMove:
+		// Find the item under the mouse.
+		for index, item := range f.items {
+			_, itemY, _, itemHeight := item.GetRect()
+			if y >= itemY && y < itemY+itemHeight && index != f.dragItem {
+				f.MoveFormItem(f.dragItem, index)
+				f.dragItem = index
+				break
+			}
+		}
+	case MouseLeftUp:
+		from, to := f.dragFrom, f.dragItem
+		f.dragItem = -1
+		if from != to && f.reordered != nil {
+			f.reordered(from, to)
+		}
+	}
+}
+
+// moveFocusedItem moves the focused item one position up (delta < 0) or down
+// (delta > 0). It returns whether the item was moved.
+func (f *FormScrollable) moveFocusedItem(delta int) bool {
+	from := f.focusIndex()
+	if from < 0 || from >= len(f.items) {
+		return false
+	}
+	to := from + delta
+	if to < 0 || to >= len(f.items) {
+		return true // Consume the key anyway.
+	}
+	f.MoveFormItem(from, to)
+	if f.reordered != nil {
+		f.reordered(from, to)
+	}
+	return true
+}
+
+// focusedItemUsesKey returns whether the focused item (if any) handles the
+// given navigation key (e.g. PgUp) itself, such that the form shouldn't.
+func (f *FormScrollable) focusedItemUsesKey(key tcell.Key) bool {
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	return itemUsesKey(f.items[index], key)
+}
+
+// focusedItemIsOpen returns whether the focused item is an open drop-down.
+func (f *FormScrollable) focusedItemIsOpen() bool {
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	dropDown, ok := unwrapItem(f.items[index]).(interface{ IsOpen() bool })
+	return ok && dropDown.IsOpen()
+}
+
+// pageTextView processes keys which scroll the focused text view. The form is
+// scrolled first if the text view isn't fully visible. Once the text view was
+// scrolled to its beginning or end, the focus moves to the previous or next
+// element. Returns whether the event was consumed.
+func (f *FormScrollable) pageTextView(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	var down bool
+	lines := 1
+	switch event.Key() {
+	case tcell.KeyUp:
+	case tcell.KeyDown:
+		down = true
+	case tcell.KeyPgUp:
+		lines = f.pageHeight
+	case tcell.KeyPgDn:
+		down, lines = true, f.pageHeight
+	default:
+		return false
+	}
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	textView, ok := f.items[index].(*TextView)
+	if !ok {
+		return false
+	}
+
+	// Reveal the hidden part of the text view first.
+	_, y, _, height := textView.GetRect()
+	if down && y+height > f.pageTop+f.pageHeight {
+		f.scrollOffset += min(lines, y+height-f.pageTop-f.pageHeight)
+		return true
+	}
+	if !down && y < f.pageTop {
+		f.scrollOffset -= min(lines, f.pageTop-y)
+		return true
+	}
+
+	// Move on if the text view didn't scroll with the last key.
+	row, _ := textView.GetScrollOffset()
+	if textView == f.pagingItem && down == f.pagingDown && row == f.pagingRow || !down && row == 0 {
+		f.pagingItem = nil
+		key := tcell.KeyTab
+		if !down {
+			key = tcell.KeyBacktab
+		}
+		textView.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), setFocus)
+		return true
+	}
+	f.pagingItem, f.pagingDown, f.pagingRow = textView, down, row
+	return false
+}
+
+// focusEdge moves the focus to the first (or last) element which can receive
+// focus.
+func (f *FormScrollable) focusEdge(first bool, setFocus func(p Primitive)) {
+	count := len(f.items) + len(f.buttons)
+	if count == 0 {
+		return
+	}
+	if first {
+		// Disabled elements are skipped in the direction of the last key.
+		f.focusedElement, f.lastFinishedKey = 0, tcell.KeyTab
+	} else {
+		f.focusedElement, f.lastFinishedKey = count-1, tcell.KeyBacktab
+	}
+	f.upScrollButton.SetDisabled(f.focusedElement == 0)
+	f.downScrollButton.SetDisabled(f.focusedElement == count-1)
+	f.Focus(setFocus)
+}
+
+// isButtonCollapsed returns whether the button with the given index didn't fit
+// into the buttons row and was moved into the overflow menu.
+func (f *FormScrollable) isButtonCollapsed(index int) bool {
+	return f.overflowIndex >= 0 && index >= f.overflowIndex && index < len(f.buttons)
+}
+
+// overflowFocusIndex returns the index of the collapsed button which represents
+// the overflow menu in the focus cycle or -1 if there is none.
+func (f *FormScrollable) overflowFocusIndex() int {
+	if f.overflowIndex < 0 {
+		return -1
+	}
+	for index := f.overflowIndex; index < len(f.buttons); index++ {
+		if !f.buttons[index].IsDisabled() {
+			return index
+		}
+	}
+	return -1
+}
+
+// showOverflowMenu opens a popup menu listing all collapsed buttons. Selecting
+// an entry triggers the corresponding button.
+func (f *FormScrollable) showOverflowMenu() {
+	if f.overflowIndex < 0 {
+		return
+	}
+	var (
+		labels  []string
+		buttons []*Button
+	)
+	for _, button := range f.buttons[f.overflowIndex:] {
+		if button.IsDisabled() {
+			continue
+		}
+		labels = append(labels, button.GetLabel())
+		buttons = append(buttons, button)
+	}
+	if len(buttons) == 0 {
+		return
+	}
+	x, y, width, _ := f.overflowButton.GetRect()
+	f.showMenu(x, y, width, labels, func(index int) {
+		// Buttons don't expose their "selected" function, let them handle
+		// an Enter key instead.
+		if handler := buttons[index].InputHandler(); handler != nil {
+			handler(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(p Primitive) {})
+		}
+	})
+}
+
+// showMenu opens a popup menu with the given entries next to the given anchor
+// (usually the control which opened the menu). The "selected" function is
+// called with the index of the chosen entry after the menu was closed.
+func (f *FormScrollable) showMenu(anchorX, anchorY, anchorWidth int, entries []string, selected func(index int)) {
+	list := NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true)
+	list.SetBorder(true)
+
+	var width int
+	for index, entry := range entries {
+		if w := TaggedStringWidth(entry); w > width {
+			width = w
+		}
+		index := index
+		list.AddItem(entry, "", 0, func() {
+			f.popup = nil
+			if selected != nil {
+				selected(index)
+			}
+		})
+	}
+	width += 2
+	height := len(entries) + 2
+
+	// Menus prefer to open upwards and aligned to the right of the anchor.
+	x, y, formWidth, formHeight := f.GetRect()
+	menuX := anchorX + anchorWidth - width
+	if menuX+width > x+formWidth {
+		menuX = x + formWidth - width
+	}
+	if menuX < x {
+		menuX = x
+	}
+	menuY := anchorY - height
+	if menuY < y {
+		menuY = anchorY + 1
+		if menuY+height > y+formHeight {
+			height = y + formHeight - menuY
+		}
+	}
+	list.SetRect(menuX, menuY, width, height)
+	f.popup = list
+}
+
+// showConfirm opens a modal dialog with the given message and two buttons.
+// The "confirmed" function is called after the dialog was closed with the
+// first button. The second button and Escape close it without calling it.
+func (f *FormScrollable) showConfirm(message, confirm, dismiss string, confirmed func()) {
+	modal := NewModal().
+		SetText(message).
+		AddButtons([]string{confirm, dismiss}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			f.popup = nil
+			if buttonIndex == 0 && confirmed != nil {
+				confirmed()
+			}
+		})
+
+	// The dialog moves the focus between its buttons itself.
+	var focused Primitive
+	var focus func(p Primitive)
+	focus = func(p Primitive) {
+		if focused != nil {
+			focused.Blur()
+		}
+		focused = p
+		p.Focus(focus)
+	}
+	modal.Focus(focus)
+	f.popup = modal
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (f *FormScrollable) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return f.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Keep the focus inside if the form traps it.
+		if f.focusTrap && f.HasFocus() {
+			if !f.InRect(event.Position()) && (action == MouseLeftDown || action == MouseMiddleDown || action == MouseRightDown) {
+				return true, nil
+			}
+			setFocus = f.trapFocus(setFocus)
+		}
+
+		// Record the changes to the values of the focused item and the item
+		// under the mouse.
+		defer f.trackChanges(f.mouseItems(event.Position())...)()
+
+		// An open popup gets all mouse events. Clicking outside of it closes it.
+		if f.popup != nil {
+			consumed, capture = f.popup.MouseHandler()(action, event, func(p Primitive) {})
+			if !consumed && action == MouseLeftDown {
+				f.popup = nil
+			}
+			return true, capture
+		}
+
+		// Any click stops momentum scrolling.
+		if action == MouseLeftDown || action == MouseMiddleDown {
+			f.stopMomentum()
+		}
+
+		// The middle mouse button drags the form anywhere.
+		if action == MouseMiddleDown && f.middleDragScrolling && f.InRect(event.Position()) {
+			f.startPan(event, true)
+			return true, f
+		}
+
+		// Scroll with the scroll bar.
+		if f.scrollBar.grab >= 0 || action == MouseLeftDown {
+			_, _, _, height := f.GetInnerRect()
+			x, y := event.Position()
+			if offset, ok := f.scrollBar.mouse(action, x, y, f.scrollOffset, height); ok {
+				f.scrollOffset = offset
+				f.trackFocus = false
+				if f.scrollBar.grab >= 0 {
+					return true, f
+				}
+				return true, nil
+			}
+		}
+
+		// Scroll the form while its background is dragged.
+		if f.pan.active {
+			f.panMouse(action, event)
+			return true, f
+		}
+
+		// Select text in read-only items.
+		if f.selecting {
+			f.selectMouse(action, event)
+			return true, f
+		}
+		switch action {
+		case MouseLeftDown:
+			f.selectionItem = -1
+			if index := f.readOnlyItemAt(event.Position()); index >= 0 {
+				x, y := event.Position()
+				itemX, itemY, _, _ := f.items[index].GetRect()
+				f.selectionItem, f.selecting = index, true
+				f.selectionStart = image.Pt(x-itemX, y-itemY)
+				f.selectionEnd = f.selectionStart
+				if f.isSkippedTextView(index) {
+					f.Focus(setFocus)
+				} else {
+					setFocus(f.items[index])
+				}
+				return true, f
+			}
+		case MouseRightClick:
+			if f.readOnlyItemAt(event.Position()) == f.selectionItem && f.CopySelection() {
+				return true, nil
+			}
+		}
+
+		// Move a dragged item to the position of the mouse.
+		if f.dragItem >= 0 {
+			f.dragMouse(action, event)
+			return true, f
+		}
+
+		// Start dragging an item by its handle.
+		if f.reorderable && action == MouseLeftDown {
+			if index := f.handleIndexAt(event.Position()); index >= 0 {
+				f.dragItem, f.dragFrom = index, index
+				return true, f
+			}
+		}
+
+		// Clickable messages, e.g. conflicts.
+		if action == MouseLeftClick {
+			if message, y := f.messageAt(event.Position()); message.clicked != nil {
+				message.clicked(y, setFocus)
+				return true, nil
+			}
+		}
+
+		// Revert an item with its revert control.
+		if f.revertControls && action == MouseLeftClick {
+			if index := f.revertControlIndexAt(event.Position()); index >= 0 {
+				f.RevertItem(index)
+				return true, nil
+			}
+		}
+
+		// Delete an item with its delete control.
+		if f.deletable && action == MouseLeftClick {
+			if index := f.deleteControlIndexAt(event.Position()); index >= 0 {
+				f.deleteItem(index, setFocus)
+				return true, nil
+			}
+		}
+
+		// Open the secret menu of an item with its secret control.
+		if f.secretProvider != nil && action == MouseLeftClick {
+			if index := f.secretControlIndexAt(event.Position()); index >= 0 {
+				f.showSecretMenu(index)
+				return true, nil
+			}
+		}
+
 		// At the end, update f.focusedElement and prepare current item/button.
 		defer func() {
 			if consumed {
@@ -863,6 +2923,7 @@ func (f *FormScrollable) MouseHandler() func(action MouseAction, event *tcell.Ev
 				if index >= 0 {
 					f.focusedElement = index
 				}
+				f.notifyFocusChanged()
 
 				if f.focusedElement <= 0 {
 					f.upScrollButton.SetDisabled(true)
@@ -879,15 +2940,24 @@ func (f *FormScrollable) MouseHandler() func(action MouseAction, event *tcell.Ev
 		}()
 
 		// Determine items to pass mouse events to.
-		for _, item := range f.items {
+		for index, item := range f.items {
 			// Exclude TextView items from mouse-down events as they are
-			// read-only items and thus should not be focused.
-			if _, ok := item.(*TextView); ok && action == MouseLeftDown {
+			// read-only items and thus should not be focused (unless
+			// configured otherwise).
+			if f.isSkippedTextView(index) && action == MouseLeftDown {
 				continue
 			}
 
-			consumed, capture = item.MouseHandler()(action, event, setFocus)
+			// Disabled and hidden items don't react to the mouse at all.
+			if f.IsItemDisabled(index) || !f.IsItemVisible(index) {
+				continue
+			}
+
+			f.withShownLabel(item, func() {
+				consumed, capture = item.MouseHandler()(action, event, setFocus)
+			})
 			if consumed {
+				capture = f.captureWithShownLabel(item, capture)
 				return
 			}
 		}
@@ -898,6 +2968,15 @@ func (f *FormScrollable) MouseHandler() func(action MouseAction, event *tcell.Ev
 			}
 		}
 
+		for _, toolbar := range [][]*ToolButton{f.topToolbar, f.bottomToolbar} {
+			for _, button := range toolbar {
+				consumed, capture = button.MouseHandler()(action, event, setFocus)
+				if consumed {
+					return
+				}
+			}
+		}
+
 		consumed, capture = f.upScrollButton.MouseHandler()(action, event, setFocus)
 		if consumed {
 			return
@@ -908,11 +2987,43 @@ func (f *FormScrollable) MouseHandler() func(action MouseAction, event *tcell.Ev
 			return
 		}
 
+		consumed, capture = f.overflowButton.MouseHandler()(action, event, setFocus)
+		if consumed {
+			return
+		}
+
+		for _, button := range []*NoneFocusableButton{f.leftScrollButton, f.rightScrollButton} {
+			consumed, capture = button.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+
+		// Horizontal wheel events scroll horizontally scrollable forms.
+		if (action == MouseScrollLeft || action == MouseScrollRight) && f.InRect(event.Position()) && f.maxScrollOffsetX > 0 {
+			lines := f.wheelLines
+			if action == MouseScrollLeft {
+				lines = -lines
+			}
+			f.ScrollHorizontallyTo(f.scrollOffsetX + lines)
+			return true, nil
+		}
+
+		// The mouse wheel scrolls the form unless an element consumed it.
+		if (action == MouseScrollUp || action == MouseScrollDown) && f.InRect(event.Position()) {
+			f.scrollWheel(action == MouseScrollUp)
+			return true, nil
+		}
+
 		// A mouse down anywhere else will return the focus to the last selected
-		// element.
+		// element. It may also start dragging the form's background.
 		if action == MouseLeftDown && f.InRect(event.Position()) {
 			f.Focus(setFocus)
 			consumed = true
+			if f.dragScrolling {
+				f.startPan(event, false)
+				capture = f
+			}
 		}
 
 		return
@@ -922,6 +3033,108 @@ func (f *FormScrollable) MouseHandler() func(action MouseAction, event *tcell.Ev
 // InputHandler returns the handler for this primitive.
 func (f *FormScrollable) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return f.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if f.focusTrap {
+			setFocus = f.trapFocus(setFocus)
+		}
+		f.stopMomentum()
+		f.trackFocus = true
+
+		// An open popup gets all key events. Escape closes it.
+		if f.popup != nil {
+			if event.Key() == tcell.KeyEscape {
+				f.popup = nil
+			} else if handler := f.popup.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+			return
+		}
+
+		// Hotkeys come before anything else, then shortcuts.
+		if f.handleHotkey(event) || f.handleShortcut(event, setFocus) {
+			return
+		}
+
+		// Navigation keys stand for the form's own keys.
+		if f.vimKey(event, setFocus) || f.navigate(event, setFocus) {
+			return
+		}
+
+		// Ctrl+C copies selected text.
+		if event.Key() == tcell.KeyCtrlC && f.CopySelection() {
+			return
+		}
+
+		// Ctrl+Z/Y undo and redo changes. Other changes to the focused item
+		// are recorded.
+		if f.undoKey(event) {
+			return
+		}
+		defer f.trackChanges(f.focusedItems()...)()
+
+		// Ctrl+R opens the chooser of a conflicting item.
+		if f.resolveFocusedConflict(event, setFocus) {
+			return
+		}
+
+		// Ctrl+K opens the secret menu of a secret item.
+		if f.openFocusedSecretMenu(event) {
+			return
+		}
+
+		// Ctrl+Up/Down move reorderable items.
+		if f.reorderable && event.Modifiers()&tcell.ModCtrl != 0 {
+			switch event.Key() {
+			case tcell.KeyUp:
+				if f.moveFocusedItem(-1) {
+					return
+				}
+			case tcell.KeyDown:
+				if f.moveFocusedItem(1) {
+					return
+				}
+			}
+		}
+
+		// PgUp/PgDn scroll the form without moving the focus, unless the
+		// focused item uses these keys itself.
+		if key := event.Key(); key == tcell.KeyPgUp || key == tcell.KeyPgDn {
+			if !f.focusedItemUsesKey(key) {
+				f.trackFocus = false
+				if key == tcell.KeyPgUp {
+					f.scrollOffset = f.GetScrollOffset() - f.pageHeight
+				} else {
+					f.scrollOffset = f.GetScrollOffset() + f.pageHeight
+				}
+				return
+			}
+		}
+
+		// Scroll keys in tall text views reveal the text view first, then
+		// scroll its text, and then move on to the neighbouring element.
+		if f.pageTextView(event, setFocus) {
+			return
+		}
+
+		// Home/End move the focus to the first/last element, unless the
+		// focused item uses these keys itself.
+		if key := event.Key(); key == tcell.KeyHome || key == tcell.KeyEnd {
+			if !f.focusedItemUsesKey(key) {
+				f.focusEdge(key == tcell.KeyHome, setFocus)
+				return
+			}
+		}
+
+		// Enter on a collapsed button opens the overflow menu.
+		if event.Key() == tcell.KeyEnter && f.isButtonCollapsed(f.focusIndex()-len(f.items)) {
+			f.showOverflowMenu()
+			return
+		}
+
+		// Enter follows the focused item's finished-key policy.
+		if event.Key() == tcell.KeyEnter && f.enterByPolicy(setFocus) {
+			return
+		}
+
 		for _, item := range f.items {
 			if item != nil && item.HasFocus() {
 				if handler := item.InputHandler(); handler != nil {
@@ -945,6 +3158,12 @@ func (f *FormScrollable) InputHandler() func(event *tcell.EventKey, setFocus fun
 // PasteHandler returns the handler for this primitive.
 func (f *FormScrollable) PasteHandler() func(pastedText string, setFocus func(p Primitive)) {
 	return f.WrapPasteHandler(func(pastedText string, setFocus func(p Primitive)) {
+		if f.focusTrap {
+			setFocus = f.trapFocus(setFocus)
+		}
+		f.trackFocus = true
+		defer f.trackChanges(f.focusedItems()...)()
+
 		for _, item := range f.items {
 			if item != nil && item.HasFocus() {
 				if handler := item.PasteHandler(); handler != nil {
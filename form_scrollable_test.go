@@ -0,0 +1,129 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+func TestFocusSkipsOnlyDisabledButton(t *testing.T) {
+	f := NewFormScrollable().AddButton("OK", nil)
+	f.GetButton(0).SetDisabled(true)
+
+	var focused Primitive
+	f.Focus(func(p Primitive) { focused = p })
+	if focused != nil && focused != f {
+		t.Fatalf("expected the form itself to receive focus, got %T", focused)
+	}
+}
+
+func TestFocusSkipAfterEscapeDoesNotCancel(t *testing.T) {
+	var cancelled int
+	f := NewFormScrollable().
+		AddInputField("Name", "", 10, nil, nil).
+		AddButton("OK", nil).
+		SetCancelFunc(func() { cancelled++ })
+	f.GetButton(0).SetDisabled(true)
+	f.Focus(func(p Primitive) {})
+
+	f.lastFinishedKey = tcell.KeyEscape
+	f.focusedElement = 1
+	f.Focus(func(p Primitive) {})
+	if cancelled != 0 {
+		t.Fatalf("skipping a disabled button cancelled the form %d times", cancelled)
+	}
+	if f.focusedElement != 0 {
+		t.Fatalf("expected focus to wrap around to the input field, got element %d", f.focusedElement)
+	}
+}
+
+// focuser imitates the focus handling of the application.
+type focuser struct {
+	focused Primitive
+}
+
+// setFocus moves the focus to the given primitive.
+func (fc *focuser) setFocus(p Primitive) {
+	if fc.focused != nil {
+		fc.focused.Blur()
+	}
+	fc.focused = p
+	p.Focus(fc.setFocus)
+}
+
+// press sends the given key to the form.
+func (fc *focuser) press(f *FormScrollable, key tcell.Key, r rune) {
+	f.InputHandler()(tcell.NewEventKey(key, r, tcell.ModNone), fc.setFocus)
+}
+
+func TestButtonExitFunc(t *testing.T) {
+	var keys []tcell.Key
+	f := NewFormScrollable().
+		AddButton("OK", nil).
+		AddButton("Cancel", nil).
+		SetButtonExitFunc(0, func(key tcell.Key) { keys = append(keys, key) })
+	fc := &focuser{}
+	fc.setFocus(f)
+	fc.press(f, tcell.KeyTab, 0)
+	if _, button := f.GetFocusedItemIndex(); button != 1 {
+		t.Fatalf("expected the second button to have focus, got %d", button)
+	}
+	if len(keys) != 1 || keys[0] != tcell.KeyTab {
+		t.Fatalf("expected the exit handler to be called with Tab, got %v", keys)
+	}
+}
+
+func TestItemFinishedFuncKeptOnFocus(t *testing.T) {
+	var finished int
+	f := NewFormScrollable().
+		AddInputField("Name", "", 10, nil, nil).
+		AddInputField("Mail", "", 10, nil, nil).
+		SetItemFinishedFunc(0, func(key tcell.Key) bool {
+			finished++
+			return false
+		})
+	fc := &focuser{}
+	fc.setFocus(f)
+	fc.setFocus(f)
+	fc.press(f, tcell.KeyTab, 0)
+	if finished != 1 {
+		t.Fatalf("expected the handler to be called once, got %d", finished)
+	}
+	if index, _ := f.GetFocusedItemIndex(); index != 1 {
+		t.Fatalf("expected the second item to have focus, got %d", index)
+	}
+}
+
+func TestItemExitFunc(t *testing.T) {
+	var keys []tcell.Key
+	var focused []int
+	f := NewFormScrollable().
+		AddInputField("Name", "", 10, nil, nil).
+		AddInputField("Mail", "", 10, nil, nil).
+		SetItemFinishedFunc(0, func(key tcell.Key) bool { return key == tcell.KeyEnter })
+	f.SetItemExitFunc(0, func(key tcell.Key) {
+		keys = append(keys, key)
+		index, _ := f.GetFocusedItemIndex()
+		focused = append(focused, index)
+	})
+	fc := &focuser{}
+	fc.setFocus(f)
+
+	// Enter is consumed by the finished handler, the item isn't left.
+	fc.press(f, tcell.KeyEnter, 0)
+	if len(keys) != 0 {
+		t.Fatalf("expected no exit for a consumed key, got %v", keys)
+	}
+	fc.press(f, tcell.KeyTab, 0)
+	if len(keys) != 1 || keys[0] != tcell.KeyTab {
+		t.Fatalf("expected the exit handler to be called with Tab, got %v", keys)
+	}
+	if focused[0] != 1 {
+		t.Fatalf("expected the handler to run after the focus moved, got %d", focused[0])
+	}
+	fc.press(f, tcell.KeyTab, 0)
+	if len(keys) != 1 {
+		t.Fatalf("expected no exit for another item, got %v", keys)
+	}
+}
@@ -0,0 +1,143 @@
+package form
+
+import (
+	. "github.com/rivo/tview"
+)
+
+// itemFormatter formats the text of a form item while it doesn't have focus
+// (see SetItemFormatter).
+type itemFormatter struct {
+	// The functions which turn the raw text into the text shown and back.
+	display func(raw string) string
+	parse   func(display string) (string, error)
+
+	// Whether the item was drawn. Until then, its text is left alone as tview
+	// can't replace the text of input fields which were never drawn.
+	drawn bool
+
+	// Whether the item shows formatted text, the text it shows, and the raw
+	// text it was formatted from.
+	formatted bool
+	shown     string
+	raw       string
+}
+
+// SetItemFormatter sets functions which format the text of the form item at
+// the given index while it doesn't have focus, e.g. so that a number field
+// shows "1,234.56" while its value is "1234.56". When the item loses focus,
+// its raw text is formatted with the display function. When it receives
+// focus, the formatted text is parsed back into the raw text with the parse
+// function, which the user then edits. If parsing fails, the raw text the
+// item had before it was formatted is restored.
+//
+// Validators, dirty tracking, notices, and Submit use the raw text
+// (see GetItemRawText). Text set by the app while the item shows formatted
+// text is taken as raw text and formatted again. Nil functions remove the
+// formatter and restore the raw text.
+func (f *FormScrollable) SetItemFormatter(index int, display func(raw string) string, parse func(display string) (string, error)) *FormScrollable {
+	item := f.items[index]
+	state := f.state(item)
+	if display == nil || parse == nil {
+		if state.formatter != nil {
+			setItemText(item, f.rawText(item))
+			state.formatter = nil
+		}
+		return f
+	}
+	drawn := state.formatter != nil && state.formatter.drawn
+	state.formatter = &itemFormatter{display: display, parse: parse, drawn: drawn}
+	f.applyFormatter(item, state.formatter)
+	return f
+}
+
+// GetItemRawText returns the text of the form item at the given index as
+// given by the user, i.e. without the formatting of its formatter (see
+// SetItemFormatter).
+func (f *FormScrollable) GetItemRawText(index int) string {
+	return f.rawText(f.items[index])
+}
+
+// rawText returns the text of the given item without the formatting of its
+// formatter.
+func (f *FormScrollable) rawText(item FormItem) string {
+	text := getItemText(item)
+	if state, ok := f.itemStates[item]; ok && state.formatter != nil {
+		if formatter := state.formatter; formatter.formatted && text == formatter.shown {
+			return formatter.raw
+		}
+	}
+	return text
+}
+
+// snapshot returns the value of the given item like itemSnapshot, but the raw
+// text for items with a formatter, so that formatting doesn't change it.
+func (f *FormScrollable) snapshot(item FormItem) (any, bool) {
+	if state, ok := f.itemStates[item]; ok && state.formatter != nil {
+		return f.rawText(item), true
+	}
+	return itemSnapshot(item)
+}
+
+// applyFormatters formats the items with formatters which don't have focus
+// and restores the raw text of the one which has. It returns whether the text
+// of any item changed.
+func (f *FormScrollable) applyFormatters() bool {
+	var changed bool
+	for _, item := range f.items {
+		if state, ok := f.itemStates[item]; ok && state.formatter != nil {
+			changed = f.applyFormatter(item, state.formatter) || changed
+		}
+	}
+	return changed
+}
+
+// formattersDrawn is called after the form was drawn. Items with formatters
+// which were drawn for the first time are formatted and, if their text
+// changed, the form is drawn again.
+func (f *FormScrollable) formattersDrawn() {
+	var first bool
+	for _, item := range f.items {
+		state, ok := f.itemStates[item]
+		if !ok || state.formatter == nil || state.formatter.drawn {
+			continue
+		}
+		if _, _, width, _ := item.GetRect(); width > 0 {
+			state.formatter.drawn, first = true, true
+		}
+	}
+	if first && f.applyFormatters() && f.app != nil {
+		f.queueUpdateDraw(func() {})
+	}
+}
+
+// applyFormatter formats the given item with the given formatter if it
+// doesn't have focus and restores its raw text if it has. It returns whether
+// the item's text changed.
+func (f *FormScrollable) applyFormatter(item FormItem, formatter *itemFormatter) bool {
+	if !formatter.drawn {
+		return false
+	}
+	text := getItemText(item)
+	formatted := formatter.formatted && text == formatter.shown
+	if item.HasFocus() {
+		if !formatted {
+			formatter.formatted = false
+			return false
+		}
+		raw, err := formatter.parse(text)
+		if err != nil {
+			raw = formatter.raw
+		}
+		formatter.formatted = false
+		setItemText(item, raw)
+		return true
+	}
+	if formatted {
+		return false
+	}
+	formatter.raw = text
+	formatter.shown = formatter.display(text)
+	formatter.formatted = true
+	setItemText(item, formatter.shown)
+	return true
+}
@@ -5,6 +5,7 @@ go 1.20
 require (
 	github.com/gdamore/tcell/v2 v2.7.4
 	github.com/rivo/tview v0.0.0-20240505185119-ed116790de0f
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,64 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// SetItemHelp sets a text which describes the form item at the given index.
+// It is shown dimmed below the item's field or, if the form has a help bar
+// (see SetHelpBar), in the help bar while the item has focus. It is also
+// included in exported documents (see ExportDocument). An empty text removes
+// the help.
+func (f *FormScrollable) SetItemHelp(index int, text string) *FormScrollable {
+	f.state(f.items[index]).help = text
+	return f
+}
+
+// GetItemHelp returns the help text of the form item at the given index (see
+// SetItemHelp).
+func (f *FormScrollable) GetItemHelp(index int) string {
+	if state, ok := f.itemStates[f.items[index]]; ok {
+		return state.help
+	}
+	return ""
+}
+
+// SetHelpBar sets whether the help texts of items (see SetItemHelp) are shown
+// in a row at the bottom of the form, which doesn't scroll, instead of below
+// the items' fields. The help bar only shows the help of the focused item.
+func (f *FormScrollable) SetHelpBar(helpBar bool) *FormScrollable {
+	f.helpBar = helpBar
+	return f
+}
+
+// SetHelpColor sets the color of help texts. They are also dimmed.
+func (f *FormScrollable) SetHelpColor(color tcell.Color) *FormScrollable {
+	f.helpColor = color
+	return f
+}
+
+// helpMessage returns the help text of the item with the given state as a
+// message below the item's field and whether there is one.
+func (f *FormScrollable) helpMessage(state *itemState) (itemMessage, bool) {
+	if state.help == "" || f.helpBar {
+		return itemMessage{}, false
+	}
+	return itemMessage{text: state.help, color: f.helpColor, dim: true}, true
+}
+
+// drawHelpBar draws the help text of the focused item, if any, into the row at
+// the given position.
+func (f *FormScrollable) drawHelpBar(screen tcell.Screen, x, y, width int) {
+	style := tcell.StyleDefault.Background(f.GetBackgroundColor())
+	for column := x; column < x+width; column++ {
+		screen.SetContent(column, y, ' ', nil, style)
+	}
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return
+	}
+	if state, ok := f.itemStates[f.items[index]]; ok && state.help != "" {
+		printStyled(screen, Escape(state.help), x, y, width, AlignLeft, style.Foreground(f.helpColor).Dim(true))
+	}
+}
@@ -0,0 +1,327 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// ErrHotkeyConflict is returned when a hotkey is registered for a key
+// combination which is already in use.
+var ErrHotkeyConflict = errors.New("hotkey conflict")
+
+// Hotkey is a key combination handled by a form before the key event is
+// passed on to the focused item.
+type Hotkey struct {
+	Key       tcell.Key
+	Modifiers tcell.ModMask
+
+	// The character if Key is tcell.KeyRune.
+	Rune rune
+
+	// What the key combination does, shown in the key help (see
+	// ShowKeyHelp).
+	Description string
+
+	// The function called when the hotkey is pressed.
+	handler func()
+}
+
+// String returns a human-readable name of the key combination, e.g.
+// "Ctrl+L" or "Alt+n".
+func (h Hotkey) String() string {
+	name := tcell.NewEventKey(h.Key, h.Rune, h.Modifiers).Name()
+	if h.Key == tcell.KeyRune && h.Rune != 0 {
+		return strings.Replace(name, "Rune["+string(h.Rune)+"]", string(h.Rune), 1)
+	}
+	return name
+}
+
+// matches returns whether the given key combination triggers the hotkey. The
+// characters of tcell.KeyRune combinations must be the same.
+func (h Hotkey) matches(key tcell.Key, r rune, modifiers tcell.ModMask) bool {
+	if h.Key != key || key == tcell.KeyRune && h.Rune != r {
+		return false
+	}
+	return normalizeModifiers(h.Key, h.Modifiers) == normalizeModifiers(key, modifiers)
+}
+
+// conflicts returns whether the given key combination and the hotkey are
+// triggered by the same key events.
+func (h Hotkey) conflicts(other Hotkey) bool {
+	return h.matches(other.Key, other.Rune, other.Modifiers)
+}
+
+// normalizeModifiers removes the Ctrl modifier for control keys (Ctrl+A to
+// Ctrl+Z) as these keys imply it and terminals don't report it consistently.
+func normalizeModifiers(key tcell.Key, modifiers tcell.ModMask) tcell.ModMask {
+	if key >= tcell.KeyCtrlA && key <= tcell.KeyCtrlZ {
+		return modifiers &^ tcell.ModCtrl
+	}
+	return modifiers
+}
+
+// formKeys are the key combinations the form always handles itself.
+var formKeys = []Hotkey{
+	{Key: tcell.KeyTab, Description: "Next element"},
+	{Key: tcell.KeyBacktab, Description: "Previous element"},
+	{Key: tcell.KeyEnter, Description: "Next element, submit, or press button"},
+	{Key: tcell.KeyEscape, Description: "Cancel"},
+	{Key: tcell.KeyCtrlC, Description: "Copy selection"},
+	{Key: tcell.KeyCtrlR, Description: "Resolve conflict"},
+	{Key: tcell.KeyCtrlZ, Description: "Undo"},
+	{Key: tcell.KeyCtrlY, Description: "Redo"},
+	{Key: tcell.KeyPgUp, Description: "Scroll up"},
+	{Key: tcell.KeyPgDn, Description: "Scroll down"},
+	{Key: tcell.KeyHome, Description: "First element"},
+	{Key: tcell.KeyEnd, Description: "Last element"},
+}
+
+// GetFormKeys returns the form's own keymap in its current configuration, i.e.
+// the key combinations the form handles itself and which can't be registered
+// as hotkeys: the keys it always handles, e.g. Tab and PgUp, the keys of
+// enabled features, e.g. Ctrl+Up and Ctrl+Down of reorderable items, the
+// navigation keys and characters (see SetNavigationKeys, SetNavigationRunes,
+// and SetVimMode), the shortcut letters (see SetItemShortcut), and Ctrl+PgUp
+// and Ctrl+PgDn if the form is a tab of a TabbedFormScrollable.
+func (f *FormScrollable) GetFormKeys() []Hotkey {
+	keys := append([]Hotkey(nil), formKeys...)
+	add := func(hotkey Hotkey) {
+		for _, used := range keys {
+			if used.conflicts(hotkey) {
+				return // Keys are used for their first action.
+			}
+		}
+		keys = append(keys, hotkey)
+	}
+	if f.reorderable {
+		add(Hotkey{Key: tcell.KeyUp, Modifiers: tcell.ModCtrl, Description: "Move item up"})
+		add(Hotkey{Key: tcell.KeyDown, Modifiers: tcell.ModCtrl, Description: "Move item down"})
+	}
+	if f.secretProvider != nil {
+		add(Hotkey{Key: tcell.KeyCtrlK, Description: "Secret menu"})
+	}
+	if f.tabbed {
+		add(Hotkey{Key: tcell.KeyPgUp, Modifiers: tcell.ModCtrl, Description: "Previous tab"})
+		add(Hotkey{Key: tcell.KeyPgDn, Modifiers: tcell.ModCtrl, Description: "Next tab"})
+	}
+
+	// Navigation keys.
+	for _, navigation := range []struct {
+		keys        []tcell.Key
+		description string
+	}{
+		{f.nextKeys, "Next element"},
+		{f.prevKeys, "Previous element"},
+		{f.submitKeys, "Submit"},
+		{f.cancelKeys, "Cancel"},
+	} {
+		for _, key := range navigation.keys {
+			add(Hotkey{Key: key, Description: navigation.description})
+		}
+	}
+	for _, r := range f.nextRunes {
+		add(Hotkey{Key: tcell.KeyRune, Rune: r, Description: "Next element"})
+	}
+	for _, r := range f.prevRunes {
+		add(Hotkey{Key: tcell.KeyRune, Rune: r, Description: "Previous element"})
+	}
+	if f.vimMode {
+		add(Hotkey{Key: tcell.KeyDown, Description: "Next element"})
+		add(Hotkey{Key: tcell.KeyUp, Description: "Previous element"})
+		add(Hotkey{Key: tcell.KeyRune, Rune: 'j', Description: "Next element"})
+		add(Hotkey{Key: tcell.KeyRune, Rune: 'k', Description: "Previous element"})
+		add(Hotkey{Key: tcell.KeyRune, Rune: 'g', Description: "First element (gg)"})
+		add(Hotkey{Key: tcell.KeyRune, Rune: 'G', Description: "Last element"})
+		add(Hotkey{Key: tcell.KeyRune, Rune: 'i', Description: "Edit item"})
+	}
+
+	// Shortcut letters.
+	if f.shortcutModifier != tcell.ModNone {
+		for _, item := range f.items {
+			if r := f.itemShortcut(item); r != 0 {
+				add(f.shortcutKey(r, "Focus "+item.GetLabel()))
+			}
+		}
+		for _, button := range f.buttons {
+			if r, ok := f.buttonShortcuts[button]; ok {
+				add(f.shortcutKey(r, "Press "+button.GetLabel()))
+			}
+		}
+	}
+	return keys
+}
+
+// shortcutKey returns the key combination of the given shortcut letter (see
+// handleShortcut). Terminals report Ctrl+letter as control keys.
+func (f *FormScrollable) shortcutKey(r rune, description string) Hotkey {
+	r = unicode.ToLower(r)
+	if f.shortcutModifier&tcell.ModCtrl != 0 && r >= 'a' && r <= 'z' {
+		return Hotkey{Key: tcell.KeyCtrlA + tcell.Key(r-'a'), Modifiers: f.shortcutModifier, Description: description}
+	}
+	return Hotkey{Key: tcell.KeyRune, Rune: r, Modifiers: f.shortcutModifier, Description: description}
+}
+
+// RegisterHotkey registers a function which is called when the given key
+// combination is pressed while the form has focus, e.g. F2 to save or Ctrl+L
+// to clear a field. Hotkeys are handled before the key event reaches the
+// focused item. Characters are registered with RegisterHotkeyRune, an error is
+// returned for tcell.KeyRune. An error wrapping ErrHotkeyConflict is returned
+// if the key combination is used by the form itself in its current
+// configuration (see GetFormKeys) or was already registered. Hotkeys take
+// precedence over form keys which are configured later, e.g. navigation keys.
+func (f *FormScrollable) RegisterHotkey(key tcell.Key, modifiers tcell.ModMask, handler func()) error {
+	return f.registerHotkey(Hotkey{Key: key, Modifiers: modifiers, handler: handler})
+}
+
+// RegisterHotkeyRune is like RegisterHotkey but registers a character with
+// the given modifiers, e.g. Alt+s. Characters without modifiers don't reach
+// the items anymore, e.g. input fields, so they should only be registered in
+// forms whose items don't take text. An error is returned for the character 0.
+func (f *FormScrollable) RegisterHotkeyRune(r rune, modifiers tcell.ModMask, handler func()) error {
+	return f.registerHotkey(Hotkey{Key: tcell.KeyRune, Rune: r, Modifiers: modifiers, handler: handler})
+}
+
+// registerHotkey registers the given hotkey (see RegisterHotkey).
+func (f *FormScrollable) registerHotkey(hotkey Hotkey) error {
+	if hotkey.Key == tcell.KeyRune && hotkey.Rune == 0 {
+		return errors.New("character hotkeys require a character")
+	}
+	for _, used := range f.GetFormKeys() {
+		if used.conflicts(hotkey) {
+			return fmt.Errorf("%w: %s is used by the form", ErrHotkeyConflict, hotkey)
+		}
+	}
+	for _, used := range f.hotkeys {
+		if used.conflicts(hotkey) {
+			return fmt.Errorf("%w: %s is already registered", ErrHotkeyConflict, hotkey)
+		}
+	}
+	f.hotkeys = append(f.hotkeys, hotkey)
+	return nil
+}
+
+// UnregisterHotkey removes the hotkey registered for the given key
+// combination, if any.
+func (f *FormScrollable) UnregisterHotkey(key tcell.Key, modifiers tcell.ModMask) *FormScrollable {
+	return f.unregisterHotkey(Hotkey{Key: key, Modifiers: modifiers})
+}
+
+// UnregisterHotkeyRune removes the hotkey registered for the given character
+// and modifiers with RegisterHotkeyRune, if any.
+func (f *FormScrollable) UnregisterHotkeyRune(r rune, modifiers tcell.ModMask) *FormScrollable {
+	return f.unregisterHotkey(Hotkey{Key: tcell.KeyRune, Rune: r, Modifiers: modifiers})
+}
+
+// unregisterHotkey removes the registered hotkey with the key combination of
+// the given one, if any.
+func (f *FormScrollable) unregisterHotkey(hotkey Hotkey) *FormScrollable {
+	for index, used := range f.hotkeys {
+		if used.conflicts(hotkey) {
+			f.hotkeys = append(f.hotkeys[:index], f.hotkeys[index+1:]...)
+			break
+		}
+	}
+	return f
+}
+
+// SetHotkeyDescription sets what the registered hotkey with the key
+// combination of the given one does, e.g. "Save", for the key help (see
+// ShowKeyHelp). The given hotkey only needs its Key, Rune, and Modifiers, e.g.
+// Hotkey{Key: tcell.KeyF2}.
+func (f *FormScrollable) SetHotkeyDescription(hotkey Hotkey, description string) *FormScrollable {
+	for index, used := range f.hotkeys {
+		if used.conflicts(hotkey) {
+			f.hotkeys[index].Description = description
+			break
+		}
+	}
+	return f
+}
+
+// GetHotkeys returns the registered hotkeys in the order they were
+// registered, e.g. to list them in a help text.
+func (f *FormScrollable) GetHotkeys() []Hotkey {
+	return append([]Hotkey(nil), f.hotkeys...)
+}
+
+// handleHotkey calls the handler of the hotkey matching the given event. It
+// returns whether there was such a hotkey.
+func (f *FormScrollable) handleHotkey(event *tcell.EventKey) bool {
+	for _, hotkey := range f.hotkeys {
+		if hotkey.matches(event.Key(), event.Rune(), event.Modifiers()) {
+			if hotkey.handler != nil {
+				hotkey.handler()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// keyHelp is the overlay opened by ShowKeyHelp. It is centered in the form.
+type keyHelp struct {
+	*TextView
+	form          *FormScrollable
+	width, height int
+}
+
+// ShowKeyHelp opens an overlay listing the keys the form handles itself in its
+// current configuration (see GetFormKeys) and the registered hotkeys with
+// their descriptions (see SetHotkeyDescription). The overlay scrolls with the
+// arrow keys, Enter and Escape close it. To open it with a key, register a
+// hotkey, e.g.:
+//
+//	form.RegisterHotkey(tcell.KeyF1, tcell.ModNone, func() { form.ShowKeyHelp() })
+func (f *FormScrollable) ShowKeyHelp() *FormScrollable {
+	keys := f.GetFormKeys()
+	if len(f.hotkeys) > 0 {
+		keys = append(keys, f.hotkeys...)
+	}
+	var nameWidth int
+	for _, key := range keys {
+		nameWidth = max(nameWidth, TaggedStringWidth(Escape(key.String())))
+	}
+	var (
+		b     strings.Builder
+		width int
+	)
+	for index, key := range keys {
+		if index == len(keys)-len(f.hotkeys) {
+			b.WriteString("\n") // Hotkeys are listed after the form's keys.
+		}
+		name := Escape(key.String())
+		line := name + strings.Repeat(" ", nameWidth-TaggedStringWidth(name)+2) + Escape(key.Description)
+		width = max(width, TaggedStringWidth(line))
+		b.WriteString(line + "\n")
+	}
+	text := strings.TrimSuffix(b.String(), "\n")
+
+	help := &keyHelp{
+		TextView: NewTextView().SetDynamicColors(true).SetText(text),
+		form:     f,
+		width:    width + 4,
+		height:   strings.Count(text, "\n") + 3,
+	}
+	help.SetBorder(true).SetTitle(" Keys ")
+	help.SetBorderPadding(0, 0, 1, 1)
+	help.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			f.popup = nil
+		}
+	})
+	f.popup = help
+	return f
+}
+
+// Draw draws the overlay centered in the form, at most as large as the form.
+func (h *keyHelp) Draw(screen tcell.Screen) {
+	x, y, width, height := h.form.GetRect()
+	helpWidth, helpHeight := min(h.width, width), min(h.height, height)
+	h.SetRect(x+(width-helpWidth)/2, y+(height-helpHeight)/2, helpWidth, helpHeight)
+	h.TextView.Draw(screen)
+}
@@ -0,0 +1,112 @@
+package form
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRegisterHotkeyConflicts(t *testing.T) {
+	for name, test := range map[string]struct {
+		configure func(f *FormScrollable)
+		key       tcell.Key
+		r         rune
+		modifiers tcell.ModMask
+	}{
+		"fixed key":       {func(f *FormScrollable) {}, tcell.KeyCtrlZ, 0, tcell.ModNone},
+		"reorderable":     {func(f *FormScrollable) { f.SetItemsReorderable(true) }, tcell.KeyUp, 0, tcell.ModCtrl},
+		"navigation key":  {func(f *FormScrollable) { f.SetNavigationKeys(nil, nil, []tcell.Key{tcell.KeyF2}, nil) }, tcell.KeyF2, 0, tcell.ModNone},
+		"navigation rune": {func(f *FormScrollable) { f.SetNavigationRunes([]rune{'n'}, nil) }, tcell.KeyRune, 'n', tcell.ModNone},
+		"vim mode":        {func(f *FormScrollable) { f.SetVimMode(true) }, tcell.KeyDown, 0, tcell.ModNone},
+		"alt shortcut":    {func(f *FormScrollable) { f.SetItemShortcut(0, 'N') }, tcell.KeyRune, 'n', tcell.ModAlt},
+		"ctrl shortcut": {func(f *FormScrollable) {
+			f.SetShortcutModifier(tcell.ModCtrl).SetItemShortcut(1, 'L')
+		}, tcell.KeyCtrlL, 0, tcell.ModNone},
+		"tabs": {func(f *FormScrollable) { NewTabbedFormScrollable().AddTab("General", f) }, tcell.KeyPgDn, 0, tcell.ModCtrl},
+	} {
+		register := func(f *FormScrollable) error {
+			if test.key == tcell.KeyRune {
+				return f.RegisterHotkeyRune(test.r, test.modifiers, nil)
+			}
+			return f.RegisterHotkey(test.key, test.modifiers, nil)
+		}
+		newForm := func() *FormScrollable {
+			return NewFormScrollable().
+				AddInputField("Name", "", 20, nil, nil).
+				AddButton("Save", nil)
+		}
+		if name != "fixed key" {
+			if err := register(newForm()); err != nil {
+				t.Errorf("%s: expected %v to be free by default, got %v", name, test.key, err)
+			}
+		}
+		f := newForm()
+		test.configure(f)
+		if err := register(f); !errors.Is(err, ErrHotkeyConflict) {
+			t.Errorf("%s: expected a conflict, got %v", name, err)
+		}
+	}
+}
+
+func TestRuneHotkeys(t *testing.T) {
+	var saved int
+	f := NewFormScrollable().AddInputField("Name", "", 20, nil, nil)
+	if err := f.RegisterHotkey(tcell.KeyRune, tcell.ModAlt, nil); err == nil {
+		t.Error("expected an error for a character hotkey without character")
+	}
+	if err := f.RegisterHotkeyRune(0, tcell.ModAlt, nil); err == nil {
+		t.Error("expected an error for the character 0")
+	}
+	if err := f.RegisterHotkeyRune('s', tcell.ModAlt, func() { saved++ }); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.RegisterHotkeyRune('s', tcell.ModAlt, nil); !errors.Is(err, ErrHotkeyConflict) {
+		t.Errorf("expected a conflict for Alt+s, got %v", err)
+	}
+	fc := &focuser{}
+	fc.setFocus(f)
+
+	// Only Alt+s triggers the hotkey, other characters are typed.
+	f.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModAlt), fc.setFocus)
+	fc.press(f, tcell.KeyRune, 's')
+	f.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModAlt), fc.setFocus)
+	if saved != 1 {
+		t.Errorf("expected one save, got %d", saved)
+	}
+	if text := getItemText(f.GetFormItem(0)); text != "s" {
+		t.Errorf("expected the typed character, got %q", text)
+	}
+	if name := f.GetHotkeys()[0].String(); name != "Alt+s" {
+		t.Errorf("expected the name Alt+s, got %q", name)
+	}
+	f.UnregisterHotkeyRune('s', tcell.ModAlt)
+	if count := len(f.GetHotkeys()); count != 0 {
+		t.Errorf("expected no hotkeys, got %d", count)
+	}
+}
+
+func TestShowKeyHelp(t *testing.T) {
+	f := NewFormScrollable().
+		AddInputField("Name", "", 20, nil, nil).
+		SetItemShortcut(0, 'n')
+	if err := f.RegisterHotkey(tcell.KeyF2, tcell.ModNone, nil); err != nil {
+		t.Fatal(err)
+	}
+	f.SetHotkeyDescription(Hotkey{Key: tcell.KeyF2}, "Save")
+	fc := &focuser{}
+	fc.setFocus(f)
+
+	f.ShowKeyHelp()
+	text := screenText(drawForm(t, f, 60, 40))
+	for _, line := range []string{"Tab", "Next element", "Alt+n", "Focus Name", "F2", "Save"} {
+		if !strings.Contains(text, line) {
+			t.Errorf("expected %q in the key help:\n%s", line, text)
+		}
+	}
+	fc.press(f, tcell.KeyEnter, 0)
+	if f.popup != nil {
+		t.Error("expected Enter to close the key help")
+	}
+}
@@ -0,0 +1,264 @@
+package form
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// maxImageZoom is the largest zoom factor of an ImageItem.
+const maxImageZoom = 16
+
+// ImageItem is an image form item which, unlike tview.Image, can receive
+// focus. A focused image item can be zoomed with "+" and "-" ("0" resets the
+// zoom), panned with the arrow keys, and previewed with Enter. Clicking it
+// opens the preview, too.
+type ImageItem struct {
+	*tview.Image
+
+	// The full image.
+	image image.Image
+
+	// The zoom factor (1 shows the full image) and the center of the visible
+	// part of the image, relative to the image's size.
+	zoom             int
+	centerX, centerY float64
+
+	// An optional function which is called when the user wants to see a
+	// preview of the image.
+	preview func(img image.Image)
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var _ tview.FormItem = (*ImageItem)(nil)
+
+// NewImageItem returns a new image item showing the given image.
+func NewImageItem(img image.Image) *ImageItem {
+	i := &ImageItem{
+		Image:   tview.NewImage(),
+		zoom:    1,
+		centerX: 0.5,
+		centerY: 0.5,
+	}
+	i.SetImage(img)
+	return i
+}
+
+// SetImage sets the image to be displayed and resets zoom and pan.
+func (i *ImageItem) SetImage(img image.Image) *ImageItem {
+	i.image = img
+	i.zoom, i.centerX, i.centerY = 1, 0.5, 0.5
+	i.Image.SetImage(img)
+	return i
+}
+
+// GetImage returns the full image.
+func (i *ImageItem) GetImage() image.Image {
+	return i.image
+}
+
+// SetPreviewFunc sets a function which is called when the user presses Enter
+// on the item or clicks it. FormScrollable.AddImageItem sets it to show the
+// image in an overlay.
+func (i *ImageItem) SetPreviewFunc(handler func(img image.Image)) *ImageItem {
+	i.preview = handler
+	return i
+}
+
+// SetZoom sets the zoom factor, between 1 (the full image is shown) and 16.
+func (i *ImageItem) SetZoom(zoom int) *ImageItem {
+	i.zoom = clamp(zoom, 1, maxImageZoom)
+	i.update()
+	return i
+}
+
+// GetZoom returns the zoom factor.
+func (i *ImageItem) GetZoom() int {
+	return i.zoom
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (i *ImageItem) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	i.finished = handler
+	return i
+}
+
+// update shows the visible part of the image according to the zoom factor
+// and the pan position.
+func (i *ImageItem) update() {
+	if i.image == nil {
+		return
+	}
+	bounds := i.image.Bounds()
+	width, height := bounds.Dx()/i.zoom, bounds.Dy()/i.zoom
+
+	// Keep the visible part inside the image.
+	halfX, halfY := 0.5/float64(i.zoom), 0.5/float64(i.zoom)
+	i.centerX = clampFloat(i.centerX, halfX, 1-halfX)
+	i.centerY = clampFloat(i.centerY, halfY, 1-halfY)
+
+	x := bounds.Min.X + int(i.centerX*float64(bounds.Dx())) - width/2
+	y := bounds.Min.Y + int(i.centerY*float64(bounds.Dy())) - height/2
+	visible := image.Rect(x, y, x+width, y+height).Intersect(bounds)
+	if visible == bounds {
+		i.Image.SetImage(i.image)
+	} else {
+		i.Image.SetImage(&croppedImage{Image: i.image, bounds: visible})
+	}
+}
+
+// Draw draws this primitive onto the screen. The label of a focused image
+// item is highlighted.
+func (i *ImageItem) Draw(screen tcell.Screen) {
+	if i.HasFocus() {
+		style := i.GetLabelStyle()
+		i.SetLabelStyle(style.Reverse(true))
+		defer i.SetLabelStyle(style)
+	}
+	i.Image.Draw(screen)
+}
+
+// Focus is called when this primitive receives focus.
+func (i *ImageItem) Focus(delegate func(p tview.Primitive)) {
+	i.Box.Focus(delegate)
+}
+
+// UsesKey returns whether the item uses the given key itself: the arrow keys
+// move the visible part of the image.
+func (i *ImageItem) UsesKey(key tcell.Key) bool {
+	switch key {
+	case tcell.KeyLeft, tcell.KeyRight, tcell.KeyUp, tcell.KeyDown:
+		return true
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive.
+func (i *ImageItem) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return i.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		step := 0.25 / float64(i.zoom)
+		switch key := event.Key(); key {
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape:
+			if i.finished != nil {
+				i.finished(key)
+			}
+		case tcell.KeyEnter:
+			if i.preview != nil {
+				i.preview(i.image)
+			}
+		case tcell.KeyLeft:
+			i.centerX -= step
+			i.update()
+		case tcell.KeyRight:
+			i.centerX += step
+			i.update()
+		case tcell.KeyUp:
+			i.centerY -= step
+			i.update()
+		case tcell.KeyDown:
+			i.centerY += step
+			i.update()
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case '+', '=':
+				i.SetZoom(i.zoom * 2)
+			case '-':
+				i.SetZoom(i.zoom / 2)
+			case '0':
+				i.SetZoom(1)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (i *ImageItem) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return i.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if !i.InRect(event.Position()) {
+			return false, nil
+		}
+		switch action {
+		case tview.MouseLeftDown:
+			setFocus(i)
+			consumed = true
+		case tview.MouseLeftClick:
+			if i.preview != nil {
+				i.preview(i.image)
+			}
+			consumed = true
+		}
+		return
+	})
+}
+
+// croppedImage is the part of an image within the given bounds.
+type croppedImage struct {
+	image.Image
+	bounds image.Rectangle
+}
+
+// Bounds returns the bounds of the visible part.
+func (c *croppedImage) Bounds() image.Rectangle {
+	return c.bounds
+}
+
+// At returns the color of the pixel at the given position.
+func (c *croppedImage) At(x, y int) color.Color {
+	return c.Image.At(x, y)
+}
+
+// imagePreview shows an image in an overlay. Any click or Enter closes it.
+type imagePreview struct {
+	*tview.Image
+	close func()
+}
+
+// InputHandler returns the handler for this primitive.
+func (p *imagePreview) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if event.Key() == tcell.KeyEnter {
+			p.close()
+		}
+	}
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (p *imagePreview) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if action == tview.MouseLeftClick {
+			p.close()
+		}
+		return true, nil
+	}
+}
+
+// AddImageItem adds an image item to the form (see ImageItem). Unlike images
+// added with AddImage, it can be focused, zoomed, and panned. Clicking it or
+// pressing Enter shows the full image in an overlay covering the form.
+func (f *FormScrollable) AddImageItem(label string, img image.Image, width, height, colors int) *FormScrollable {
+	item := NewImageItem(img)
+	item.SetLabel(label).
+		SetSize(height, width).
+		SetAlign(tview.AlignTop, tview.AlignLeft).
+		SetColors(colors)
+	item.SetPreviewFunc(func(img image.Image) {
+		f.showImagePreview(label, img, item.GetColors())
+	})
+	f.addItem(item)
+	return f
+}
+
+// showImagePreview shows the given image in an overlay covering the form.
+func (f *FormScrollable) showImagePreview(title string, img image.Image, colors int) {
+	preview := &imagePreview{
+		Image: tview.NewImage().SetImage(img).SetColors(colors),
+		close: func() { f.popup = nil },
+	}
+	preview.SetBorder(true).SetTitle(title)
+	preview.SetRect(f.GetRect())
+	f.popup = preview
+}
@@ -0,0 +1,482 @@
+package form
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// IPField is a form item for an IPv4 address ("192.168.1.10") or, if CIDR
+// notation is allowed, a network ("10.0.0.0/8"). It has a segment for each
+// octet and one for the prefix length. Digits are typed into the selected
+// segment, which moves on to the next segment after three digits, "." moves
+// to the next octet, and "/" to the prefix length. Left and right select a
+// segment, Backspace deletes the last digit (of the previous segment if the
+// selected one is empty). Segments whose value is out of range are
+// highlighted in the invalid color (see SetInvalidColor).
+type IPField struct {
+	*tview.Box
+
+	// Whether the field has a prefix length segment.
+	cidr bool
+
+	// The digits of the octets and of the prefix length and the selected
+	// segment.
+	segments [5]string
+	segment  int
+
+	// The label and its width (0 means the width of the label text).
+	label      string
+	labelWidth int
+
+	// Colors.
+	labelColor           tcell.Color
+	fieldTextColor       tcell.Color
+	fieldBackgroundColor tcell.Color
+	invalidColor         tcell.Color
+
+	// Whether the item is disabled.
+	disabled bool
+
+	// An optional function which is called when the text changed.
+	changed func(ip net.IP, network *net.IPNet)
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var (
+	_ tview.FormItem  = (*IPField)(nil)
+	_ ItemValuer      = (*IPField)(nil)
+	_ ItemValueSetter = (*IPField)(nil)
+)
+
+// NewIPField returns a new, empty field for an IPv4 address or, if cidr is
+// true, for an address with an optional prefix length.
+func NewIPField(cidr bool) *IPField {
+	return &IPField{
+		Box:                  tview.NewBox(),
+		cidr:                 cidr,
+		labelColor:           tview.Styles.SecondaryTextColor,
+		fieldTextColor:       tview.Styles.PrimaryTextColor,
+		fieldBackgroundColor: tview.Styles.ContrastBackgroundColor,
+		invalidColor:         tcell.ColorRed,
+	}
+}
+
+// SetLabel sets the text to be displayed before the field.
+func (i *IPField) SetLabel(label string) *IPField {
+	i.label = label
+	return i
+}
+
+// GetLabel returns the text to be displayed before the field.
+func (i *IPField) GetLabel() string {
+	return i.label
+}
+
+// SetInvalidColor sets the text color of segments whose value is out of
+// range. The default is red.
+func (i *IPField) SetInvalidColor(color tcell.Color) *IPField {
+	i.invalidColor = color
+	return i
+}
+
+// SetChangedFunc sets a handler which is called when the text changed, with
+// the address and, if a prefix length was given, the network. Both are nil
+// if the text is incomplete or invalid.
+func (i *IPField) SetChangedFunc(handler func(ip net.IP, network *net.IPNet)) *IPField {
+	i.changed = handler
+	return i
+}
+
+// GetText returns the text, e.g. "10.0.0.1/8", or an empty string if no
+// digits were typed.
+func (i *IPField) GetText() string {
+	octets := i.segments[:4]
+	if strings.Join(i.segments[:], "") == "" {
+		return ""
+	}
+	text := strings.Join(octets, ".")
+	if i.cidr && i.segments[4] != "" {
+		text += "/" + i.segments[4]
+	}
+	return text
+}
+
+// SetText sets the text, e.g. "192.168.1.10" or "10.0.0.0/8". An error is
+// returned and the text is not changed if it isn't made of up to four
+// numbers separated by dots, followed by a prefix length if CIDR notation is
+// allowed. Incomplete and invalid addresses are set nonetheless.
+func (i *IPField) SetText(text string) error {
+	var segments [5]string
+	address, prefix, hasPrefix := strings.Cut(text, "/")
+	if hasPrefix {
+		if !i.cidr {
+			return fmt.Errorf("CIDR notation not allowed: %q", text)
+		}
+		segments[4] = prefix
+	}
+	if address != "" {
+		octets := strings.Split(address, ".")
+		if len(octets) > 4 {
+			return fmt.Errorf("too many octets: %q", text)
+		}
+		copy(segments[:], octets)
+	}
+	for _, segment := range segments {
+		if len(segment) > 3 || strings.Trim(segment, "0123456789") != "" {
+			return fmt.Errorf("invalid IP address: %q", text)
+		}
+	}
+	i.setSegments(segments)
+	return nil
+}
+
+// GetIP returns the address or nil if the text is incomplete or invalid.
+func (i *IPField) GetIP() net.IP {
+	ip, _ := i.parse()
+	return ip
+}
+
+// GetIPNet returns the network or nil if no prefix length was given or the
+// text is incomplete or invalid.
+func (i *IPField) GetIPNet() *net.IPNet {
+	_, network := i.parse()
+	return network
+}
+
+// IsValid returns whether the text is a complete, valid address (with a
+// valid prefix length, if one was given).
+func (i *IPField) IsValid() bool {
+	return i.GetIP() != nil
+}
+
+// GetValue returns the text (see GetText).
+func (i *IPField) GetValue() any {
+	return i.GetText()
+}
+
+// SetValue sets the value from text (see SetText), a net.IP, or a
+// *net.IPNet. Other values are ignored.
+func (i *IPField) SetValue(value any) {
+	switch value := value.(type) {
+	case string:
+		i.SetText(value)
+	case net.IP:
+		if ip := value.To4(); ip != nil {
+			i.SetText(ip.String())
+		}
+	case *net.IPNet:
+		if value != nil && value.IP.To4() != nil {
+			i.SetText(value.String())
+		}
+	}
+}
+
+// parse returns the address and the network described by the text, nil if
+// they are incomplete or invalid.
+func (i *IPField) parse() (net.IP, *net.IPNet) {
+	for index := 0; index < 4; index++ {
+		if i.segments[index] == "" || !i.segmentValid(index) {
+			return nil, nil
+		}
+	}
+	ip := net.ParseIP(strings.Join(i.segments[:4], ".")).To4()
+	if ip == nil || !i.cidr || i.segments[4] == "" {
+		return ip, nil
+	}
+	if !i.segmentValid(4) {
+		return nil, nil
+	}
+	bits, _ := strconv.Atoi(i.segments[4])
+	return ip, &net.IPNet{IP: ip.Mask(net.CIDRMask(bits, 32)), Mask: net.CIDRMask(bits, 32)}
+}
+
+// segmentValid returns whether the segment at the given index is empty or
+// in range. Leading zeros are invalid as they are ambiguous (octal or
+// decimal).
+func (i *IPField) segmentValid(index int) bool {
+	segment := i.segments[index]
+	if segment == "" {
+		return true
+	}
+	if len(segment) > 1 && segment[0] == '0' {
+		return false
+	}
+	value, err := strconv.Atoi(segment)
+	if index == 4 {
+		return err == nil && value <= 32
+	}
+	return err == nil && value <= 255
+}
+
+// setSegments sets the segments and calls the "changed" handler if they
+// changed.
+func (i *IPField) setSegments(segments [5]string) {
+	if segments == i.segments {
+		return
+	}
+	i.segments = segments
+	if i.changed != nil {
+		i.changed(i.parse())
+	}
+}
+
+// segmentCount returns the number of segments.
+func (i *IPField) segmentCount() int {
+	if i.cidr {
+		return 5
+	}
+	return 4
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (i *IPField) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	i.labelWidth = labelWidth
+	i.labelColor = labelColor
+	i.SetBackgroundColor(bgColor)
+	i.fieldTextColor = fieldTextColor
+	i.fieldBackgroundColor = fieldBgColor
+	return i
+}
+
+// GetFieldWidth returns the screen width of the field.
+func (i *IPField) GetFieldWidth() int {
+	if i.cidr {
+		return 18 // "255.255.255.255/32"
+	}
+	return 15 // "255.255.255.255"
+}
+
+// GetFieldHeight returns the height of the field.
+func (i *IPField) GetFieldHeight() int {
+	return 1
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (i *IPField) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	i.finished = handler
+	return i
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (i *IPField) SetDisabled(disabled bool) tview.FormItem {
+	i.disabled = disabled
+	if i.finished != nil {
+		i.finished(-1)
+	}
+	return i
+}
+
+// ipSegmentWidth returns the screen width of the segment at the given index.
+func ipSegmentWidth(index int) int {
+	if index == 4 {
+		return 2
+	}
+	return 3
+}
+
+// Draw draws this primitive onto the screen.
+func (i *IPField) Draw(screen tcell.Screen) {
+	i.Box.DrawForSubclass(screen, i)
+
+	x, y, width, height := i.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if i.labelWidth > 0 {
+		labelWidth := min(i.labelWidth, rightLimit-x)
+		tview.Print(screen, i.label, x, y, labelWidth, tview.AlignLeft, i.labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := tview.Print(screen, i.label, x, y, rightLimit-x, tview.AlignLeft, i.labelColor)
+		x += drawnWidth
+	}
+
+	// Draw field.
+	fieldStyle := tcell.StyleDefault.Background(i.fieldBackgroundColor).Foreground(i.fieldTextColor)
+	if i.disabled {
+		fieldStyle = fieldStyle.Background(i.GetBackgroundColor())
+	}
+	for index := 0; index < i.segmentCount(); index++ {
+		if index > 0 {
+			separator := '.'
+			if index == 4 {
+				separator = '/'
+			}
+			if x >= rightLimit {
+				return
+			}
+			screen.SetContent(x, y, separator, nil, fieldStyle)
+			x++
+		}
+		style := fieldStyle
+		if !i.segmentValid(index) {
+			style = style.Foreground(i.invalidColor)
+		}
+		if index == i.segment && i.HasFocus() {
+			style = style.Reverse(true)
+		}
+		for _, r := range fmt.Sprintf("%*s", ipSegmentWidth(index), i.segments[index]) {
+			if x >= rightLimit {
+				return
+			}
+			screen.SetContent(x, y, r, nil, style)
+			x++
+		}
+	}
+}
+
+// segmentAt returns the segment at the given screen column or -1 if there is
+// none.
+func (i *IPField) segmentAt(column int) int {
+	x, _, _, _ := i.GetInnerRect()
+	if i.labelWidth > 0 {
+		x += i.labelWidth
+	} else {
+		x += tview.TaggedStringWidth(i.label)
+	}
+	for index := 0; index < i.segmentCount(); index++ {
+		width := ipSegmentWidth(index) + 1 // Including the separator before it.
+		if index == 0 {
+			width--
+		}
+		if column >= x && column < x+width {
+			return index
+		}
+		x += width
+	}
+	return -1
+}
+
+// Focus is called when this primitive receives focus.
+func (i *IPField) Focus(delegate func(p tview.Primitive)) {
+	if i.disabled && i.finished != nil {
+		i.finished(-1)
+		return
+	}
+	i.Box.Focus(delegate)
+}
+
+// UsesKey returns whether the field uses the given key itself: Left, Right,
+// Home, and End select the segments.
+func (i *IPField) UsesKey(key tcell.Key) bool {
+	switch key {
+	case tcell.KeyLeft, tcell.KeyRight, tcell.KeyHome, tcell.KeyEnd:
+		return true
+	}
+	return false
+}
+
+// SubmitsOnEnter returns true: Enter in the field submits the form like in
+// an input field (see SetSubmitFunc).
+func (i *IPField) SubmitsOnEnter() bool {
+	return true
+}
+
+// InputHandler returns the handler for this primitive.
+func (i *IPField) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return i.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if i.disabled {
+			return
+		}
+		segments := i.segments
+		switch key := event.Key(); key {
+		case tcell.KeyLeft:
+			i.segment = max(i.segment-1, 0)
+		case tcell.KeyRight:
+			i.segment = min(i.segment+1, i.segmentCount()-1)
+		case tcell.KeyHome:
+			i.segment = 0
+		case tcell.KeyEnd:
+			i.segment = i.segmentCount() - 1
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if segments[i.segment] == "" && i.segment > 0 {
+				i.segment--
+			}
+			if text := segments[i.segment]; text != "" {
+				segments[i.segment] = text[:len(text)-1]
+			}
+			i.setSegments(segments)
+		case tcell.KeyDelete:
+			segments[i.segment] = ""
+			i.setSegments(segments)
+		case tcell.KeyRune:
+			switch r := event.Rune(); {
+			case r == '.' && i.segment < 3:
+				i.segment++
+			case r == '/' && i.cidr:
+				i.segment = 4
+			case r >= '0' && r <= '9':
+				if len(segments[i.segment]) >= ipSegmentWidth(i.segment) {
+					if i.segment >= 3 {
+						break // The last octet or the prefix length is full.
+					}
+					i.segment++
+				}
+				segments[i.segment] += string(r)
+				i.setSegments(segments)
+				if len(segments[i.segment]) == ipSegmentWidth(i.segment) && i.segment < 3 {
+					i.segment++
+				}
+			}
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape, tcell.KeyUp, tcell.KeyDown:
+			if i.finished != nil {
+				i.finished(key)
+			}
+		}
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (i *IPField) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return i.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		if !i.disabled {
+			i.SetText(strings.TrimSpace(pastedText))
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (i *IPField) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return i.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if i.disabled || !i.InRect(event.Position()) {
+			return false, nil
+		}
+		if action == tview.MouseLeftDown {
+			setFocus(i)
+			if column, _ := event.Position(); i.segmentAt(column) >= 0 {
+				i.segment = i.segmentAt(column)
+			}
+			consumed = true
+		}
+		return
+	})
+}
+
+// AddIPField adds a field for an IPv4 address to the form (see IPField),
+// with an optional prefix length if allowCIDR is true, initialized with the
+// given text (see IPField.SetText). The field only validates (see Validate)
+// if it is empty or a valid address. The optional "changed" function is
+// called with the address and the network (see IPField.SetChangedFunc).
+func (f *FormScrollable) AddIPField(label, value string, allowCIDR bool, changed func(ip net.IP, network *net.IPNet)) *FormScrollable {
+	field := NewIPField(allowCIDR).SetLabel(label)
+	field.SetText(value)
+	field.SetChangedFunc(changed)
+	f.addItem(field)
+	f.SetValidator(len(f.items)-1, func(text string) error {
+		if text != "" && !field.IsValid() {
+			return fmt.Errorf("invalid IP address")
+		}
+		return nil
+	})
+	return f
+}
@@ -0,0 +1,515 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// itemListEntry is an entry of an ItemList.
+type itemListEntry struct {
+	item   tview.FormItem
+	remove *tview.Button
+}
+
+// ItemList is a form item for a variable number of entries, e.g. several email
+// addresses. Each entry is a form item created by a factory function, shown
+// with a button ("✕") which removes it. A button below the entries ("+ Add")
+// adds an entry. The list grows and shrinks with its entries, the form
+// reflows accordingly.
+//
+// Tab and Backtab move between the entries and the buttons. The entries are
+// set up without label width so that their own labels (if any) are shown in
+// full.
+type ItemList struct {
+	*tview.Box
+
+	// The entries, the function which creates them, and the button which adds
+	// an entry.
+	entries []*itemListEntry
+	factory func(index int) tview.FormItem
+	add     *tview.Button
+
+	// The index of the focused cell. The cells are each entry followed by its
+	// remove button, followed by the add button.
+	focusedCell int
+
+	// The delegate of the last call to Focus, used to move focus between
+	// cells.
+	delegate func(p tview.Primitive)
+
+	// The last key which finished a cell. It is repeated for cells which
+	// cannot receive focus.
+	lastKey tcell.Key
+
+	// Whether a cell is currently being focused. Only then, cells which cannot
+	// receive focus are skipped.
+	focusing bool
+
+	// The label, its width (0 means the width of the label text), and the
+	// width of the entries (0 means all available space).
+	label      string
+	labelWidth int
+	fieldWidth int
+
+	// Colors.
+	labelColor           tcell.Color
+	backgroundColor      tcell.Color
+	fieldTextColor       tcell.Color
+	fieldBackgroundColor tcell.Color
+
+	// Whether the item is disabled.
+	disabled bool
+
+	// An optional function which is called when an entry was added or
+	// removed.
+	changed func(count int)
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var (
+	_ tview.FormItem  = (*ItemList)(nil)
+	_ ItemValuer      = (*ItemList)(nil)
+	_ ItemValueSetter = (*ItemList)(nil)
+)
+
+// NewItemList returns a new, empty list whose entries are created by the given
+// function, which receives the index of the new entry.
+func NewItemList(factory func(index int) tview.FormItem) *ItemList {
+	l := &ItemList{
+		Box:                  tview.NewBox(),
+		factory:              factory,
+		lastKey:              tcell.KeyTab,
+		labelColor:           tview.Styles.SecondaryTextColor,
+		backgroundColor:      tview.Styles.PrimitiveBackgroundColor,
+		fieldTextColor:       tview.Styles.PrimaryTextColor,
+		fieldBackgroundColor: tview.Styles.ContrastBackgroundColor,
+	}
+	l.add = tview.NewButton("+ Add").
+		SetSelectedFunc(func() {
+			l.AddEntry()
+			l.focusedCell = 2 * (len(l.entries) - 1)
+			l.focusCell()
+		})
+	l.add.SetExitFunc(func(key tcell.Key) {
+		l.cellFinished(l.add, key)
+	})
+	l.applyStyles()
+	return l
+}
+
+// SetLabel sets the text to be displayed before the entries.
+func (l *ItemList) SetLabel(label string) *ItemList {
+	l.label = label
+	return l
+}
+
+// GetLabel returns the text to be displayed before the entries.
+func (l *ItemList) GetLabel() string {
+	return l.label
+}
+
+// SetFieldWidth sets the screen width of the entries including their remove
+// buttons. A value of 0 means the entries take all available space.
+func (l *ItemList) SetFieldWidth(width int) *ItemList {
+	l.fieldWidth = width
+	return l
+}
+
+// SetChangedFunc sets a handler which is called with the number of entries
+// when an entry was added or removed.
+func (l *ItemList) SetChangedFunc(handler func(count int)) *ItemList {
+	l.changed = handler
+	return l
+}
+
+// AddEntry appends an entry created by the factory function.
+func (l *ItemList) AddEntry() *ItemList {
+	entry := &itemListEntry{
+		item:   l.factory(len(l.entries)),
+		remove: tview.NewButton("✕"),
+	}
+	entry.item.SetFinishedFunc(func(key tcell.Key) {
+		l.cellFinished(entry.item, key)
+	})
+	entry.remove.SetSelectedFunc(func() {
+		l.removeEntry(entry)
+	})
+	entry.remove.SetExitFunc(func(key tcell.Key) {
+		l.cellFinished(entry.remove, key)
+	})
+	l.entries = append(l.entries, entry)
+	l.applyStyles()
+	if l.changed != nil {
+		l.changed(len(l.entries))
+	}
+	return l
+}
+
+// RemoveEntry removes the entry at the given index.
+func (l *ItemList) RemoveEntry(index int) *ItemList {
+	if index >= 0 && index < len(l.entries) {
+		l.removeEntry(l.entries[index])
+	}
+	return l
+}
+
+// removeEntry removes the given entry. If it had focus, the focus moves to the
+// same cell of the next entry (or the add button).
+func (l *ItemList) removeEntry(entry *itemListEntry) {
+	for index, e := range l.entries {
+		if e != entry {
+			continue
+		}
+		hadFocus := l.HasFocus()
+		l.entries = append(l.entries[:index], l.entries[index+1:]...)
+		if l.focusedCell >= 2*(index+1) {
+			l.focusedCell -= 2
+		} else if l.focusedCell >= 2*index {
+			l.focusedCell = min(l.focusedCell, 2*len(l.entries))
+		}
+		if hadFocus {
+			l.focusCell()
+		}
+		if l.changed != nil {
+			l.changed(len(l.entries))
+		}
+		return
+	}
+}
+
+// GetEntry returns the entry at the given index.
+func (l *ItemList) GetEntry(index int) tview.FormItem {
+	return l.entries[index].item
+}
+
+// GetEntryCount returns the number of entries.
+func (l *ItemList) GetEntryCount() int {
+	return len(l.entries)
+}
+
+// GetValue returns the values of the entries which have a value, in order.
+func (l *ItemList) GetValue() any {
+	var values []any
+	for _, entry := range l.entries {
+		if value, ok := getItemValue(entry.item); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// SetValue adds or removes entries so that there is one for each value of a
+// slice as returned by GetValue and sets their values. Other values are
+// ignored.
+func (l *ItemList) SetValue(value any) {
+	values, ok := value.([]any)
+	if !ok {
+		return
+	}
+	for len(l.entries) > len(values) {
+		l.removeEntry(l.entries[len(l.entries)-1])
+	}
+	for len(l.entries) < len(values) {
+		l.AddEntry()
+	}
+	for index, entry := range l.entries {
+		setItemValue(entry.item, values[index])
+	}
+}
+
+// cells returns the focusable cells in order.
+func (l *ItemList) cells() []tview.Primitive {
+	cells := make([]tview.Primitive, 0, 2*len(l.entries)+1)
+	for _, entry := range l.entries {
+		cells = append(cells, entry.item, entry.remove)
+	}
+	return append(cells, l.add)
+}
+
+// cellFinished is called when the user leaves the given cell with the given
+// key.
+func (l *ItemList) cellFinished(cell tview.Primitive, key tcell.Key) {
+	index := -1
+	for i, c := range l.cells() {
+		if c == cell {
+			index = i
+		}
+	}
+	if index < 0 {
+		return
+	}
+	if key < 0 {
+		if !l.focusing {
+			return // An entry was disabled while not being focused.
+		}
+		key = l.lastKey
+	} else {
+		l.lastKey = key
+	}
+	switch key {
+	case tcell.KeyTab, tcell.KeyEnter:
+		if index+1 < len(l.cells()) && !l.disabled {
+			l.focusedCell = index + 1
+			l.focusCell()
+			return
+		}
+		key = tcell.KeyTab
+	case tcell.KeyBacktab:
+		if index > 0 && !l.disabled {
+			l.focusedCell = index - 1
+			l.focusCell()
+			return
+		}
+	}
+	if l.finished != nil {
+		l.finished(key)
+	}
+}
+
+// focusCell moves focus to the focused cell using the delegate of the last
+// call to Focus.
+func (l *ItemList) focusCell() {
+	if l.delegate != nil {
+		focusing := l.focusing
+		l.focusing = true
+		l.delegate(l.cells()[l.focusedCell])
+		l.focusing = focusing
+	}
+}
+
+// applyStyles passes the colors on to the entries and buttons.
+func (l *ItemList) applyStyles() {
+	buttonStyle := tcell.StyleDefault.Background(l.backgroundColor).Foreground(l.labelColor)
+	buttons := []*tview.Button{l.add}
+	for _, entry := range l.entries {
+		entry.item.SetFormAttributes(0, l.labelColor, l.backgroundColor, l.fieldTextColor, l.fieldBackgroundColor)
+		entry.item.SetDisabled(l.disabled)
+		buttons = append(buttons, entry.remove)
+	}
+	for _, button := range buttons {
+		button.SetStyle(buttonStyle).
+			SetActivatedStyle(buttonStyle.Reverse(true)).
+			SetDisabledStyle(buttonStyle).
+			SetDisabled(l.disabled)
+	}
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (l *ItemList) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	l.labelWidth = labelWidth
+	l.labelColor = labelColor
+	l.backgroundColor = bgColor
+	l.fieldTextColor = fieldTextColor
+	l.fieldBackgroundColor = fieldBgColor
+	l.SetBackgroundColor(bgColor)
+	l.applyStyles()
+	return l
+}
+
+// GetFieldWidth returns the screen width of the entries including their remove
+// buttons. A value of 0 means the entries take all available space.
+func (l *ItemList) GetFieldWidth() int {
+	return l.fieldWidth
+}
+
+// GetFieldHeight returns the height of all entries plus one for the add
+// button.
+func (l *ItemList) GetFieldHeight() int {
+	height := 1
+	for _, entry := range l.entries {
+		height += max(entry.item.GetFieldHeight(), 1)
+	}
+	return height
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (l *ItemList) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	l.finished = handler
+	return l
+}
+
+// SetDisabled sets whether or not the item and all its entries are disabled.
+func (l *ItemList) SetDisabled(disabled bool) tview.FormItem {
+	l.disabled = disabled
+	l.applyStyles()
+	if l.finished != nil {
+		l.finished(-1)
+	}
+	return l
+}
+
+// Draw draws this primitive onto the screen.
+func (l *ItemList) Draw(screen tcell.Screen) {
+	l.Box.DrawForSubclass(screen, l)
+
+	x, y, width, height := l.GetInnerRect()
+	rightLimit, bottomLimit := x+width, y+height
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if l.labelWidth > 0 {
+		labelWidth := min(l.labelWidth, rightLimit-x)
+		tview.Print(screen, l.label, x, y, labelWidth, tview.AlignLeft, l.labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := tview.Print(screen, l.label, x, y, rightLimit-x, tview.AlignLeft, l.labelColor)
+		x += drawnWidth
+	}
+	if l.fieldWidth > 0 {
+		rightLimit = min(rightLimit, x+l.fieldWidth)
+	}
+
+	// Draw the entries, each followed by its remove button. Focused cells are
+	// drawn last (in case of overlaps).
+	var focused []tview.Primitive
+	for _, entry := range l.entries {
+		entryHeight := max(entry.item.GetFieldHeight(), 1)
+		entryWidth := formItemWidth(entry.item)
+		if entryWidth <= 0 {
+			entryWidth = rightLimit - x - 2
+		}
+		entryWidth = clamp(entryWidth, 0, rightLimit-x-2)
+		entry.item.SetRect(x, y, entryWidth, clamp(entryHeight, 0, bottomLimit-y))
+		entry.remove.SetRect(x+entryWidth+1, y, 1, 1)
+		if y < bottomLimit {
+			for _, cell := range []tview.Primitive{entry.item, entry.remove} {
+				if cell.HasFocus() {
+					focused = append(focused, cell)
+				} else {
+					cell.Draw(screen)
+				}
+			}
+		}
+		y += entryHeight
+	}
+	if y < bottomLimit {
+		l.add.SetRect(x, y, min(tview.TaggedStringWidth(l.add.GetLabel()), rightLimit-x), 1)
+		l.add.Draw(screen)
+	}
+	for _, cell := range focused {
+		cell.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus. The focused cell
+// receives focus instead.
+func (l *ItemList) Focus(delegate func(p tview.Primitive)) {
+	if l.disabled && l.finished != nil {
+		l.finished(-1)
+		return
+	}
+	l.delegate = delegate
+	switch l.lastKey {
+	case tcell.KeyTab, tcell.KeyEnter:
+		l.focusedCell = 0
+	case tcell.KeyBacktab:
+		l.focusedCell = 2 * len(l.entries)
+	}
+	l.focusedCell = clamp(l.focusedCell, 0, 2*len(l.entries))
+	l.focusCell()
+}
+
+// HasFocus returns whether or not this item or one of its cells has focus.
+func (l *ItemList) HasFocus() bool {
+	for _, cell := range l.cells() {
+		if cell.HasFocus() {
+			return true
+		}
+	}
+	return l.Box.HasFocus()
+}
+
+// Blur is called when this primitive loses focus.
+func (l *ItemList) Blur() {
+	for _, cell := range l.cells() {
+		if cell.HasFocus() {
+			cell.Blur()
+		}
+	}
+	l.Box.Blur()
+}
+
+// UsesKey returns whether the list uses the given key itself, i.e. whether the
+// focused entry uses it.
+func (l *ItemList) UsesKey(key tcell.Key) bool {
+	for _, cell := range l.cells() {
+		if cell.HasFocus() {
+			item, ok := cell.(tview.FormItem)
+			return ok && itemUsesKey(item, key)
+		}
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive. Events are forwarded to
+// the cell which has focus.
+func (l *ItemList) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		for index, cell := range l.cells() {
+			if cell.HasFocus() {
+				l.focusedCell = index
+				if handler := cell.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+				return
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive. Events are
+// forwarded to the cells.
+func (l *ItemList) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return l.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if l.disabled || !l.InRect(event.Position()) {
+			return false, nil
+		}
+		for index, cell := range l.cells() {
+			index := index
+			consumed, capture = cell.MouseHandler()(action, event, func(p tview.Primitive) {
+				l.focusedCell = index
+				l.delegate = setFocus
+				setFocus(p)
+			})
+			if consumed {
+				return
+			}
+		}
+
+		// Clicks on the label focus the item.
+		if action == tview.MouseLeftDown {
+			setFocus(l)
+			consumed = true
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive. Pasted text is forwarded
+// to the cell which has focus.
+func (l *ItemList) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return l.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		for _, cell := range l.cells() {
+			if cell.HasFocus() {
+				if handler := cell.PasteHandler(); handler != nil {
+					handler(pastedText, setFocus)
+				}
+				return
+			}
+		}
+	})
+}
+
+// AddItemList adds a list with a variable number of entries to the form (see
+// ItemList). The entries are created by the given function, which receives the
+// index of the new entry. The list starts with one entry.
+func (f *FormScrollable) AddItemList(label string, factory func(index int) tview.FormItem) *FormScrollable {
+	f.addItem(NewItemList(factory).
+		SetLabel(label).
+		AddEntry())
+	return f
+}
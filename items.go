@@ -0,0 +1,419 @@
+package form
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// itemState holds additional settings and state the form keeps for a form
+// item.
+type itemState struct {
+	// An optional function which validates the item's value and the error
+	// returned by its last invocation.
+	validator func(value string) error
+	err       error
+
+	// Whether the item is a text view which is not scrollable and whether it
+	// is a section heading (see AddSection).
+	fixed, section bool
+
+	// A text describing the item, shown with the item and used in exported
+	// documents, and whether its value is secret, which is masked in exported
+	// documents.
+	help   string
+	secret bool
+
+	// The key under which the value of a secret item is kept by the secret
+	// provider (see SetItemSecretKey), empty for the item's label.
+	secretKey string
+
+	// Whether the item was disabled with SetItemDisabled.
+	disabled bool
+
+	// Whether the item was hidden with SetItemVisible.
+	hidden bool
+
+	// Whether the item is loading, see SetItemLoading.
+	loading bool
+
+	// A channel which is closed to end the watch of the item's value (see
+	// BindWatch), nil if it isn't watched.
+	watchStop chan struct{}
+
+	// The notice shown below the item (see SetItemNotice), its level, the
+	// item's value when it was set, and when it expires (zero if never).
+	notice        string
+	noticeLevel   NoticeLevel
+	noticeValue   string
+	noticeExpires time.Time
+
+	// The captured default value (see CaptureDefaults), whether it was
+	// captured, and an optional function which is called after the item was
+	// reverted to it.
+	defaultValue any
+	hasDefault   bool
+	revert       func()
+
+	// The value the item was given by its definition in the last Rebuild.
+	defined any
+
+	// The policy for the keys with which the user leaves the item, if it was
+	// set with SetFinishedKeyPolicy.
+	finishedPolicy *FinishedKeyPolicy
+
+	// What happens when the user presses Escape in the item, if it was set
+	// with SetItemEscapePolicy.
+	escapePolicy EscapePolicy
+
+	// An optional function which may consume the keys with which the user
+	// leaves the item (see SetItemFinishedFunc).
+	finished func(key tcell.Key) bool
+
+	// An optional function which is called after the form processed the key
+	// with which the user left the item (see SetItemExitFunc).
+	exit func(key tcell.Key)
+
+	// The style of the item's label and field, if it was set with
+	// SetItemStyle.
+	style *itemStyle
+
+	// Where the item's label is placed, if it was set with
+	// SetItemLabelPlacement.
+	labelPlacement *LabelPlacement
+
+	// Whether the item requires a value.
+	required bool
+
+	// The letter which focuses the item (see SetItemShortcut), 0 if none.
+	shortcut rune
+
+	// Formats the item's text while it doesn't have focus (see
+	// SetItemFormatter), nil if not set.
+	formatter *itemFormatter
+}
+
+// itemMessage is a line of text shown below an item's field. If clicked is
+// not nil, it is called with the message's screen row when the message is
+// clicked.
+type itemMessage struct {
+	text    string
+	color   tcell.Color
+	dim     bool
+	clicked func(y int, setFocus func(p Primitive))
+}
+
+// state returns the state of the given item, creating it if necessary.
+func (f *FormScrollable) state(item FormItem) *itemState {
+	state, ok := f.itemStates[item]
+	if !ok {
+		state = &itemState{}
+		f.itemStates[item] = state
+	}
+	return state
+}
+
+// labelWidth returns the screen width of the given item's label, including
+// the marker of required items. It is only measured again when the label
+// changed.
+func (f *FormScrollable) labelWidth(item FormItem) int {
+	width := f.measureLabel(item).width
+	if state, ok := f.itemStates[item]; ok && state.required {
+		return width + TaggedStringWidth(f.requiredMarker)
+	}
+	return width
+}
+
+// addItem adds the given item to the form and measures its label.
+func (f *FormScrollable) addItem(item FormItem) {
+	f.items = append(f.items, item)
+	f.measureLabel(item)
+}
+
+// itemColors returns the label color, the field text color, and the field
+// background color of the given item.
+func (f *FormScrollable) itemColors(item FormItem) (label, fieldText, fieldBackground tcell.Color) {
+	label, fieldText, fieldBackground = f.styledColors(item, f.labelColor, f.fieldTextColor, f.fieldBackgroundColor)
+	state, ok := f.itemStates[item]
+	switch {
+	case ok && state.disabled:
+		label, _, _ = f.buttonDisabledStyle.Decompose()
+	case ok && state.err != nil:
+		label = f.errorColor
+	case f.isModified(item):
+		label = f.modifiedColor
+	}
+	return
+}
+
+// itemMessages returns the lines of text shown below the given item's field.
+func (f *FormScrollable) itemMessages(item FormItem) []itemMessage {
+	var messages []itemMessage
+	if state, ok := f.itemStates[item]; ok {
+		if state.err != nil {
+			messages = append(messages, itemMessage{text: state.err.Error(), color: f.errorColor})
+		}
+		if notice, ok := f.itemNotice(item, state); ok {
+			messages = append(messages, notice)
+		}
+	}
+	if conflict, ok := f.conflictMessage(item); ok {
+		messages = append(messages, conflict)
+	}
+	if state, ok := f.itemStates[item]; ok {
+		if help, ok := f.helpMessage(state); ok {
+			messages = append(messages, help)
+		}
+	}
+	return messages
+}
+
+// ItemValuer is implemented by custom form items which have a value. It lets
+// GetFormValues and validators access the value of items unknown to this
+// package.
+type ItemValuer interface {
+	// GetValue returns the current value of the item.
+	GetValue() any
+}
+
+// ItemValueSetter is implemented by custom form items whose value can be set.
+// It lets the form restore values of items unknown to this package, e.g. when
+// reverting them (see RevertItem).
+type ItemValueSetter interface {
+	// SetValue sets the value of the item, as returned by GetValue.
+	SetValue(value any)
+}
+
+// ItemKeyUser is implemented by form items which use keys the form would
+// otherwise process itself, e.g. Up and Down to change a value or to select a
+// suggestion, or Home and End to move the cursor. The form leaves such keys to
+// the focused item, including navigation keys (see SetNavigationKeys).
+type ItemKeyUser interface {
+	// UsesKey returns whether the item currently uses the given key.
+	UsesKey(key tcell.Key) bool
+}
+
+// ItemSubmitter is implemented by form items which submit the form when the
+// user presses Enter in them, like single-line input fields (see
+// SetSubmitFunc). Other items, e.g. text areas, leave Enter to the form's
+// navigation.
+type ItemSubmitter interface {
+	// SubmitsOnEnter returns whether Enter in the item submits the form.
+	SubmitsOnEnter() bool
+}
+
+// itemUsesKey returns whether the given item uses the given key itself, such
+// that the form shouldn't process it.
+func itemUsesKey(item FormItem, key tcell.Key) bool {
+	item = unwrapItem(item)
+	if user, ok := item.(ItemKeyUser); ok {
+		return user.UsesKey(key)
+	}
+	switch item := item.(type) {
+	case *TextArea:
+		return true
+	case *TextView:
+		return true // Only scrollable text views receive focus.
+	case *DropDown:
+		return item.IsOpen()
+	case *InputField:
+		return key == tcell.KeyHome || key == tcell.KeyEnd
+	}
+	return false
+}
+
+// GetFormValues returns the current values of all form items which have a
+// value, keyed by their labels: the text of input fields, text areas, and text
+// views (string), the current option of drop-downs (string, empty if none is
+// selected), the checked options of multi-select drop-downs ([]string), the
+// state of checkboxes (bool), and the value of items
+// implementing ItemValuer. If several items share a label, the value of the
+// last one is returned.
+func (f *FormScrollable) GetFormValues() map[string]any {
+	values := make(map[string]any, len(f.items))
+	for _, item := range f.items {
+		if value, ok := getItemValue(item); ok {
+			values[item.GetLabel()] = value
+		}
+	}
+	return values
+}
+
+// getItemValue returns the value of the given form item and whether the item
+// has a value.
+func getItemValue(item FormItem) (any, bool) {
+	switch item := item.(type) {
+	case *WrappedItem:
+		return getItemValue(item.GetItem())
+	case ItemValuer:
+		return item.GetValue(), true
+	case *ComboBox:
+		return item.GetText(), true
+	case *AutocompleteField:
+		return item.GetText(), true
+	case *MaskedInputField:
+		return item.GetText(), true
+	case *InputField:
+		return item.GetText(), true
+	case *TextArea:
+		return item.GetText(), true
+	case *TextView:
+		return item.GetText(true), true
+	case *DropDown:
+		_, option := item.GetCurrentOption()
+		return option, true
+	case *LazyDropDown:
+		_, option := item.GetCurrentOption()
+		return option, true
+	case *OptionsDropDown:
+		if item.multiSelect {
+			return item.GetCheckedTexts(), true
+		}
+		_, option := item.GetCurrentOption()
+		return option, true
+	case *Checkbox:
+		return item.IsChecked(), true
+	}
+	return nil, false
+}
+
+// setItemValue sets the value of the given form item, as returned by
+// getItemValue. As the option texts of tview drop-downs can't be looked up,
+// their value is set by the index of the option instead. Returns false if the
+// item's value can't be set.
+func setItemValue(item FormItem, value any) bool {
+	switch item := item.(type) {
+	case ItemValueSetter:
+		item.SetValue(value)
+		return true
+	case *ComboBox:
+		text, ok := value.(string)
+		if ok {
+			setInputFieldText(item.InputField, text)
+		}
+		return ok
+	case *AutocompleteField:
+		text, ok := value.(string)
+		if ok {
+			setInputFieldText(item.InputField, text)
+		}
+		return ok
+	case *MaskedInputField:
+		text, ok := value.(string)
+		if ok {
+			item.SetText(text)
+		}
+		return ok
+	case *InputField:
+		text, ok := value.(string)
+		if ok {
+			setInputFieldText(item, text)
+		}
+		return ok
+	case *TextArea:
+		text, ok := value.(string)
+		if ok {
+			item.SetText(text, false)
+		}
+		return ok
+	case *DropDown:
+		index, ok := value.(int)
+		if ok {
+			item.SetCurrentOption(index)
+		}
+		return ok
+	case *LazyDropDown:
+		index, ok := value.(int)
+		if ok {
+			item.SetCurrentOption(index)
+		}
+		return ok
+	case *OptionsDropDown:
+		if texts, ok := value.([]string); ok {
+			var indices []int
+			for index, option := range item.options[item.shortcuts:] {
+				for _, text := range texts {
+					if !option.Header && option.Text == text {
+						indices = append(indices, index)
+						break
+					}
+				}
+			}
+			item.SetCheckedOptions(indices)
+			return true
+		}
+		text, ok := value.(string)
+		if !ok {
+			return false
+		}
+		index := -1
+		for i := 0; i < item.GetOptionCount(); i++ {
+			if option := item.GetOption(i); !option.Header && option.Text == text {
+				index = i
+				break
+			}
+		}
+		item.SetCurrentOption(index)
+		return true
+	case *Checkbox:
+		checked, ok := value.(bool)
+		if ok {
+			item.SetChecked(checked)
+		}
+		return ok
+	}
+	return false
+}
+
+// setItemText sets the value of the given form item from text as returned by
+// getItemText. Returns false if the item's value can't be set from the text.
+func setItemText(item FormItem, text string) bool {
+	if wrapped, ok := item.(*WrappedItem); ok {
+		item = wrapped.GetItem()
+	}
+	switch item := item.(type) {
+	case *TimeField:
+		return item.SetText(text) == nil
+	case *Checkbox:
+		checked, err := strconv.ParseBool(text)
+		if err == nil {
+			item.SetChecked(checked)
+		}
+		return err == nil
+	case *OptionsDropDown:
+		if item.multiSelect {
+			return setItemValue(item, strings.Split(text, ", "))
+		}
+	case *TagsField:
+		return setItemValue(item, strings.Split(text, ", "))
+	}
+	return setItemValue(item, text)
+}
+
+// getItemText returns the value of the given form item as text. Items without
+// a value return an empty string.
+func getItemText(item FormItem) string {
+	if wrapped, ok := item.(*WrappedItem); ok {
+		item = wrapped.GetItem()
+	}
+	if field, ok := item.(*TimeField); ok {
+		return field.GetText()
+	}
+	value, ok := getItemValue(item)
+	if !ok {
+		return ""
+	}
+	switch value := value.(type) {
+	case string:
+		return value
+	case []string:
+		return strings.Join(value, ", ")
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,103 @@
+package form
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// drawForm draws the form onto a simulation screen of the given size.
+func drawForm(t *testing.T, f *FormScrollable, width, height int) tcell.SimulationScreen {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(screen.Fini)
+	screen.SetSize(width, height)
+	f.SetRect(0, 0, width, height)
+	f.Draw(screen)
+	return screen
+}
+
+func TestSetItemValueReplacesTextOfOffScreenField(t *testing.T) {
+	f := NewFormScrollable()
+	for i := 0; i < 20; i++ {
+		f.AddInputField("Field", "changed", 20, nil, nil)
+	}
+	f.AddInputField("Last", "changed", 20, nil, nil)
+	f.AddMaskedInputField("Phone", "###-####", "555-1234", nil)
+	drawForm(t, f, 40, 5)
+
+	if err := f.Unmarshal([]byte(`{"items":[{"label":"Last","type":"InputField","value":"v"},{"label":"Phone","type":"MaskedInputField","value":"555-9999"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if text := f.GetFormItem(20).(interface{ GetText() string }).GetText(); text != "v" {
+		t.Errorf("expected %q, got %q", "v", text)
+	}
+	if text := f.GetFormItem(21).(*MaskedInputField).GetText(); text != "555-9999" {
+		t.Errorf("expected %q, got %q", "555-9999", text)
+	}
+}
+
+func TestSetItemValueReplacesTextOfUndrawnField(t *testing.T) {
+	f := NewFormScrollable().AddInputField("Name", "changed", 20, nil, nil)
+	if !setItemValue(f.GetFormItem(0), "v") {
+		t.Fatal("value not set")
+	}
+	if text := f.GetFormItem(0).(interface{ GetText() string }).GetText(); text != "v" {
+		t.Errorf("expected %q, got %q", "v", text)
+	}
+}
+
+func TestMaxLabelWidth(t *testing.T) {
+	f := NewFormScrollable().
+		AddInputField("Name", "", 10, nil, nil).
+		AddInputField("Address", "", 10, nil, nil).
+		AddCheckbox("Up", false, nil)
+	if width := f.GetMaxLabelWidth(); width != 7 {
+		t.Fatalf("expected 7, got %d", width)
+	}
+	f.SetItemRequired(0, true).SetRequiredMarker("**")
+	if width := f.GetMaxLabelWidth(); width != 7 {
+		t.Fatalf("expected 7 with a required name, got %d", width)
+	}
+	f.RemoveFormItem(1)
+	if width := f.GetMaxLabelWidth(); width != 6 {
+		t.Fatalf("expected 6 after removing the longest label, got %d", width)
+	}
+	f.SetItemLabelPlacement(0, LabelAbove)
+	if width := f.GetMaxLabelWidth(); width != 2 {
+		t.Fatalf("expected 2 with the name above its field, got %d", width)
+	}
+	f.GetFormItem(1).(*Checkbox).SetLabel("Enabled")
+	drawForm(t, f, 40, 5)
+	if width := f.GetMaxLabelWidth(); width != 7 {
+		t.Fatalf("expected 7 after relabelling, got %d", width)
+	}
+}
+
+func TestDrawOnlyCapturesDefaults(t *testing.T) {
+	f := NewFormScrollable().
+		AddInputField("A very long label", "", 10, nil, nil).
+		AddCheckbox("Agree", false, nil).
+		SetLabelWidth(6)
+	drawForm(t, f, 40, 5)
+
+	// Drawing captures missing defaults (see CaptureDefaults) but keeps
+	// nothing else, e.g. the shown labels, in the items' states.
+	for item, state := range f.itemStates {
+		if !reflect.DeepEqual(*state, itemState{defaultValue: state.defaultValue, hasDefault: true}) {
+			t.Errorf("%s: unexpected state after drawing: %+v", item.GetLabel(), *state)
+		}
+	}
+	if count := len(f.labels); count != 2 {
+		t.Errorf("expected 2 measured labels, got %d", count)
+	}
+	f.RemoveFormItem(0)
+	if count := len(f.labels); count != 1 {
+		t.Errorf("expected 1 measured label after removing an item, got %d", count)
+	}
+}
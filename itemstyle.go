@@ -0,0 +1,85 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// itemStyle is the style of an item's label and of its field.
+type itemStyle struct {
+	label, field tcell.Style
+}
+
+// SetItemStyle sets the styles of the label and of the field of the form item
+// at the given index, e.g. to highlight it, overriding the form's colors and
+// the style for the item's type (see SetTypeItemStyle). Colors which are
+// tcell.ColorDefault are taken from the form. The labels of disabled, invalid,
+// and modified items keep their colors. Text attributes (e.g. bold) are only
+// applied to input fields, checkboxes, and text areas (labels only for the
+// latter two), other items only use the colors.
+func (f *FormScrollable) SetItemStyle(index int, labelStyle, fieldStyle tcell.Style) *FormScrollable {
+	f.state(f.items[index]).style = &itemStyle{label: labelStyle, field: fieldStyle}
+	return f
+}
+
+// SetTypeItemStyle sets the styles of the labels and of the fields of form
+// items of the same type as the given example, e.g. (*tview.Checkbox)(nil) for
+// all checkboxes (see SetItemStyle).
+func (f *FormScrollable) SetTypeItemStyle(example FormItem, labelStyle, fieldStyle tcell.Style) *FormScrollable {
+	if f.typeStyles == nil {
+		f.typeStyles = make(map[reflect.Type]itemStyle)
+	}
+	f.typeStyles[reflect.TypeOf(example)] = itemStyle{label: labelStyle, field: fieldStyle}
+	return f
+}
+
+// itemStyle returns the style of the given item and whether it has one.
+func (f *FormScrollable) itemStyle(item FormItem) (itemStyle, bool) {
+	if state, ok := f.itemStates[item]; ok && state.style != nil {
+		return *state.style, true
+	}
+	style, ok := f.typeStyles[reflect.TypeOf(item)]
+	return style, ok
+}
+
+// styledColors returns the given colors, replaced by those of the given item's
+// style which are not tcell.ColorDefault.
+func (f *FormScrollable) styledColors(item FormItem, label, fieldText, fieldBackground tcell.Color) (tcell.Color, tcell.Color, tcell.Color) {
+	style, ok := f.itemStyle(item)
+	if !ok {
+		return label, fieldText, fieldBackground
+	}
+	if color, _, _ := style.label.Decompose(); color != tcell.ColorDefault {
+		label = color
+	}
+	text, background, _ := style.field.Decompose()
+	if text != tcell.ColorDefault {
+		fieldText = text
+	}
+	if background != tcell.ColorDefault {
+		fieldBackground = background
+	}
+	return label, fieldText, fieldBackground
+}
+
+// applyItemStyle applies the text attributes of the given item's style, with
+// the given colors, to the items which support them. It is called after the
+// item's form attributes were set.
+func (f *FormScrollable) applyItemStyle(item FormItem, label, fieldText, fieldBackground tcell.Color) {
+	style, ok := f.itemStyle(item)
+	if !ok {
+		return
+	}
+	labelStyle := style.label.Foreground(label).Background(f.GetBackgroundColor())
+	switch item := item.(type) {
+	case *InputField:
+		item.SetLabelStyle(labelStyle).
+			SetFieldStyle(style.field.Foreground(fieldText).Background(fieldBackground))
+	case *Checkbox:
+		item.SetLabelStyle(labelStyle)
+	case *TextArea:
+		item.SetLabelStyle(labelStyle)
+	}
+}
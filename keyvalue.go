@@ -0,0 +1,576 @@
+package form
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// keyValueRow is a row of a KeyValueEditor.
+type keyValueRow struct {
+	key, value *tview.InputField
+	remove     *tview.Button
+}
+
+// KeyValueEditor is a form item for a set of key-value pairs, e.g. environment
+// variables or HTTP headers. Each pair is a row with an input field for the
+// key, one for the value, and a button ("✕") which removes the row. A button
+// below the rows ("+ Add") adds an empty row.
+//
+// Tab and Enter move to the next input field or button, Backtab to the
+// previous one, up and down to the row above and below. Ctrl+D removes the
+// focused row. Rows with an empty key are not part of the item's value.
+type KeyValueEditor struct {
+	*tview.Box
+
+	// The rows and the button which adds a row.
+	rows []*keyValueRow
+	add  *tview.Button
+
+	// The index of the focused cell. The cells are the key field, the value
+	// field, and the remove button of each row, followed by the add button.
+	focusedCell int
+
+	// The delegate of the last call to Focus, used to move focus between
+	// cells.
+	delegate func(p tview.Primitive)
+
+	// The last key which finished the item or one of its cells.
+	lastKey tcell.Key
+
+	// The label, its width (0 means the width of the label text), and the
+	// width of the field (0 means all available space).
+	label      string
+	labelWidth int
+	fieldWidth int
+
+	// The placeholders of the key and value fields.
+	keyPlaceholder, valuePlaceholder string
+
+	// Colors.
+	labelColor           tcell.Color
+	backgroundColor      tcell.Color
+	fieldTextColor       tcell.Color
+	fieldBackgroundColor tcell.Color
+
+	// Whether the item is disabled.
+	disabled bool
+
+	// The pairs last reported to the "changed" handler.
+	pairs map[string]string
+
+	// An optional function which is called when the pairs changed.
+	changed func(pairs map[string]string)
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var (
+	_ tview.FormItem  = (*KeyValueEditor)(nil)
+	_ ItemValuer      = (*KeyValueEditor)(nil)
+	_ ItemValueSetter = (*KeyValueEditor)(nil)
+)
+
+// NewKeyValueEditor returns a new, empty key-value editor.
+func NewKeyValueEditor() *KeyValueEditor {
+	e := &KeyValueEditor{
+		Box:                  tview.NewBox(),
+		lastKey:              tcell.KeyTab,
+		keyPlaceholder:       "key",
+		valuePlaceholder:     "value",
+		labelColor:           tview.Styles.SecondaryTextColor,
+		backgroundColor:      tview.Styles.PrimitiveBackgroundColor,
+		fieldTextColor:       tview.Styles.PrimaryTextColor,
+		fieldBackgroundColor: tview.Styles.ContrastBackgroundColor,
+		pairs:                map[string]string{},
+	}
+	e.add = tview.NewButton("+ Add").
+		SetSelectedFunc(func() {
+			e.addRow("", "")
+			e.focusedCell = 3 * (len(e.rows) - 1)
+			e.focusCell()
+		})
+	e.add.SetExitFunc(func(key tcell.Key) {
+		e.cellFinished(e.add, key)
+	})
+	e.applyStyles()
+	return e
+}
+
+// SetLabel sets the text to be displayed before the rows.
+func (e *KeyValueEditor) SetLabel(label string) *KeyValueEditor {
+	e.label = label
+	return e
+}
+
+// GetLabel returns the text to be displayed before the rows.
+func (e *KeyValueEditor) GetLabel() string {
+	return e.label
+}
+
+// SetFieldWidth sets the screen width of the rows. A value of 0 means the rows
+// take all available space.
+func (e *KeyValueEditor) SetFieldWidth(width int) *KeyValueEditor {
+	e.fieldWidth = width
+	return e
+}
+
+// SetPlaceholders sets the texts shown in empty key and value fields.
+func (e *KeyValueEditor) SetPlaceholders(key, value string) *KeyValueEditor {
+	e.keyPlaceholder, e.valuePlaceholder = key, value
+	for _, row := range e.rows {
+		row.key.SetPlaceholder(key)
+		row.value.SetPlaceholder(value)
+	}
+	return e
+}
+
+// SetPairs replaces the rows with the given pairs, sorted by key.
+func (e *KeyValueEditor) SetPairs(pairs map[string]string) *KeyValueEditor {
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	e.rows = nil
+	for _, key := range keys {
+		e.addRow(key, pairs[key])
+	}
+	e.focusedCell = min(e.focusedCell, 3*len(e.rows))
+	e.notifyChanged()
+	return e
+}
+
+// GetPairs returns the pairs of all rows with a key. If several rows have the
+// same key, the last one wins.
+func (e *KeyValueEditor) GetPairs() map[string]string {
+	pairs := make(map[string]string, len(e.rows))
+	for _, row := range e.rows {
+		if key := row.key.GetText(); key != "" {
+			pairs[key] = row.value.GetText()
+		}
+	}
+	return pairs
+}
+
+// GetValue returns the pairs as a map[string]string (see GetPairs).
+func (e *KeyValueEditor) GetValue() any {
+	return e.GetPairs()
+}
+
+// SetValue sets the pairs from a map[string]string (see SetPairs). Other values
+// are ignored.
+func (e *KeyValueEditor) SetValue(value any) {
+	if pairs, ok := value.(map[string]string); ok {
+		e.SetPairs(pairs)
+	}
+}
+
+// SetChangedFunc sets a handler which is called with the pairs (see GetPairs)
+// when they changed.
+func (e *KeyValueEditor) SetChangedFunc(handler func(pairs map[string]string)) *KeyValueEditor {
+	e.changed = handler
+	return e
+}
+
+// notifyChanged calls the "changed" handler if the pairs changed since it was
+// last called.
+func (e *KeyValueEditor) notifyChanged() {
+	pairs := e.GetPairs()
+	if reflect.DeepEqual(pairs, e.pairs) {
+		return
+	}
+	e.pairs = pairs
+	if e.changed != nil {
+		e.changed(e.GetPairs())
+	}
+}
+
+// addRow appends a row with the given key and value.
+func (e *KeyValueEditor) addRow(key, value string) {
+	row := &keyValueRow{
+		key:    tview.NewInputField().SetText(key).SetPlaceholder(e.keyPlaceholder),
+		value:  tview.NewInputField().SetText(value).SetPlaceholder(e.valuePlaceholder),
+		remove: tview.NewButton("✕"),
+	}
+	for _, field := range []*tview.InputField{row.key, row.value} {
+		field := field
+		field.SetChangedFunc(func(text string) {
+			e.notifyChanged()
+		})
+		field.SetDoneFunc(func(key tcell.Key) {
+			e.cellFinished(field, key)
+		})
+	}
+	row.remove.SetSelectedFunc(func() {
+		e.removeRow(row)
+	})
+	row.remove.SetExitFunc(func(key tcell.Key) {
+		e.cellFinished(row.remove, key)
+	})
+	e.rows = append(e.rows, row)
+	e.applyStyles()
+}
+
+// removeRow removes the given row. If one of its cells had focus, the focus
+// moves to the same cell of the next row (or the add button).
+func (e *KeyValueEditor) removeRow(row *keyValueRow) {
+	for index, r := range e.rows {
+		if r != row {
+			continue
+		}
+		hadFocus := e.HasFocus()
+		e.rows = append(e.rows[:index], e.rows[index+1:]...)
+		if e.focusedCell >= 3*(index+1) {
+			e.focusedCell -= 3
+		} else if e.focusedCell >= 3*index {
+			e.focusedCell = min(e.focusedCell, 3*len(e.rows))
+		}
+		if hadFocus {
+			e.focusCell()
+		}
+		e.notifyChanged()
+		return
+	}
+}
+
+// RemoveRow removes the row at the given index.
+func (e *KeyValueEditor) RemoveRow(index int) *KeyValueEditor {
+	if index >= 0 && index < len(e.rows) {
+		e.removeRow(e.rows[index])
+	}
+	return e
+}
+
+// GetRowCount returns the number of rows.
+func (e *KeyValueEditor) GetRowCount() int {
+	return len(e.rows)
+}
+
+// cells returns the focusable cells in order.
+func (e *KeyValueEditor) cells() []tview.Primitive {
+	cells := make([]tview.Primitive, 0, 3*len(e.rows)+1)
+	for _, row := range e.rows {
+		cells = append(cells, row.key, row.value, row.remove)
+	}
+	return append(cells, e.add)
+}
+
+// cellIndex returns the index of the given cell or -1 if it doesn't exist
+// (anymore).
+func (e *KeyValueEditor) cellIndex(cell tview.Primitive) int {
+	for index, c := range e.cells() {
+		if c == cell {
+			return index
+		}
+	}
+	return -1
+}
+
+// cellFinished is called when the user leaves the given cell with the given
+// key.
+func (e *KeyValueEditor) cellFinished(cell tview.Primitive, key tcell.Key) {
+	index := e.cellIndex(cell)
+	if index < 0 {
+		return
+	}
+	e.lastKey = key
+	switch key {
+	case tcell.KeyTab, tcell.KeyEnter:
+		if index+1 < len(e.cells()) {
+			e.focusedCell = index + 1
+			e.focusCell()
+			return
+		}
+		key = tcell.KeyTab
+	case tcell.KeyBacktab:
+		if index > 0 {
+			e.focusedCell = index - 1
+			e.focusCell()
+			return
+		}
+	}
+	if e.finished != nil {
+		e.finished(key)
+	}
+}
+
+// focusCell moves focus to the focused cell using the delegate of the last
+// call to Focus.
+func (e *KeyValueEditor) focusCell() {
+	if e.delegate != nil {
+		e.delegate(e.cells()[e.focusedCell])
+	}
+}
+
+// applyStyles passes the colors on to the cells.
+func (e *KeyValueEditor) applyStyles() {
+	buttonStyle := tcell.StyleDefault.Background(e.backgroundColor).Foreground(e.labelColor)
+	buttons := []*tview.Button{e.add}
+	for _, row := range e.rows {
+		for _, field := range []*tview.InputField{row.key, row.value} {
+			field.SetFieldTextColor(e.fieldTextColor).
+				SetFieldBackgroundColor(e.fieldBackgroundColor).
+				SetPlaceholderStyle(tcell.StyleDefault.Background(e.fieldBackgroundColor).Foreground(e.labelColor)).
+				SetBackgroundColor(e.backgroundColor)
+			field.SetDisabled(e.disabled)
+		}
+		buttons = append(buttons, row.remove)
+	}
+	for _, button := range buttons {
+		button.SetStyle(buttonStyle).
+			SetActivatedStyle(buttonStyle.Reverse(true)).
+			SetDisabledStyle(buttonStyle).
+			SetDisabled(e.disabled)
+	}
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (e *KeyValueEditor) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	e.labelWidth = labelWidth
+	e.labelColor = labelColor
+	e.backgroundColor = bgColor
+	e.fieldTextColor = fieldTextColor
+	e.fieldBackgroundColor = fieldBgColor
+	e.SetBackgroundColor(bgColor)
+	e.applyStyles()
+	return e
+}
+
+// GetFieldWidth returns the screen width of the rows. A value of 0 means the
+// rows take all available space.
+func (e *KeyValueEditor) GetFieldWidth() int {
+	return e.fieldWidth
+}
+
+// GetFieldHeight returns the number of rows plus one for the add button.
+func (e *KeyValueEditor) GetFieldHeight() int {
+	return len(e.rows) + 1
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (e *KeyValueEditor) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	e.finished = handler
+	return e
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (e *KeyValueEditor) SetDisabled(disabled bool) tview.FormItem {
+	e.disabled = disabled
+	e.applyStyles()
+	if e.finished != nil {
+		e.finished(-1)
+	}
+	return e
+}
+
+// Draw draws this primitive onto the screen.
+func (e *KeyValueEditor) Draw(screen tcell.Screen) {
+	e.Box.DrawForSubclass(screen, e)
+
+	x, y, width, height := e.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if e.labelWidth > 0 {
+		labelWidth := min(e.labelWidth, rightLimit-x)
+		tview.Print(screen, e.label, x, y, labelWidth, tview.AlignLeft, e.labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := tview.Print(screen, e.label, x, y, rightLimit-x, tview.AlignLeft, e.labelColor)
+		x += drawnWidth
+	}
+	if e.fieldWidth > 0 {
+		rightLimit = min(rightLimit, x+e.fieldWidth)
+	}
+
+	// Draw the rows: key, gap, value, gap, remove button.
+	fieldsWidth := max(rightLimit-x-3, 2)
+	keyWidth := fieldsWidth / 2
+	valueWidth := fieldsWidth - keyWidth
+	var focused tview.Primitive
+	for index, row := range e.rows {
+		if index >= height {
+			break
+		}
+		row.key.SetRect(x, y+index, keyWidth, 1)
+		row.value.SetRect(x+keyWidth+1, y+index, valueWidth, 1)
+		row.remove.SetRect(x+keyWidth+valueWidth+2, y+index, 1, 1)
+		for _, cell := range []tview.Primitive{row.key, row.value, row.remove} {
+			if cell.HasFocus() {
+				focused = cell
+			} else {
+				cell.Draw(screen)
+			}
+		}
+	}
+	if len(e.rows) < height {
+		e.add.SetRect(x, y+len(e.rows), min(tview.TaggedStringWidth(e.add.GetLabel()), rightLimit-x), 1)
+		e.add.Draw(screen)
+	}
+
+	// Draw the focused cell last so that it positions the cursor.
+	if focused != nil {
+		focused.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus. The focused cell
+// receives focus instead.
+func (e *KeyValueEditor) Focus(delegate func(p tview.Primitive)) {
+	if e.disabled && e.finished != nil {
+		e.finished(-1)
+		return
+	}
+	e.delegate = delegate
+	switch e.lastKey {
+	case tcell.KeyTab, tcell.KeyEnter:
+		e.focusedCell = 0
+	case tcell.KeyBacktab:
+		e.focusedCell = 3 * len(e.rows)
+	}
+	e.focusedCell = clamp(e.focusedCell, 0, 3*len(e.rows))
+	e.focusCell()
+}
+
+// HasFocus returns whether or not this item or one of its cells has focus.
+func (e *KeyValueEditor) HasFocus() bool {
+	for _, cell := range e.cells() {
+		if cell.HasFocus() {
+			return true
+		}
+	}
+	return e.Box.HasFocus()
+}
+
+// Blur is called when this primitive loses focus.
+func (e *KeyValueEditor) Blur() {
+	for _, cell := range e.cells() {
+		if cell.HasFocus() {
+			cell.Blur()
+		}
+	}
+	e.Box.Blur()
+}
+
+// UsesKey returns whether the editor uses the given key itself: Up and Down
+// move between the rows, Ctrl+D removes a row, and the focused cell may use
+// other keys, e.g. Home and End.
+func (e *KeyValueEditor) UsesKey(key tcell.Key) bool {
+	for index, cell := range e.cells() {
+		if !cell.HasFocus() {
+			continue
+		}
+		row := index / 3
+		switch key {
+		case tcell.KeyUp:
+			return row > 0
+		case tcell.KeyDown, tcell.KeyCtrlD:
+			return row < len(e.rows)
+		}
+		item, ok := cell.(tview.FormItem)
+		return ok && itemUsesKey(item, key)
+	}
+	return false
+}
+
+// InputHandler returns the handler for this primitive. Events are forwarded to
+// the cell which has focus.
+func (e *KeyValueEditor) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return e.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if e.disabled {
+			return
+		}
+		for index, cell := range e.cells() {
+			if !cell.HasFocus() {
+				continue
+			}
+			e.focusedCell = index
+			e.delegate = setFocus
+			row, column := index/3, index%3
+			switch event.Key() {
+			case tcell.KeyUp:
+				if row > 0 {
+					e.focusedCell = 3*(row-1) + column
+					e.focusCell()
+				}
+				return
+			case tcell.KeyDown:
+				if row < len(e.rows) {
+					e.focusedCell = min(3*(row+1)+column, 3*len(e.rows))
+					e.focusCell()
+				}
+				return
+			case tcell.KeyCtrlD:
+				if row < len(e.rows) {
+					e.removeRow(e.rows[row])
+				}
+				return
+			}
+			if handler := cell.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+			return
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive. Events are
+// forwarded to the cells.
+func (e *KeyValueEditor) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return e.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if e.disabled || !e.InRect(event.Position()) {
+			return false, nil
+		}
+		for index, cell := range e.cells() {
+			index := index
+			consumed, capture = cell.MouseHandler()(action, event, func(p tview.Primitive) {
+				e.focusedCell = index
+				e.delegate = setFocus
+				setFocus(p)
+			})
+			if consumed {
+				return
+			}
+		}
+
+		// Clicks on the label focus the item.
+		if action == tview.MouseLeftDown {
+			setFocus(e)
+			consumed = true
+		}
+		return
+	})
+}
+
+// PasteHandler returns the handler for this primitive. Pasted text is forwarded
+// to the cell which has focus.
+func (e *KeyValueEditor) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return e.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		for _, cell := range e.cells() {
+			if cell.HasFocus() {
+				if handler := cell.PasteHandler(); handler != nil {
+					handler(pastedText, setFocus)
+				}
+				return
+			}
+		}
+	})
+}
+
+// AddKeyValueEditor adds an editor for key-value pairs to the form (see
+// KeyValueEditor). The optional "changed" function is called with the pairs
+// whenever they change.
+func (f *FormScrollable) AddKeyValueEditor(label string, pairs map[string]string, changed func(pairs map[string]string)) *FormScrollable {
+	f.addItem(NewKeyValueEditor().
+		SetLabel(label).
+		SetPairs(pairs).
+		SetChangedFunc(changed))
+	return f
+}
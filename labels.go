@@ -0,0 +1,267 @@
+package form
+
+import (
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// LabelPlacement determines where the label of a form item is shown.
+type LabelPlacement int
+
+// Label placements.
+const (
+	LabelLeft   LabelPlacement = iota // Left of the field, aligned with the other labels (the default).
+	LabelAbove                        // In a row of its own above the field, which starts at the left.
+	LabelHidden                       // Not at all, the field starts at the left.
+)
+
+// itemLabel holds the measured label of a form item.
+type itemLabel struct {
+	// The item's label when its width was last measured, that width, and the
+	// width it takes in the label column left of the fields (see
+	// leftLabelWidth), not limited to the fixed label width.
+	label       string
+	width       int
+	columnWidth int
+
+	// The label which is shown instead of the item's label (see
+	// withShownLabel) and the label it was determined for.
+	shown, shownFor string
+}
+
+// measureLabel returns the measured label of the given item. The label is
+// only measured again when it changed. The width of the longest label (see
+// GetMaxLabelWidth) is updated accordingly.
+func (f *FormScrollable) measureLabel(item FormItem) *itemLabel {
+	measured, ok := f.labels[item]
+	if !ok {
+		measured = &itemLabel{}
+		f.labels[item] = measured
+	}
+	if label := item.GetLabel(); !ok || label != measured.label {
+		measured.label = label
+		measured.width = TaggedStringWidth(label)
+		f.updateColumnWidth(item, measured)
+	}
+	return measured
+}
+
+// updateColumnWidth updates the width the given item's label takes in the
+// label column, e.g. after it was marked as required, and the width of the
+// longest label.
+func (f *FormScrollable) updateColumnWidth(item FormItem, measured *itemLabel) {
+	previous := measured.columnWidth
+	measured.columnWidth = 0
+	if f.itemLabelPlacement(item) == LabelLeft {
+		measured.columnWidth = measured.width + TaggedStringWidth(f.requiredMarkerOf(item))
+	}
+	switch {
+	case f.maxLabelStale:
+	case measured.columnWidth >= f.maxLabelWidth:
+		f.maxLabelWidth = measured.columnWidth
+	case previous == f.maxLabelWidth:
+		f.maxLabelStale = true
+	}
+}
+
+// labelChanged updates the width the label of the given item takes in the
+// label column after its placement or its required marker changed.
+func (f *FormScrollable) labelChanged(item FormItem) {
+	f.updateColumnWidth(item, f.measureLabel(item))
+}
+
+// labelsChanged updates the widths the labels of all items take in the label
+// column after the form's label placement or required marker changed.
+func (f *FormScrollable) labelsChanged() {
+	f.maxLabelWidth, f.maxLabelStale = 0, false
+	for _, item := range f.items {
+		f.labelChanged(item)
+	}
+}
+
+// forgetLabel removes the measured label of the given item, which was removed
+// from the form.
+func (f *FormScrollable) forgetLabel(item FormItem) {
+	if measured, ok := f.labels[item]; ok {
+		if measured.columnWidth == f.maxLabelWidth {
+			f.maxLabelStale = true
+		}
+		delete(f.labels, item)
+	}
+}
+
+// SetLabelPlacement sets where the labels of the form items are shown. In
+// narrow forms, placing labels above the fields leaves the fields the whole
+// width instead of shrinking them by the width of the longest label. Items'
+// own placements (see SetItemLabelPlacement) take precedence.
+func (f *FormScrollable) SetLabelPlacement(placement LabelPlacement) *FormScrollable {
+	f.labelPlacement = placement
+	f.labelsChanged()
+	return f
+}
+
+// SetItemLabelPlacement sets where the label of the form item at the given
+// index is shown, overriding the form's placement (see SetLabelPlacement).
+func (f *FormScrollable) SetItemLabelPlacement(index int, placement LabelPlacement) *FormScrollable {
+	f.state(f.items[index]).labelPlacement = &placement
+	f.labelChanged(f.items[index])
+	return f
+}
+
+// itemLabelPlacement returns where the given item's label is shown.
+func (f *FormScrollable) itemLabelPlacement(item FormItem) LabelPlacement {
+	if state, ok := f.itemStates[item]; ok && state.labelPlacement != nil {
+		return *state.labelPlacement
+	}
+	return f.labelPlacement
+}
+
+// SetLabelWidth sets the screen width of the labels left of the fields, not
+// including the space between labels and fields. Longer labels are truncated
+// (see SetLabelTruncation). A width of 0, the default, makes room for the
+// longest label, which wastes space if one label is much longer than the
+// others.
+func (f *FormScrollable) SetLabelWidth(width int) *FormScrollable {
+	f.fixedLabelWidth = max(width, 0)
+	return f
+}
+
+// SetLabelTruncation sets the character which ends labels that were truncated
+// because they don't fit into their width (see SetLabelWidth), e.g. '…'. If
+// it is 0, the default, labels are cut off.
+func (f *FormScrollable) SetLabelTruncation(ellipsis rune) *FormScrollable {
+	f.labelEllipsis = ellipsis
+	return f
+}
+
+// leftLabelWidth returns the screen width of the given item's label if it is
+// shown left of the item's field, 0 otherwise, limited to the fixed label
+// width, if any.
+func (f *FormScrollable) leftLabelWidth(item FormItem) int {
+	width := f.measureLabel(item).columnWidth
+	if f.fixedLabelWidth > 0 {
+		return min(width, f.fixedLabelWidth)
+	}
+	return width
+}
+
+// layoutLabel determines the label which is shown for the given item (see
+// withShownLabel) if its label area is the given number of cells wide,
+// including the space between label and field.
+func (f *FormScrollable) layoutLabel(item FormItem, labelWidth int) {
+	label := unwrapItem(item).GetLabel()
+	marker := f.requiredMarkerOf(item)
+	shortcut := f.itemShortcut(item)
+	shown := underlineShortcut(label, shortcut) + marker
+	switch {
+	case f.itemLabelPlacement(item) != LabelLeft:
+		shown = ""
+	case labelWidth > 0 && f.labelWidth(item) >= labelWidth:
+		shown = underlineShortcut(truncateLabel(label, labelWidth-1-TaggedStringWidth(marker), f.labelEllipsis), shortcut) + marker
+	}
+	measured := f.measureLabel(item)
+	measured.shown, measured.shownFor = shown, label
+}
+
+// truncateLabel returns the given label (which may contain style tags) if it
+// fits into the given width. Otherwise, it returns the longest beginning of the
+// label which fits, including the ellipsis if it is not 0.
+func truncateLabel(label string, width int, ellipsis rune) string {
+	if TaggedStringWidth(label) <= width {
+		return label
+	}
+	if ellipsis != 0 {
+		width--
+	}
+	if width < 0 {
+		return ""
+	}
+	var end int
+	for index := 0; index < len(label); {
+		// Style tags are kept whole.
+		_, size := utf8.DecodeRuneInString(label[index:])
+		next := index + size
+		if label[index] == '[' {
+			if closing := strings.IndexByte(label[index:], ']'); closing > 0 && TaggedStringWidth(label[index:index+closing+1]) == 0 {
+				next = index + closing + 1
+			}
+		}
+		if TaggedStringWidth(label[:next]) > width {
+			break
+		}
+		index, end = next, next
+	}
+	if ellipsis == 0 {
+		return label[:end]
+	}
+	var b strings.Builder
+	b.WriteString(label[:end])
+	b.WriteString(Escape(string(ellipsis)))
+	return b.String()
+}
+
+// withShownLabel calls the given function, which lays out or draws the given
+// item or passes it an event, with the item's label replaced by the label
+// which is shown, i.e. removed if it is not shown left of the item's field,
+// truncated if it doesn't fit, marked if the item is required, or with the
+// item's shortcut letter underlined (see layoutLabel). Items (e.g. input fields)
+// draw their labels themselves and fall back to the width of the label if
+// their label width is 0, also when processing mouse events.
+func (f *FormScrollable) withShownLabel(item FormItem, do func()) {
+	if measured, ok := f.labels[item]; ok {
+		inner := unwrapItem(item)
+		if label := inner.GetLabel(); label == measured.shownFor && label != measured.shown && setItemLabel(inner, measured.shown) {
+			defer setItemLabel(inner, label)
+		}
+	}
+	do()
+}
+
+// setItemLabel sets the label of the given item and returns whether it has a
+// SetLabel function.
+func setItemLabel(item FormItem, label string) bool {
+	method := reflect.ValueOf(item).MethodByName("SetLabel")
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().In(0).Kind() != reflect.String {
+		return false
+	}
+	method.Call([]reflect.Value{reflect.ValueOf(label)})
+	return true
+}
+
+// labelCapture is a primitive which captured the mouse on behalf of a form
+// item whose label must be replaced while it processes mouse events (see
+// withShownLabel).
+type labelCapture struct {
+	Primitive
+	form *FormScrollable
+	item FormItem
+}
+
+// captureWithShownLabel returns the given primitive, which captured the mouse
+// while the given item processed a mouse event, wrapped so that it keeps
+// processing mouse events with the item's shown label.
+func (f *FormScrollable) captureWithShownLabel(item FormItem, capture Primitive) Primitive {
+	if measured, ok := f.labels[item]; capture == nil || !ok || measured.shown == measured.shownFor {
+		return capture
+	}
+	return &labelCapture{Primitive: capture, form: f, item: item}
+}
+
+// MouseHandler returns the mouse handler of the captured primitive, which is
+// called with the item's shown label.
+func (c *labelCapture) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		handler := c.Primitive.MouseHandler()
+		if handler == nil {
+			return false, nil
+		}
+		c.form.withShownLabel(c.item, func() {
+			consumed, capture = handler(action, event, setFocus)
+		})
+		return consumed, c.form.captureWithShownLabel(c.item, capture)
+	}
+}
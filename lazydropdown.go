@@ -0,0 +1,158 @@
+package form
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// LazyDropDown is a drop-down whose options are fetched by a provider function
+// when the drop-down is opened for the first time. While the provider runs,
+// the list shows a loading row. If the provider fails, the list shows the error
+// and a row to retry.
+type LazyDropDown struct {
+	*tview.DropDown
+
+	// The function which fetches the options.
+	provider func(ctx context.Context) ([]string, error)
+
+	// The handler called when the user selects an option. It may be nil.
+	selected func(option string, optionIndex int)
+
+	// The function which runs updates on the application's goroutine.
+	queueUpdate func(update func())
+
+	// Whether the options were fetched and, while they are being fetched, the
+	// function which cancels the provider's context.
+	loaded bool
+	cancel context.CancelFunc
+}
+
+var _ tview.FormItem = (*LazyDropDown)(nil)
+
+// NewLazyDropDown returns a new drop-down which fetches its options with the
+// given provider when it is opened for the first time. The provider is called
+// in its own goroutine. The "selected" function is called when the user
+// selects an option. It may be nil.
+func NewLazyDropDown(provider func(ctx context.Context) ([]string, error), selected func(option string, optionIndex int)) *LazyDropDown {
+	return &LazyDropDown{
+		DropDown: tview.NewDropDown(),
+		provider: provider,
+		selected: selected,
+		queueUpdate: func(update func()) {
+			update()
+		},
+	}
+}
+
+// SetUpdateFunc sets the function which runs updates on the application's
+// goroutine after the provider returned, e.g. tview.Application.QueueUpdateDraw.
+// By default, updates run on the provider's goroutine.
+func (d *LazyDropDown) SetUpdateFunc(queueUpdate func(update func())) *LazyDropDown {
+	d.queueUpdate = queueUpdate
+	return d
+}
+
+// Reload discards the fetched options so that they are fetched again when the
+// drop-down is opened the next time. A running provider is cancelled.
+func (d *LazyDropDown) Reload() *LazyDropDown {
+	if d.cancel != nil {
+		d.cancel()
+		d.cancel = nil
+	}
+	d.loaded = false
+	d.SetSelectedFunc(nil)
+	d.SetOptions(nil, nil)
+	return d
+}
+
+// load calls the provider in the background and replaces the options with
+// its result.
+func (d *LazyDropDown) load() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.setStatus("Loading…", nil)
+	go func() {
+		options, err := d.provider(ctx)
+		d.queueUpdate(func() {
+			if ctx.Err() != nil {
+				return // Cancelled.
+			}
+			cancel()
+			d.cancel = nil
+			if err != nil {
+				d.setStatus(fmt.Sprintf("Error: %s", err), nil)
+				d.AddOption("Retry", func() {
+					d.SetCurrentOption(-1)
+					d.load()
+				})
+				return
+			}
+			d.loaded = true
+			d.SetOptions(options, nil)
+			d.SetCurrentOption(-1)
+			d.SetSelectedFunc(d.selected)
+		})
+	}()
+}
+
+// setStatus replaces the options with a single row showing the given status
+// text. Selecting it calls the given function, which may be nil.
+func (d *LazyDropDown) setStatus(text string, selected func()) {
+	d.SetSelectedFunc(nil)
+	d.SetOptions(nil, nil)
+	d.AddOption(text, func() {
+		d.SetCurrentOption(-1)
+		if selected != nil {
+			selected()
+		}
+	})
+}
+
+// UsesKey returns whether the drop-down uses the given key itself, which it
+// does while it is open.
+func (d *LazyDropDown) UsesKey(key tcell.Key) bool {
+	return d.IsOpen()
+}
+
+// InputHandler returns the handler for this primitive.
+func (d *LazyDropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		d.DropDown.InputHandler()(event, setFocus)
+		if d.IsOpen() && !d.loaded && d.cancel == nil && d.GetOptionCount() == 0 {
+			d.load()
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (d *LazyDropDown) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return d.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		consumed, capture = d.DropDown.MouseHandler()(action, event, setFocus)
+		if d.IsOpen() && !d.loaded && d.cancel == nil && d.GetOptionCount() == 0 {
+			d.load()
+		}
+		return
+	})
+}
+
+// AddDropDownLazy adds a drop-down to the form whose options are fetched with
+// the given provider when it is opened for the first time (see LazyDropDown).
+// If an application was set with SetApplication, the options are set on the
+// application's goroutine. The "selected" function is called when the user
+// selects an option. It may be nil.
+func (f *FormScrollable) AddDropDownLazy(label string, provider func(ctx context.Context) ([]string, error), selected func(option string, optionIndex int)) *FormScrollable {
+	dropDown := NewLazyDropDown(provider, selected)
+	dropDown.SetLabel(label)
+	dropDown.SetUpdateFunc(func(update func()) {
+		if f.app != nil {
+			f.queueUpdateDraw(update)
+		} else {
+			update()
+		}
+	})
+	f.addItem(dropDown)
+	return f
+}
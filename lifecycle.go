@@ -0,0 +1,81 @@
+package form
+
+import "github.com/gdamore/tcell/v2"
+
+// SetShownFunc sets a function which is called when the form becomes visible,
+// e.g. to start tickers, loaders, or autosave timers which are only needed
+// while the user sees the form. The form becomes visible when it is drawn or
+// when Show is called.
+func (f *FormScrollable) SetShownFunc(handler func()) *FormScrollable {
+	f.shownHandler = handler
+	return f
+}
+
+// SetHiddenFunc sets a function which is called when the form becomes
+// invisible. The form becomes invisible when Hide is called or, if the
+// application reports its frames with AfterDraw, when the application's
+// screen was drawn without drawing the form, e.g. because another page of
+// tview.Pages was switched to.
+func (f *FormScrollable) SetHiddenFunc(handler func()) *FormScrollable {
+	f.hiddenHandler = handler
+	return f
+}
+
+// Show marks the form as visible, calling the function set with SetShownFunc
+// if it was invisible.
+func (f *FormScrollable) Show() *FormScrollable {
+	f.setShown(true)
+	return f
+}
+
+// Hide marks the form as invisible, calling the function set with
+// SetHiddenFunc if it was visible. It becomes visible again when it is drawn.
+func (f *FormScrollable) Hide() *FormScrollable {
+	f.setShown(false)
+	return f
+}
+
+// IsShown returns whether the form is visible (see SetShownFunc and
+// SetHiddenFunc).
+func (f *FormScrollable) IsShown() bool {
+	return f.shown
+}
+
+// setShown sets whether the form is visible and calls the corresponding
+// function if this changed.
+func (f *FormScrollable) setShown(shown bool) {
+	if shown == f.shown {
+		return
+	}
+	f.shown = shown
+	handler := f.hiddenHandler
+	if shown {
+		handler = f.shownHandler
+	}
+	if handler != nil {
+		handler()
+	}
+}
+
+// AfterDraw reports that the application finished drawing its screen. If the
+// form was not drawn since the last report, it becomes invisible (see
+// SetHiddenFunc). The form doesn't change the application's after-draw
+// function itself, the application calls AfterDraw from its own, e.g.:
+//
+//	app.SetAfterDrawFunc(form.AfterDraw)
+//
+// Applications with several forms report to each of them. Without reports,
+// the form only becomes invisible when Hide is called.
+func (f *FormScrollable) AfterDraw(screen tcell.Screen) {
+	if !f.drawnInFrame {
+		f.setShown(false)
+	}
+	f.drawnInFrame = false
+}
+
+// markDrawn is called when the form is drawn. It makes the form visible and
+// notes that it was drawn in the current frame (see AfterDraw).
+func (f *FormScrollable) markDrawn() {
+	f.drawnInFrame = true
+	f.setShown(true)
+}
@@ -0,0 +1,39 @@
+package form
+
+import (
+	"testing"
+
+	. "github.com/rivo/tview"
+)
+
+func TestAfterDraw(t *testing.T) {
+	var events []string
+	f := NewFormScrollable().
+		AddInputField("Name", "", 10, nil, nil).
+		SetShownFunc(func() { events = append(events, "shown") }).
+		SetHiddenFunc(func() { events = append(events, "hidden") })
+
+	screen := drawForm(t, f, 20, 3)
+	f.AfterDraw(screen)
+	if !f.IsShown() {
+		t.Fatal("expected the form to stay visible after a frame it was drawn in")
+	}
+	f.AfterDraw(screen)
+	if f.IsShown() {
+		t.Fatal("expected the form to be hidden after a frame without it")
+	}
+	f.Draw(screen)
+	f.Hide()
+	if got, want := len(events), 4; got != want || events[3] != "hidden" {
+		t.Errorf("unexpected events %v", events)
+	}
+}
+
+func TestDrawKeepsAfterDrawFunc(t *testing.T) {
+	app := NewApplication()
+	f := NewFormScrollable().SetApplication(app)
+	drawForm(t, f, 20, 3)
+	if app.GetAfterDrawFunc() != nil {
+		t.Error("drawing the form set the application's after-draw function")
+	}
+}
@@ -0,0 +1,141 @@
+package form
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// loadingInterval is the time between two frames of the loading spinner.
+const loadingInterval = 100 * time.Millisecond
+
+// loadingFrames are the frames of the loading spinner.
+var loadingFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// SetItemLoading sets whether the form item at the given index is loading,
+// e.g. while its options or its initial value are fetched in the background.
+// Instead of a loading item, its label and a spinner are drawn and the item
+// can't receive focus. The spinner is only animated if an application was set
+// with SetApplication.
+//
+// Like all other methods, this one must be called from the application's
+// goroutine. Use FinishItemLoading to populate the item from another
+// goroutine.
+func (f *FormScrollable) SetItemLoading(index int, loading bool) *FormScrollable {
+	item := f.items[index]
+	state := f.state(item)
+	if state.loading == loading {
+		return f
+	}
+	state.loading = loading
+	item.SetDisabled(loading || state.disabled)
+	if loading {
+		f.startLoadingAnimation()
+	} else if !f.isLoading() {
+		f.stopLoadingAnimation()
+	}
+	return f
+}
+
+// IsItemLoading returns whether the form item at the given index is loading,
+// see SetItemLoading.
+func (f *FormScrollable) IsItemLoading(index int) bool {
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	state, ok := f.itemStates[f.items[index]]
+	return ok && state.loading
+}
+
+// FinishItemLoading populates a loading form item and shows it again. It may
+// be called from any goroutine: if an application was set with
+// SetApplication, the update function is queued for the application's
+// goroutine and the form is redrawn afterwards. Otherwise, it is called right
+// away. The update function receives the item, it may be nil. The item is
+// identified by its index at the time of the call.
+func (f *FormScrollable) FinishItemLoading(index int, update func(item FormItem)) {
+	item := f.items[index]
+	finish := func() {
+		if update != nil {
+			update(item)
+		}
+		if index := f.itemIndex(item); index >= 0 {
+			f.SetItemLoading(index, false)
+		}
+	}
+	if f.app != nil {
+		f.queueUpdateDraw(finish)
+	} else {
+		finish()
+	}
+}
+
+// isLoading returns whether any form item is loading.
+func (f *FormScrollable) isLoading() bool {
+	for _, state := range f.itemStates {
+		if state.loading {
+			return true
+		}
+	}
+	return false
+}
+
+// startLoadingAnimation starts redrawing the form regularly to animate the
+// loading spinners, unless it is already running or the form is in
+// low-bandwidth mode.
+func (f *FormScrollable) startLoadingAnimation() {
+	if f.app == nil || f.IsLowBandwidthMode() || f.loadingStop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	f.loadingStop = stop
+	go func() {
+		ticker := time.NewTicker(loadingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				f.queueUpdateDraw(func() {})
+			}
+		}
+	}()
+}
+
+// stopLoadingAnimation stops the redrawing started by startLoadingAnimation.
+func (f *FormScrollable) stopLoadingAnimation() {
+	if f.loadingStop != nil {
+		close(f.loadingStop)
+		f.loadingStop = nil
+	}
+}
+
+// drawLoading draws the label of the given loading item followed by a spinner
+// in the given rectangle.
+func (f *FormScrollable) drawLoading(screen tcell.Screen, item FormItem, rect Rect, labelWidth int) {
+	labelWidth = min(labelWidth, rect.Width)
+	Print(screen, item.GetLabel(), rect.X, rect.Y, labelWidth, AlignLeft, f.labelColor)
+
+	// Draw a skeleton of the field with a spinner.
+	fieldWidth := rect.Width - labelWidth
+	if width := item.GetFieldWidth(); width > 0 && width < fieldWidth {
+		fieldWidth = width
+	}
+	if fieldWidth <= 0 {
+		return
+	}
+	style := tcell.StyleDefault.Background(f.fieldBackgroundColor).Foreground(f.fieldTextColor)
+	if f.IsLowBandwidthMode() {
+		screen.SetContent(rect.X+labelWidth, rect.Y, loadingFrames[0], nil, style)
+		return
+	}
+	for y := rect.Y; y < rect.Y+rect.Height; y++ {
+		for x := rect.X + labelWidth; x < rect.X+labelWidth+fieldWidth; x++ {
+			screen.SetContent(x, y, ' ', nil, style)
+		}
+	}
+	frame := loadingFrames[time.Now().UnixMilli()/loadingInterval.Milliseconds()%int64(len(loadingFrames))]
+	screen.SetContent(rect.X+labelWidth, rect.Y, frame, nil, style)
+}
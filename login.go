@@ -0,0 +1,233 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// Indices of the items of a LoginDialog.
+const (
+	loginBanner = iota
+	loginUsername
+	loginPassword
+	loginShowPassword
+	loginRemember
+)
+
+// LoginDialog is a form for logging in, with fields for the username and the
+// password, a checkbox which shows the password, a "Remember me" checkbox, and
+// a "Log in" button. Pressing the button or Enter in a field calls the login
+// function (see NewLoginDialog). While it runs, the button shows that the
+// user is being logged in and can't be pressed. If it fails, its error is
+// shown in a banner above the fields and the password is cleared. A lockout
+// function (see SetLockoutFunc) may make the user wait after failed attempts.
+//
+// If an application was set (see FormScrollable.SetApplication), the login
+// function is called in a goroutine of its own so that slow logins don't
+// block the user interface. Otherwise, it is called right away.
+//
+// The dialog is a FormScrollable, more items and buttons may be added.
+type LoginDialog struct {
+	*FormScrollable
+
+	// The items and the login button.
+	banner                 *tview.TextView
+	username, password     *tview.InputField
+	showPassword, remember *tview.Checkbox
+	button                 *tview.Button
+
+	// The function which logs the user in and the optional function which
+	// returns how long the user must wait after the given number of failed
+	// attempts in a row.
+	login   func(username, password string, remember bool) error
+	lockout func(failures int) time.Duration
+
+	// Whether the login function is running, the number of failed attempts in
+	// a row, and until when the user must wait.
+	busy        bool
+	failures    int
+	lockedUntil time.Time
+
+	// The label of the button and the label while the login function runs.
+	buttonLabel, busyLabel string
+}
+
+// NewLoginDialog returns a new login dialog which logs the user in with the
+// given function. It returns an error if the login failed, which is shown to
+// the user.
+func NewLoginDialog(login func(username, password string, remember bool) error) *LoginDialog {
+	d := &LoginDialog{
+		FormScrollable: NewFormScrollable(),
+		banner:         tview.NewTextView(),
+		username:       tview.NewInputField().SetLabel("Username"),
+		password:       tview.NewInputField().SetLabel("Password").SetMaskCharacter('*'),
+		showPassword:   tview.NewCheckbox().SetLabel("Show password"),
+		remember:       tview.NewCheckbox().SetLabel("Remember me"),
+		login:          login,
+		buttonLabel:    "Log in",
+		busyLabel:      "Logging in…",
+	}
+	d.banner.SetTextColor(d.errorColor)
+	d.showPassword.SetChangedFunc(func(checked bool) {
+		if checked {
+			d.password.SetMaskCharacter(0)
+		} else {
+			d.password.SetMaskCharacter('*')
+		}
+	})
+	d.AddFormItem(d.banner).
+		AddFormItem(d.username).
+		AddFormItem(d.password).
+		AddFormItem(d.showPassword).
+		AddFormItem(d.remember).
+		AddButton(d.buttonLabel, d.submit).
+		SetItemRequired(loginUsername, true).
+		SetItemVisible(loginBanner, false).
+		SetItemLabelPlacement(loginBanner, LabelHidden).
+		SetSubmitFunc(d.submit)
+	d.button = d.GetButton(0)
+	d.SetFocus(loginUsername)
+	return d
+}
+
+// SetLockoutFunc sets a function which returns how long the user must wait
+// before the next attempt after the given number of failed attempts in a row,
+// e.g. to back off exponentially or to lock the user out after three
+// attempts. A duration of 0 or less doesn't make the user wait.
+func (d *LoginDialog) SetLockoutFunc(handler func(failures int) time.Duration) *LoginDialog {
+	d.lockout = handler
+	return d
+}
+
+// SetButtonLabels sets the label of the login button and its label while the
+// login function runs.
+func (d *LoginDialog) SetButtonLabels(label, busy string) *LoginDialog {
+	d.buttonLabel, d.busyLabel = label, busy
+	if !d.busy {
+		d.button.SetLabel(label)
+	}
+	return d
+}
+
+// SetError shows the given error message in the banner above the fields. An
+// empty message hides the banner.
+func (d *LoginDialog) SetError(message string) *LoginDialog {
+	d.banner.SetText(message).SetSize(strings.Count(message, "\n")+1, 0)
+	d.SetItemVisible(loginBanner, message != "")
+	return d
+}
+
+// SetUsername sets the username, e.g. a remembered one, and moves the focus to
+// the password field if it is not empty.
+func (d *LoginDialog) SetUsername(username string) *LoginDialog {
+	setInputFieldText(d.username, username)
+	if username != "" {
+		d.SetFocus(loginPassword)
+	}
+	return d
+}
+
+// GetUsername returns the entered username.
+func (d *LoginDialog) GetUsername() string {
+	return d.username.GetText()
+}
+
+// SetRemembered sets whether the "Remember me" checkbox is checked.
+func (d *LoginDialog) SetRemembered(remember bool) *LoginDialog {
+	d.remember.SetChecked(remember)
+	return d
+}
+
+// IsRemembered returns whether the "Remember me" checkbox is checked.
+func (d *LoginDialog) IsRemembered() bool {
+	return d.remember.IsChecked()
+}
+
+// IsBusy returns whether the login function is running.
+func (d *LoginDialog) IsBusy() bool {
+	return d.busy
+}
+
+// ResetFailures forgets the failed attempts and ends a lockout.
+func (d *LoginDialog) ResetFailures() *LoginDialog {
+	d.failures, d.lockedUntil = 0, time.Time{}
+	d.button.SetDisabled(false)
+	return d
+}
+
+// submit calls the login function unless it is running, the user must wait,
+// or the form is invalid.
+func (d *LoginDialog) submit() {
+	if d.busy {
+		return
+	}
+	if wait := time.Until(d.lockedUntil); wait > 0 {
+		d.SetError(lockoutMessage(wait))
+		return
+	}
+	if len(d.Validate()) > 0 {
+		return
+	}
+	d.busy = true
+	d.button.SetLabel(d.busyLabel).SetDisabled(true)
+	d.SetError("")
+	username, password, remember := d.username.GetText(), d.password.GetText(), d.remember.IsChecked()
+	if d.app == nil {
+		d.finish(d.login(username, password, remember))
+		return
+	}
+	go func() {
+		err := d.login(username, password, remember)
+		d.queueUpdateDraw(func() {
+			d.finish(err)
+		})
+	}()
+}
+
+// finish updates the dialog after the login function returned the given
+// error.
+func (d *LoginDialog) finish(err error) {
+	d.busy = false
+	d.button.SetLabel(d.buttonLabel).SetDisabled(false)
+	if err == nil {
+		d.failures = 0
+		return
+	}
+	d.failures++
+	setInputFieldText(d.password, "")
+	d.SetFocus(loginPassword)
+	message := err.Error()
+	var wait time.Duration
+	if d.lockout != nil {
+		wait = d.lockout(d.failures)
+	}
+	if wait > 0 {
+		d.lockedUntil = time.Now().Add(wait)
+		d.button.SetDisabled(true)
+		message += "\n" + lockoutMessage(wait)
+		if d.app != nil {
+			time.AfterFunc(wait, func() {
+				d.queueUpdateDraw(d.unlock)
+			})
+		}
+	}
+	d.SetError(message)
+}
+
+// unlock ends the lockout if the user waited long enough.
+func (d *LoginDialog) unlock() {
+	if !d.lockedUntil.IsZero() && !time.Now().Before(d.lockedUntil) {
+		d.lockedUntil = time.Time{}
+		d.button.SetDisabled(false)
+		d.SetError("")
+	}
+}
+
+// lockoutMessage returns the message which tells the user to wait for the
+// given duration.
+func lockoutMessage(wait time.Duration) string {
+	return fmt.Sprintf("Try again in %s.", wait.Round(time.Second))
+}
@@ -0,0 +1,303 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// maskSlot is a position of an input mask: a literal or a character class.
+type maskSlot struct {
+	// The class of characters accepted at this position ('#', 'A', or '*'),
+	// 0 for a literal.
+	class rune
+
+	// The literal character, if this is a literal.
+	literal rune
+}
+
+// accepts returns whether the given character may be typed at this position.
+func (s maskSlot) accepts(r rune) bool {
+	switch s.class {
+	case '#':
+		return r >= '0' && r <= '9'
+	case 'A':
+		return unicode.IsLetter(r)
+	case '*':
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return r == s.literal
+}
+
+// parseMask parses an input mask (see MaskedInputField).
+func parseMask(mask string) []maskSlot {
+	var (
+		slots   []maskSlot
+		escaped bool
+	)
+	for _, r := range mask {
+		switch {
+		case escaped:
+			slots = append(slots, maskSlot{literal: r})
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '#' || r == 'A' || r == '*':
+			slots = append(slots, maskSlot{class: r})
+		default:
+			slots = append(slots, maskSlot{literal: r})
+		}
+	}
+	return slots
+}
+
+// MaskedInputField is an input field whose text follows a mask, e.g.
+// "###-###-####" for phone numbers or "##.##.####" for dates. In the mask,
+// "#" stands for a digit, "A" for a letter, and "*" for a letter or a digit.
+// All other characters are literals, which are inserted automatically when
+// the user types the character following them. A backslash makes the next
+// character a literal, e.g. "\#" for a literal "#".
+//
+// Characters which don't fit the next position are rejected. The user types
+// at the end of the text only: Backspace removes the last character along
+// with the literals before it, Ctrl+U clears the field. While the field has
+// focus or text, the rest of the mask is shown after the text, with "_" for
+// the positions still to be typed.
+type MaskedInputField struct {
+	*tview.InputField
+
+	// The mask and its positions.
+	mask  string
+	slots []maskSlot
+
+	// The width of the label (0 means the width of the label text), which the
+	// input field doesn't reveal.
+	labelWidth int
+}
+
+var _ tview.FormItem = (*MaskedInputField)(nil)
+
+// NewMaskedInputField returns a new, empty input field with the given mask.
+// Its field is as wide as the mask.
+func NewMaskedInputField(mask string) *MaskedInputField {
+	m := &MaskedInputField{
+		InputField: tview.NewInputField(),
+		mask:       mask,
+		slots:      parseMask(mask),
+	}
+	m.SetFieldWidth(len(m.slots) + 1)
+	return m
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause
+// the primitive to use the width of the label string.
+func (m *MaskedInputField) SetLabelWidth(width int) *MaskedInputField {
+	m.labelWidth = width
+	m.InputField.SetLabelWidth(width)
+	return m
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (m *MaskedInputField) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	m.labelWidth = labelWidth
+	m.InputField.SetFormAttributes(labelWidth, labelColor, bgColor, fieldTextColor, fieldBgColor)
+	return m
+}
+
+// GetMask returns the field's mask.
+func (m *MaskedInputField) GetMask() string {
+	return m.mask
+}
+
+// SetText sets the text. Characters which don't fit the mask are dropped and
+// missing literals are inserted, e.g. "5551234567" becomes "555-123-4567"
+// with the mask "###-###-####".
+func (m *MaskedInputField) SetText(text string) *MaskedInputField {
+	var conformed string
+	for _, r := range text {
+		if next, ok := m.insert(conformed, r); ok {
+			conformed = next
+		}
+	}
+	m.setText(conformed)
+	return m
+}
+
+// setText sets the text of the underlying input field if it fits the mask.
+func (m *MaskedInputField) setText(text string) {
+	if m.fits(text) {
+		setInputFieldText(m.InputField, text)
+	}
+}
+
+// fits returns whether the given text fits the mask, i.e. whether each of its
+// characters is accepted at its position and it is not longer than the mask.
+func (m *MaskedInputField) fits(text string) bool {
+	runes := []rune(text)
+	if len(runes) > len(m.slots) {
+		return false
+	}
+	for index, r := range runes {
+		if !m.slots[index].accepts(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetUnmaskedText returns the typed characters without the literals, e.g.
+// "5551234567" for "555-123-4567".
+func (m *MaskedInputField) GetUnmaskedText() string {
+	var text strings.Builder
+	for index, r := range []rune(m.GetText()) {
+		if index < len(m.slots) && m.slots[index].class != 0 {
+			text.WriteRune(r)
+		}
+	}
+	return text.String()
+}
+
+// IsComplete returns whether all positions of the mask were typed.
+func (m *MaskedInputField) IsComplete() bool {
+	return len([]rune(m.GetText())) == len(m.slots)
+}
+
+// insert returns the given text with the given character typed after it,
+// including the literals inserted before it, and whether the character fits
+// the mask.
+func (m *MaskedInputField) insert(text string, r rune) (string, bool) {
+	typed := text
+	for position := len([]rune(text)); position < len(m.slots); position++ {
+		slot := m.slots[position]
+		if slot.accepts(r) {
+			return typed + string(r), true
+		}
+		if slot.class != 0 {
+			break
+		}
+		typed += string(slot.literal)
+	}
+	return text, false
+}
+
+// backspace returns the given text without its last typed character and the
+// literals before it. Text beyond the end of the mask is dropped.
+func (m *MaskedInputField) backspace(text string) string {
+	runes := []rune(text)
+	if len(runes) > len(m.slots) {
+		runes = runes[:len(m.slots)]
+	}
+	if len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+	}
+	for len(runes) > 0 && m.slots[len(runes)-1].class == 0 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes)
+}
+
+// Draw draws the input field and the rest of the mask after its text.
+func (m *MaskedInputField) Draw(screen tcell.Screen) {
+	m.InputField.Draw(screen)
+	text := m.GetText()
+	if text == "" && !m.HasFocus() {
+		return
+	}
+	x, y, width, height := m.GetInnerRect()
+	if height <= 0 {
+		return
+	}
+	right := x + width
+	if m.labelWidth > 0 {
+		x += m.labelWidth
+	} else {
+		x += tview.TaggedStringWidth(m.GetLabel())
+	}
+	if fieldWidth := m.GetFieldWidth(); fieldWidth > 0 {
+		right = min(right, x+fieldWidth)
+	}
+	x += tview.TaggedStringWidth(tview.Escape(text))
+	_, background, _ := m.GetFieldStyle().Decompose()
+	style := m.GetPlaceholderStyle().Background(background)
+	typed := min(len([]rune(text)), len(m.slots))
+	for _, slot := range m.slots[typed:] {
+		if x >= right {
+			break
+		}
+		r := slot.literal
+		if slot.class != 0 {
+			r = '_'
+		}
+		screen.SetContent(x, y, r, nil, style)
+		x++
+	}
+}
+
+// UsesKey returns whether the field uses the given key itself. Like in other
+// input fields, Home and End don't move the focus to the first or the last
+// element, even though the user always types at the end.
+func (m *MaskedInputField) UsesKey(key tcell.Key) bool {
+	return key == tcell.KeyHome || key == tcell.KeyEnd
+}
+
+// SubmitsOnEnter returns true: Enter in the field submits the form like in
+// other input fields (see SetSubmitFunc).
+func (m *MaskedInputField) SubmitsOnEnter() bool {
+	return true
+}
+
+// InputHandler returns the handler for this primitive.
+func (m *MaskedInputField) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		text := m.GetText()
+		switch event.Key() {
+		case tcell.KeyRune:
+			if next, ok := m.insert(text, event.Rune()); ok {
+				m.setText(next)
+			}
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			m.setText(m.backspace(text))
+		case tcell.KeyCtrlU, tcell.KeyCtrlW:
+			setInputFieldText(m.InputField, "")
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape, tcell.KeyDown, tcell.KeyUp:
+			if handler := m.InputField.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		}
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (m *MaskedInputField) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return m.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		text := m.GetText()
+		for _, r := range pastedText {
+			if next, ok := m.insert(text, r); ok {
+				text = next
+			}
+		}
+		m.setText(text)
+	})
+}
+
+// AddMaskedInputField adds an input field whose text follows the given mask
+// (see MaskedInputField), with the given initial value. The field only
+// validates (see Validate) if it is empty or complete. The optional "changed"
+// function is called with the text whenever it changes.
+func (f *FormScrollable) AddMaskedInputField(label, mask, value string, changed func(text string)) *FormScrollable {
+	field := NewMaskedInputField(mask)
+	field.SetText(value)
+	field.SetLabel(label).
+		SetChangedFunc(changed)
+	f.addItem(field)
+	f.SetValidator(len(f.items)-1, func(text string) error {
+		if text != "" && len([]rune(text)) != len(field.slots) {
+			return fmt.Errorf("expected %s", mask)
+		}
+		return nil
+	})
+	return f
+}
@@ -0,0 +1,54 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMaskedInputFieldSetText(t *testing.T) {
+	field := NewMaskedInputField("###-####")
+	field.SetText("5551234")
+	if text := field.GetText(); text != "555-1234" {
+		t.Errorf("expected %q, got %q", "555-1234", text)
+	}
+	field.SetText("5559999")
+	if text := field.GetText(); text != "555-9999" {
+		t.Errorf("expected %q, got %q", "555-9999", text)
+	}
+	if text := field.GetUnmaskedText(); text != "5559999" {
+		t.Errorf("expected %q, got %q", "5559999", text)
+	}
+}
+
+func TestMaskedInputFieldTextLongerThanMask(t *testing.T) {
+	field := NewMaskedInputField("###-####")
+	field.InputField.SetText("555-1234555-9999")
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(30, 1)
+	field.SetRect(0, 0, 30, 1)
+	field.Focus(nil)
+	field.Draw(screen)
+
+	field.InputHandler()(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone), nil)
+	if text := field.GetText(); text != "555-123" {
+		t.Errorf("expected %q, got %q", "555-123", text)
+	}
+}
+
+func TestMaskedInputFieldRejectsNonFittingText(t *testing.T) {
+	field := NewMaskedInputField("AA-##")
+	field.PasteHandler()("ab12x", nil)
+	if text := field.GetText(); text != "ab-12" {
+		t.Errorf("expected %q, got %q", "ab-12", text)
+	}
+	field.InputHandler()(tcell.NewEventKey(tcell.KeyRune, '3', tcell.ModNone), nil)
+	if text := field.GetText(); text != "ab-12" {
+		t.Errorf("expected %q, got %q", "ab-12", text)
+	}
+}
@@ -0,0 +1,249 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// MasterDetail pairs a list of records (the master) with a form which shows
+// the selected record (the detail), the common layout of CRUD applications.
+// When the selection changes, the form is filled with the selected record by
+// the load function (see SetLoadFunc) and its values become its defaults (see
+// FormScrollable.CaptureDefaults). If the form is dirty then, the user is
+// asked first whether to save the changes (see SetSaveFunc), discard them, or
+// stay with the current record.
+//
+// The list has focus initially. Enter and Tab move the focus to the form,
+// Escape in the form moves it back to the list unless the form has a cancel
+// handler (see FormScrollable.SetCancelFunc).
+type MasterDetail struct {
+	*tview.Pages
+
+	// The master list, the detail form, and their layout.
+	list   *tview.List
+	form   *FormScrollable
+	layout *tview.Flex
+
+	// The index of the record shown in the form, -1 if none.
+	current int
+
+	// The index of the record to be selected once the user answered the
+	// question about the form's changes, -1 if the question isn't shown.
+	pending int
+
+	// Whether the list's selection is being changed by us.
+	selecting bool
+
+	// The question asked when the selection changes while the form is dirty.
+	question string
+
+	// The function which fills the form with a record and the optional
+	// function which saves the form's changes to a record.
+	load func(index int, form *FormScrollable)
+	save func(index int, form *FormScrollable) error
+
+	// The delegate of the last call to Focus, used to move the focus between
+	// the list and the form.
+	delegate func(p tview.Primitive)
+}
+
+// NewMasterDetail returns a new master-detail layout with the given list and
+// form. The list is shown left of the form and takes a third of the width
+// (see SetMasterWidth). The list's "changed" and "selected" handlers are used
+// by the layout and must not be replaced.
+func NewMasterDetail(list *tview.List, form *FormScrollable) *MasterDetail {
+	m := &MasterDetail{
+		Pages:    tview.NewPages(),
+		list:     list,
+		form:     form,
+		layout:   tview.NewFlex().AddItem(list, 0, 1, true).AddItem(form, 0, 2, false),
+		current:  -1,
+		pending:  -1,
+		question: "Save changes?",
+	}
+	list.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		m.selectionChanged(index)
+	})
+	list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		m.focusForm()
+	})
+	m.AddPage("detail", m.layout, true, true)
+	return m
+}
+
+// GetList returns the master list.
+func (m *MasterDetail) GetList() *tview.List {
+	return m.list
+}
+
+// GetForm returns the detail form.
+func (m *MasterDetail) GetForm() *FormScrollable {
+	return m.form
+}
+
+// SetMasterWidth sets the screen width of the list. A width of 0, the
+// default, gives the list a third of the width.
+func (m *MasterDetail) SetMasterWidth(width int) *MasterDetail {
+	if width > 0 {
+		m.layout.ResizeItem(m.list, width, 0)
+	} else {
+		m.layout.ResizeItem(m.list, 0, 1)
+	}
+	return m
+}
+
+// SetLoadFunc sets the function which fills the form with the record at the
+// given index of the list, e.g. by setting the items' values or by rebuilding
+// the form (see FormScrollable.Rebuild).
+func (m *MasterDetail) SetLoadFunc(handler func(index int, form *FormScrollable)) *MasterDetail {
+	m.load = handler
+	return m
+}
+
+// SetSaveFunc sets the function which saves the values of the form to the
+// record at the given index. It is called when the user chooses to save the
+// changes before selecting another record. If it returns an error, the
+// current record stays selected. Without a save function, the changes can
+// only be discarded.
+func (m *MasterDetail) SetSaveFunc(handler func(index int, form *FormScrollable) error) *MasterDetail {
+	m.save = handler
+	return m
+}
+
+// SetQuestion sets the question asked when the selection changes while the
+// form is dirty. The default is "Save changes?".
+func (m *MasterDetail) SetQuestion(question string) *MasterDetail {
+	m.question = question
+	return m
+}
+
+// GetCurrent returns the index of the record shown in the form, -1 if none.
+func (m *MasterDetail) GetCurrent() int {
+	return m.current
+}
+
+// Refresh loads the selected record into the form again, discarding its
+// changes, e.g. after the record was changed elsewhere or the list changed.
+func (m *MasterDetail) Refresh() *MasterDetail {
+	m.show(m.list.GetCurrentItem())
+	return m
+}
+
+// Saved marks the form's values as saved, e.g. after the app saved them
+// itself, so that the user isn't asked about them.
+func (m *MasterDetail) Saved() *MasterDetail {
+	m.form.CaptureDefaults()
+	return m
+}
+
+// selectionChanged is called when the list's selection changed to the given
+// index.
+func (m *MasterDetail) selectionChanged(index int) {
+	if m.selecting || index == m.current {
+		return
+	}
+	if m.pending >= 0 {
+		m.selectItem(m.current) // The question is still open.
+		return
+	}
+	if m.current < 0 || !m.form.IsDirty() {
+		m.show(index)
+		return
+	}
+
+	// Keep the current record selected until the user decided.
+	m.selectItem(m.current)
+	m.pending = index
+	buttons := []string{"Discard", "Cancel"}
+	if m.save != nil {
+		buttons = append([]string{"Save"}, buttons...)
+	}
+	modal := tview.NewModal().
+		SetText(m.question).
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			m.answered(buttonLabel)
+		})
+	m.AddPage("question", modal, true, true)
+}
+
+// answered closes the question about the form's changes after the user
+// pressed the button with the given label (empty for Escape).
+func (m *MasterDetail) answered(button string) {
+	pending := m.pending
+	m.pending = -1
+	m.RemovePage("question")
+	switch button {
+	case "Save":
+		if err := m.save(m.current, m.form); err != nil {
+			return
+		}
+		m.show(pending)
+	case "Discard":
+		m.show(pending)
+	}
+}
+
+// show selects the record at the given index and loads it into the form.
+// Without a load function, no record is shown yet.
+func (m *MasterDetail) show(index int) {
+	if index < 0 || index >= m.list.GetItemCount() || m.load == nil {
+		m.current = -1
+		return
+	}
+	m.current = index
+	m.selectItem(index)
+	m.load(index, m.form)
+	m.form.CaptureDefaults()
+}
+
+// selectItem selects the list item at the given index without reacting to
+// the change.
+func (m *MasterDetail) selectItem(index int) {
+	m.selecting = true
+	defer func() {
+		m.selecting = false
+	}()
+	m.list.SetCurrentItem(index)
+}
+
+// focusForm moves the focus to the form.
+func (m *MasterDetail) focusForm() {
+	if m.delegate != nil && m.current >= 0 {
+		m.delegate(m.form)
+	}
+}
+
+// Draw draws the list and the form. If no record is shown yet, the selected
+// one is loaded first.
+func (m *MasterDetail) Draw(screen tcell.Screen) {
+	if m.current < 0 && m.pending < 0 && m.list.GetItemCount() > 0 {
+		m.show(m.list.GetCurrentItem())
+	}
+	m.Pages.Draw(screen)
+}
+
+// Focus is called when this primitive receives focus.
+func (m *MasterDetail) Focus(delegate func(p tview.Primitive)) {
+	m.delegate = delegate
+	m.Pages.Focus(delegate)
+}
+
+// InputHandler returns the handler for this primitive.
+func (m *MasterDetail) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return m.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if m.pending < 0 {
+			switch {
+			case event.Key() == tcell.KeyTab && m.list.HasFocus():
+				m.focusForm()
+				return
+			case event.Key() == tcell.KeyEscape && m.form.HasFocus() && m.form.cancel == nil && m.form.popup == nil:
+				setFocus(m.list)
+				return
+			}
+		}
+		if handler := m.Pages.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// SetNavigationKeys sets additional keys which move the focus to the next or
+// the previous element, submit the form (see SetSubmitFunc), or cancel it
+// (see SetCancelFunc), e.g. Up and Down to move between fields. The keys act
+// like Tab, Backtab, and Escape, respectively, which keep working, including
+// the items' finished-key policies (see SetFinishedKeyPolicy) and the
+// escape policies (see SetItemEscapePolicy). A navigation key is left to the
+// focused item if the item uses it itself (see ItemKeyUser), e.g. Up and Down
+// in text areas, time fields, and open drop-downs. Keys listed for several
+// actions are used for the first of them. Nil slices remove the keys.
+func (f *FormScrollable) SetNavigationKeys(next, prev, submit, cancel []tcell.Key) *FormScrollable {
+	f.nextKeys, f.prevKeys, f.submitKeys, f.cancelKeys = next, prev, submit, cancel
+	return f
+}
+
+// SetNavigationRunes sets characters which move the focus to the next or the
+// previous element, e.g. 'j' and 'k' for vi-style navigation. They are only
+// used while an element has focus which doesn't take text input, i.e. a
+// button, a checkbox, or a text view. Other items receive them as usual.
+func (f *FormScrollable) SetNavigationRunes(next, prev []rune) *FormScrollable {
+	f.nextRunes, f.prevRunes = next, prev
+	return f
+}
+
+// navigate processes the given event if it is a navigation key (see
+// SetNavigationKeys). It returns whether the event was consumed.
+func (f *FormScrollable) navigate(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	if f.navigating || event.Modifiers()&(tcell.ModAlt|tcell.ModMeta) != 0 {
+		return false
+	}
+	var translated tcell.Key
+	if key := event.Key(); key == tcell.KeyRune {
+		switch {
+		case f.focusedItemTakesText():
+			return false
+		case containsRune(f.nextRunes, event.Rune()):
+			translated = tcell.KeyTab
+		case containsRune(f.prevRunes, event.Rune()):
+			translated = tcell.KeyBacktab
+		default:
+			return false
+		}
+	} else {
+		switch {
+		case f.focusedItemUsesKey(key):
+			return false
+		case containsKey(f.nextKeys, key):
+			translated = tcell.KeyTab
+		case containsKey(f.prevKeys, key):
+			translated = tcell.KeyBacktab
+		case containsKey(f.submitKeys, key):
+			return f.submitForm(setFocus)
+		case containsKey(f.cancelKeys, key):
+			translated = tcell.KeyEscape
+		default:
+			return false
+		}
+		if translated == key {
+			return false
+		}
+	}
+
+	f.processAs(translated, setFocus)
+	return true
+}
+
+// processAs processes the given key, which a navigation key stands for.
+// Navigation keys are not translated again meanwhile.
+func (f *FormScrollable) processAs(key tcell.Key, setFocus func(p Primitive)) {
+	f.navigating = true
+	defer func() {
+		f.navigating = false
+	}()
+	f.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), setFocus)
+}
+
+// focusedItemTakesText returns whether the focused element may take text
+// input. Only buttons, checkboxes, and text views don't.
+func (f *FormScrollable) focusedItemTakesText() bool {
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	switch unwrapItem(f.items[index]).(type) {
+	case *Checkbox, *TextView:
+		return false
+	}
+	return true
+}
+
+// containsKey returns whether the given keys contain the given key.
+func containsKey(keys []tcell.Key, key tcell.Key) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// containsRune returns whether the given characters contain the given
+// character.
+func containsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// navigationForm returns a form with the given item followed by a checkbox,
+// using Down and Up to move the focus, and focuses the item.
+func navigationForm(item FormItem) (*FormScrollable, *focuser) {
+	f := NewFormScrollable().
+		AddFormItem(item).
+		AddCheckbox("Next", false, nil).
+		SetNavigationKeys([]tcell.Key{tcell.KeyDown}, []tcell.Key{tcell.KeyUp}, nil, nil)
+	fc := &focuser{}
+	fc.setFocus(f)
+	return f, fc
+}
+
+func TestNavigationKeysLeftToTimeField(t *testing.T) {
+	field := NewTimeField().SetClock(10, 30)
+	f, fc := navigationForm(field)
+	fc.press(f, tcell.KeyRight, 0)
+	fc.press(f, tcell.KeyDown, 0)
+	if text := field.GetText(); text != "10:29" {
+		t.Errorf("expected %q, got %q", "10:29", text)
+	}
+	if index, _ := f.GetFocusedItemIndex(); index != 0 {
+		t.Errorf("expected the focus to stay on the time field, got item %d", index)
+	}
+}
+
+func TestNavigationKeysLeftToWrappedItem(t *testing.T) {
+	field := NewTimeField().SetClock(10, 30)
+	f, fc := navigationForm(WrapItem(field, &BorderDecorator{}))
+	fc.press(f, tcell.KeyUp, 0)
+	if text := field.GetText(); text != "11:30" {
+		t.Errorf("expected %q, got %q", "11:30", text)
+	}
+}
+
+func TestNavigationKeysMoveFocusFromClosedComboBox(t *testing.T) {
+	comboBox := NewComboBox([]string{"alpha", "beta"})
+	f, fc := navigationForm(comboBox)
+	if comboBox.UsesKey(tcell.KeyDown) {
+		t.Fatal("expected a combo box without suggestions to leave Down to the form")
+	}
+	fc.press(f, tcell.KeyDown, 0)
+	if index, _ := f.GetFocusedItemIndex(); index != 1 {
+		t.Errorf("expected the focus to move to the checkbox, got item %d", index)
+	}
+}
+
+func TestNavigationKeysLeftToComboBoxSuggestions(t *testing.T) {
+	comboBox := NewComboBox([]string{"alpha", "beta"})
+	f, fc := navigationForm(comboBox)
+	fc.press(f, tcell.KeyRune, 'a')
+	if !comboBox.UsesKey(tcell.KeyDown) {
+		t.Fatal("expected the combo box to use Down while suggestions are shown")
+	}
+	fc.press(f, tcell.KeyEscape, 0)
+	if comboBox.UsesKey(tcell.KeyDown) {
+		t.Fatal("expected Escape to hide the suggestions")
+	}
+}
+
+func TestNavigationKeysInKeyValueEditor(t *testing.T) {
+	editor := NewKeyValueEditor().SetPairs(map[string]string{"a": "1"})
+	f, fc := navigationForm(editor)
+	if !editor.UsesKey(tcell.KeyDown) || editor.UsesKey(tcell.KeyUp) {
+		t.Fatal("expected the editor to use Down but not Up in its first row")
+	}
+	fc.press(f, tcell.KeyDown, 0)
+	if index, _ := f.GetFocusedItemIndex(); index != 0 {
+		t.Errorf("expected the focus to stay in the editor, got item %d", index)
+	}
+}
+
+func TestHomeEndLeftToItems(t *testing.T) {
+	for name, item := range map[string]FormItem{
+		"masked input field": NewMaskedInputField("###-####"),
+		"time field":         NewTimeField(),
+		"IP field":           NewIPField(false),
+		"chat input":         NewChatInput(),
+		"wrapped item":       WrapItem(NewMaskedInputField("###"), &BorderDecorator{}),
+		"composite item":     NewCompositeFormItem("Parts", NewMaskedInputField("###")),
+	} {
+		f := NewFormScrollable().
+			AddCheckbox("First", false, nil).
+			AddFormItem(item)
+		fc := &focuser{}
+		fc.setFocus(f)
+		f.SetFocus(1)
+		fc.setFocus(f)
+		fc.press(f, tcell.KeyHome, 0)
+		if index, _ := f.GetFocusedItemIndex(); index != 1 {
+			t.Errorf("%s: expected the focus to stay on the item, got item %d", name, index)
+		}
+	}
+}
+
+func TestTimeFieldHomeEnd(t *testing.T) {
+	field := NewTimeField().SetClock(10, 30)
+	handler := field.InputHandler()
+	handler(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone), nil)
+	handler(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), nil)
+	handler(tcell.NewEventKey(tcell.KeyHome, 0, tcell.ModNone), nil)
+	handler(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), nil)
+	if text := field.GetText(); text != "11:31" {
+		t.Errorf("expected %q, got %q", "11:31", text)
+	}
+}
@@ -0,0 +1,162 @@
+package form
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+// navigatorPage is a page on the stack of a Navigator.
+type navigatorPage struct {
+	name      string
+	primitive tview.Primitive
+
+	// Whether the page is a dialog which is shown above the page below it.
+	dialog bool
+
+	// What had focus and, if the page is a form, the form's state when the
+	// page was covered by another one.
+	focus tview.Primitive
+	state *FormState
+}
+
+// Navigator manages a stack of screens, e.g. forms and dialogs, shown in a
+// tview.Pages. Only the top page has focus. Pushing a page covers the page
+// below it, popping a page uncovers it again and restores its focus and, if
+// it is a form, its focused element and scroll position (see
+// FormScrollable.GetState). Item values are not restored so that a page can
+// change the values of the form below it. Dialogs (see PushDialog) don't hide
+// the pages below them.
+type Navigator struct {
+	*tview.Pages
+
+	// The application whose focus is managed.
+	app *tview.Application
+
+	// The pages, the top page last.
+	stack []*navigatorPage
+
+	// The number of pages pushed so far, used for unique page names.
+	pushed int
+}
+
+// NewNavigator returns a new navigator without pages which sets the focus of
+// the given application. The navigator is usually the application's root.
+func NewNavigator(app *tview.Application) *Navigator {
+	return &Navigator{
+		Pages: tview.NewPages(),
+		app:   app,
+	}
+}
+
+// Push adds the given page to the top of the stack, hiding the pages below
+// it, and focuses it.
+func (n *Navigator) Push(page tview.Primitive) *Navigator {
+	n.push(page, false)
+	return n
+}
+
+// PushDialog adds the given page, e.g. a tview.Modal, to the top of the stack
+// and focuses it. Unlike with Push, the pages below it stay visible.
+func (n *Navigator) PushDialog(page tview.Primitive) *Navigator {
+	n.push(page, true)
+	return n
+}
+
+// Pop removes the top page from the stack and returns it, nil if the stack is
+// empty. The page below it is shown and gets its focus back.
+func (n *Navigator) Pop() tview.Primitive {
+	if len(n.stack) == 0 {
+		return nil
+	}
+	top := n.stack[len(n.stack)-1]
+	n.stack = n.stack[:len(n.stack)-1]
+	n.RemovePage(top.name)
+	n.update()
+	n.restore()
+	return top.primitive
+}
+
+// Replace replaces the top page of the stack with the given page, which is
+// focused, and returns the replaced page. If the stack is empty, the page is
+// pushed and nil is returned.
+func (n *Navigator) Replace(page tview.Primitive) tview.Primitive {
+	if len(n.stack) == 0 {
+		n.Push(page)
+		return nil
+	}
+	top := n.stack[len(n.stack)-1]
+	n.stack = n.stack[:len(n.stack)-1]
+	n.RemovePage(top.name)
+	n.push(page, top.dialog)
+	return top.primitive
+}
+
+// GetDepth returns the number of pages on the stack.
+func (n *Navigator) GetDepth() int {
+	return len(n.stack)
+}
+
+// GetTop returns the top page of the stack, nil if the stack is empty.
+func (n *Navigator) GetTop() tview.Primitive {
+	if len(n.stack) == 0 {
+		return nil
+	}
+	return n.stack[len(n.stack)-1].primitive
+}
+
+// push adds the given page to the top of the stack, saving the focus and the
+// state of the page it covers.
+func (n *Navigator) push(primitive tview.Primitive, dialog bool) {
+	if len(n.stack) > 0 {
+		covered := n.stack[len(n.stack)-1]
+		covered.focus = n.app.GetFocus()
+		if form, ok := covered.primitive.(*FormScrollable); ok {
+			state := form.GetState().viewState()
+			covered.state = &state
+		}
+	}
+	n.pushed++
+	page := &navigatorPage{
+		name:      fmt.Sprintf("page-%d", n.pushed),
+		primitive: primitive,
+		dialog:    dialog,
+	}
+	n.stack = append(n.stack, page)
+	n.AddPage(page.name, primitive, true, true)
+	n.update()
+	n.app.SetFocus(primitive)
+}
+
+// restore restores the focus and the state of the top page.
+func (n *Navigator) restore() {
+	if len(n.stack) == 0 {
+		return
+	}
+	top := n.stack[len(n.stack)-1]
+	focus := top.focus
+	if form, ok := top.primitive.(*FormScrollable); ok && top.state != nil {
+		form.SetState(*top.state)
+		focus = form // It hands the focus on to the restored element.
+	}
+	if focus == nil {
+		focus = top.primitive
+	}
+	top.focus, top.state = nil, nil
+	n.app.SetFocus(focus)
+}
+
+// update shows the pages which are visible: the top page and, as long as
+// they are covered by dialogs only, the pages below it.
+func (n *Navigator) update() {
+	visible := true
+	for index := len(n.stack) - 1; index >= 0; index-- {
+		page := n.stack[index]
+		if visible {
+			n.ShowPage(page.name)
+		} else {
+			n.HidePage(page.name)
+		}
+		visible = visible && page.dialog
+	}
+}
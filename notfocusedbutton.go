@@ -60,7 +60,7 @@ func (b *NoneFocusableButton) Blur() {
 func (b *NoneFocusableButton) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
 	return func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
 		if action == tview.MouseLeftClick && b.InRect(event.Position()) {
-			if b.click != nil {
+			if b.click != nil && !b.IsDisabled() {
 				b.click()
 			}
 			consumed = true
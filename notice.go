@@ -0,0 +1,82 @@
+package form
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// defaultNoticeTimeout is the time after which item notices disappear by
+// default.
+const defaultNoticeTimeout = 3 * time.Second
+
+// NoticeLevel is the level of a notice shown below a form item, see
+// SetItemNotice.
+type NoticeLevel int
+
+// Notice levels.
+const (
+	NoticeInfo NoticeLevel = iota
+	NoticeWarning
+	NoticeError
+)
+
+// SetItemNotice shows a line of text below the form item at the given index,
+// in the color of the given level (see SetNoticeColor), e.g. "Copied to
+// clipboard". Unlike validation errors, notices are transient: they disappear
+// when the item's value changes or after the notice timeout (see
+// SetNoticeTimeout). The form is only redrawn when the timeout expires if an
+// application was set with SetApplication. An empty text removes the notice.
+func (f *FormScrollable) SetItemNotice(index int, text string, level NoticeLevel) *FormScrollable {
+	item := f.items[index]
+	state := f.state(item)
+	state.notice = text
+	state.noticeLevel = level
+	state.noticeValue = f.rawText(item)
+	state.noticeExpires = time.Time{}
+	if text == "" || f.noticeTimeout <= 0 {
+		return f
+	}
+	state.noticeExpires = time.Now().Add(f.noticeTimeout)
+	if f.app != nil {
+		time.AfterFunc(f.noticeTimeout, func() {
+			f.queueUpdateDraw(func() {})
+		})
+	}
+	return f
+}
+
+// SetNoticeTimeout sets the time after which item notices disappear. A value
+// of 0 or less keeps them until the item's value changes. The default is 3
+// seconds. It applies to notices set afterwards.
+func (f *FormScrollable) SetNoticeTimeout(timeout time.Duration) *FormScrollable {
+	f.noticeTimeout = timeout
+	return f
+}
+
+// SetNoticeColor sets the text color of item notices of the given level.
+func (f *FormScrollable) SetNoticeColor(level NoticeLevel, color tcell.Color) *FormScrollable {
+	if level >= 0 && int(level) < len(f.noticeColors) {
+		f.noticeColors[level] = color
+	}
+	return f
+}
+
+// itemNotice returns the notice of the given item as a message and whether
+// there is one. Expired notices and notices whose item's value changed are
+// removed.
+func (f *FormScrollable) itemNotice(item FormItem, state *itemState) (itemMessage, bool) {
+	if state.notice == "" {
+		return itemMessage{}, false
+	}
+	if (!state.noticeExpires.IsZero() && !time.Now().Before(state.noticeExpires)) || f.rawText(item) != state.noticeValue {
+		state.notice = ""
+		return itemMessage{}, false
+	}
+	color := f.noticeColors[NoticeInfo]
+	if state.noticeLevel >= 0 && int(state.noticeLevel) < len(f.noticeColors) {
+		color = f.noticeColors[state.noticeLevel]
+	}
+	return itemMessage{text: state.notice, color: color}, true
+}
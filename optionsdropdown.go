@@ -0,0 +1,784 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// maxListHeight is the maximum number of rows of an open OptionsDropDown
+// list. Longer lists scroll.
+const maxListHeight = 10
+
+// DropDownOption is an option of an OptionsDropDown.
+type DropDownOption struct {
+	// The text shown in the list and, if the option is selected, in the field.
+	Text string
+
+	// The underlying value of the option, returned by GetSelectedValue. If
+	// nil, the text is used.
+	Value any
+
+	// An optional description. By default, it is shown right-aligned next to
+	// the text in the list.
+	Description string
+
+	// Group headers are shown in the list but can't be selected.
+	Header bool
+}
+
+// OptionRenderFunc draws a selectable option into the given area of an open
+// OptionsDropDown list. The area was filled with the given style beforehand.
+// The style is reversed for the highlighted option.
+type OptionRenderFunc func(screen tcell.Screen, option *DropDownOption, x, y, width, height int, style tcell.Style)
+
+// OptionGroup is a named group of options, see AddDropDownGrouped.
+type OptionGroup struct {
+	Name    string
+	Options []string
+}
+
+// OptionsDropDown is a drop-down form item like tview.DropDown which supports
+// additional kinds of options, e.g. group headers which can't be selected.
+// Keyboard navigation skips headers.
+type OptionsDropDown struct {
+	*tview.Box
+
+	// The options and the index of the selected option (-1 if none).
+	options []*DropDownOption
+	current int
+
+	// Whether the list is open, the index of the highlighted option, the
+	// index of the first visible option, and the list's position as of the
+	// last draw.
+	open        bool
+	highlighted int
+	listOffset  int
+	listRect    Rect
+
+	// The text typed while the list is open, used to find options.
+	prefix string
+
+	// The store of recently selected options and this drop-down's key in it,
+	// the texts of the pinned options, and the number of options at the top
+	// which belong to the "Pinned" and "Recent" sections.
+	recentStore RecentStore
+	recentKey   string
+	pinned      []string
+	shortcuts   int
+
+	// The number of rows of each option in the list and an optional function
+	// which draws the options.
+	optionRows int
+	render     OptionRenderFunc
+
+	// The label and its width (0 means the width of the label text).
+	label      string
+	labelWidth int
+
+	// The width of the field (0 means the width of the widest option).
+	fieldWidth int
+
+	// Colors.
+	labelColor           tcell.Color
+	fieldTextColor       tcell.Color
+	fieldBackgroundColor tcell.Color
+
+	// Whether the item is disabled.
+	disabled bool
+
+	// Whether several options can be checked (instead of one being selected),
+	// the checked options, and an optional function which is called when the
+	// checked options changed.
+	multiSelect bool
+	checked     map[*DropDownOption]bool
+	changed     func(indices []int)
+
+	// Whether the mouse button was pressed on the field to open the list.
+	dragging bool
+
+	// An optional function which is called when an option was selected.
+	selected func(text string, index int)
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var _ tview.FormItem = (*OptionsDropDown)(nil)
+
+// NewOptionsDropDown returns a new drop-down without options.
+func NewOptionsDropDown() *OptionsDropDown {
+	return &OptionsDropDown{
+		Box:                  tview.NewBox(),
+		current:              -1,
+		optionRows:           1,
+		labelColor:           tview.Styles.SecondaryTextColor,
+		fieldTextColor:       tview.Styles.PrimaryTextColor,
+		fieldBackgroundColor: tview.Styles.ContrastBackgroundColor,
+	}
+}
+
+// SetLabel sets the text to be displayed before the field.
+func (d *OptionsDropDown) SetLabel(label string) *OptionsDropDown {
+	d.label = label
+	return d
+}
+
+// GetLabel returns the text to be displayed before the field.
+func (d *OptionsDropDown) GetLabel() string {
+	return d.label
+}
+
+// SetFieldWidth sets the screen width of the field. A value of 0 will cause
+// the field to be as wide as the widest option.
+func (d *OptionsDropDown) SetFieldWidth(width int) *OptionsDropDown {
+	d.fieldWidth = width
+	return d
+}
+
+// GetFieldWidth returns the screen width of the field.
+func (d *OptionsDropDown) GetFieldWidth() int {
+	if d.fieldWidth > 0 {
+		return d.fieldWidth
+	}
+	width := 1
+	for _, option := range d.options {
+		width = max(width, d.optionWidth(option))
+	}
+	return width
+}
+
+// GetFieldHeight returns the height of the field.
+func (d *OptionsDropDown) GetFieldHeight() int {
+	return 1
+}
+
+// SetOptions replaces all options. The selection is cleared.
+func (d *OptionsDropDown) SetOptions(options []*DropDownOption) *OptionsDropDown {
+	d.options = options
+	d.current, d.highlighted, d.listOffset, d.shortcuts = -1, 0, 0, 0
+	return d
+}
+
+// AddOption adds a selectable option.
+func (d *OptionsDropDown) AddOption(text string) *OptionsDropDown {
+	d.options = append(d.options, &DropDownOption{Text: text})
+	return d
+}
+
+// AddValueOption adds a selectable option which shows the given text and
+// description and whose selection is reported as the given value by
+// GetSelectedValue.
+func (d *OptionsDropDown) AddValueOption(text string, value any, description string) *OptionsDropDown {
+	d.options = append(d.options, &DropDownOption{Text: text, Value: value, Description: description})
+	return d
+}
+
+// AddHeader adds a group header which can't be selected.
+func (d *OptionsDropDown) AddHeader(text string) *OptionsDropDown {
+	d.options = append(d.options, &DropDownOption{Text: text, Header: true})
+	return d
+}
+
+// GetOptionCount returns the number of options, including headers.
+func (d *OptionsDropDown) GetOptionCount() int {
+	return len(d.options)
+}
+
+// GetOption returns the option at the given index.
+func (d *OptionsDropDown) GetOption(index int) *DropDownOption {
+	return d.options[index]
+}
+
+// SetCurrentOption selects the option at the given index and calls the
+// "selected" handler. Headers can't be selected, a negative index or a
+// header's index clears the selection.
+func (d *OptionsDropDown) SetCurrentOption(index int) *OptionsDropDown {
+	if index < 0 || index >= len(d.options) || d.options[index].Header {
+		index = -1
+	}
+	d.current = index
+	if d.selected != nil {
+		if index < 0 {
+			d.selected("", -1)
+		} else {
+			d.selected(d.options[index].Text, index)
+		}
+	}
+	return d
+}
+
+// GetCurrentOption returns the index and the text of the selected option, or
+// -1 and an empty string if no option is selected.
+func (d *OptionsDropDown) GetCurrentOption() (int, string) {
+	if d.current < 0 || d.current >= len(d.options) {
+		return -1, ""
+	}
+	return d.current, d.options[d.current].Text
+}
+
+// GetSelectedValue returns the value of the selected option (its text if it
+// has no value), or nil if no option is selected.
+func (d *OptionsDropDown) GetSelectedValue() any {
+	if d.current < 0 || d.current >= len(d.options) {
+		return nil
+	}
+	option := d.options[d.current]
+	if option.Value != nil {
+		return option.Value
+	}
+	return option.Text
+}
+
+// SetOptionRenderFunc sets a function which draws the selectable options of
+// the open list, each of which occupies the given number of rows, e.g. two
+// rows to show the description below the text. Headers always occupy the
+// first row of their area. A nil function restores the default rendering.
+func (d *OptionsDropDown) SetOptionRenderFunc(rows int, render OptionRenderFunc) *OptionsDropDown {
+	d.optionRows = max(rows, 1)
+	d.render = render
+	return d
+}
+
+// SetSelectedFunc sets a handler which is called when an option was selected.
+// It receives the option's text and index (headers count, too).
+func (d *OptionsDropDown) SetSelectedFunc(handler func(text string, index int)) *OptionsDropDown {
+	d.selected = handler
+	return d
+}
+
+// SetMultiSelect sets whether several options can be checked. In the open
+// list, Space and mouse clicks toggle the highlighted option and Enter closes
+// the list. The field shows the checked options, separated by commas.
+func (d *OptionsDropDown) SetMultiSelect(multiSelect bool) *OptionsDropDown {
+	d.multiSelect = multiSelect
+	return d
+}
+
+// SetCheckedOptions checks the options at the given indices and unchecks all
+// others. Indices refer to the options without the "Pinned" and "Recent"
+// sections (see SetRecentStore). Headers can't be checked.
+func (d *OptionsDropDown) SetCheckedOptions(indices []int) *OptionsDropDown {
+	d.checked = make(map[*DropDownOption]bool)
+	options := d.options[d.shortcuts:]
+	for _, index := range indices {
+		if index >= 0 && index < len(options) && !options[index].Header {
+			d.checked[options[index]] = true
+		}
+	}
+	return d
+}
+
+// GetCheckedOptions returns the indices of the checked options, in order.
+// Indices refer to the options without the "Pinned" and "Recent" sections.
+func (d *OptionsDropDown) GetCheckedOptions() []int {
+	var indices []int
+	for index, option := range d.options[d.shortcuts:] {
+		if d.checked[option] {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// GetCheckedTexts returns the texts of the checked options, in order.
+func (d *OptionsDropDown) GetCheckedTexts() []string {
+	var texts []string
+	for _, option := range d.options[d.shortcuts:] {
+		if d.checked[option] {
+			texts = append(texts, option.Text)
+		}
+	}
+	return texts
+}
+
+// SetCheckedChangedFunc sets a handler which is called with the indices of
+// the checked options (see GetCheckedOptions) when the user checked or
+// unchecked an option.
+func (d *OptionsDropDown) SetCheckedChangedFunc(handler func(indices []int)) *OptionsDropDown {
+	d.changed = handler
+	return d
+}
+
+// toggleHighlighted checks or unchecks the highlighted option.
+func (d *OptionsDropDown) toggleHighlighted() {
+	if d.highlighted < 0 || d.highlighted >= len(d.options) || d.options[d.highlighted].Header {
+		return
+	}
+	if d.checked == nil {
+		d.checked = make(map[*DropDownOption]bool)
+	}
+	option := d.options[d.highlighted]
+	if d.checked[option] {
+		delete(d.checked, option)
+	} else {
+		d.checked[option] = true
+	}
+	if d.changed != nil {
+		d.changed(d.GetCheckedOptions())
+	}
+}
+
+// IsOpen returns whether the list of options is open.
+func (d *OptionsDropDown) IsOpen() bool {
+	return d.open
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (d *OptionsDropDown) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	d.labelWidth = labelWidth
+	d.labelColor = labelColor
+	d.SetBackgroundColor(bgColor)
+	d.fieldTextColor = fieldTextColor
+	d.fieldBackgroundColor = fieldBgColor
+	return d
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (d *OptionsDropDown) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	d.finished = handler
+	return d
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (d *OptionsDropDown) SetDisabled(disabled bool) tview.FormItem {
+	d.disabled = disabled
+	if disabled {
+		d.open = false
+	}
+	if d.finished != nil {
+		d.finished(-1)
+	}
+	return d
+}
+
+// hasHeaders returns whether any option is a header.
+func (d *OptionsDropDown) hasHeaders() bool {
+	for _, option := range d.options {
+		if option.Header {
+			return true
+		}
+	}
+	return false
+}
+
+// optionWidth returns the width of the given option in the list.
+func (d *OptionsDropDown) optionWidth(option *DropDownOption) int {
+	width := tview.TaggedStringWidth(option.Text)
+	if option.Description != "" && d.render == nil {
+		width += 2 + tview.TaggedStringWidth(option.Description)
+	}
+	if !option.Header && d.hasHeaders() {
+		width += 2 // Options of groups are indented.
+	}
+	if !option.Header && d.multiSelect {
+		width += 2 // Check box.
+	}
+	return width
+}
+
+// openList opens the list of options with the selected option highlighted.
+func (d *OptionsDropDown) openList() {
+	d.updateShortcuts()
+	d.open, d.prefix = true, ""
+	d.highlighted = d.current
+	if d.highlighted < 0 {
+		d.highlighted = -1
+		d.moveHighlight(1)
+	}
+}
+
+// closeList closes the list of options.
+func (d *OptionsDropDown) closeList() {
+	d.open, d.prefix = false, ""
+}
+
+// moveHighlight highlights the next selectable option in the given direction,
+// moving by the given number of options. Headers are skipped. If there is no
+// selectable option in that direction, the highlight doesn't change.
+func (d *OptionsDropDown) moveHighlight(delta int) {
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+	target := -1
+	for index := d.highlighted + step; index >= 0 && index < len(d.options); index += step {
+		if d.options[index].Header {
+			continue
+		}
+		target = index
+		if delta--; delta == 0 {
+			break
+		}
+	}
+	if target >= 0 {
+		d.highlighted = target
+	}
+}
+
+// selectHighlighted selects the highlighted option and closes the list. In
+// multi-select mode, it only closes the list.
+func (d *OptionsDropDown) selectHighlighted() {
+	d.closeList()
+	if d.multiSelect {
+		return
+	}
+	if d.highlighted >= 0 && d.highlighted < len(d.options) {
+		d.SetCurrentOption(d.highlighted)
+		if d.recentStore != nil && d.current >= 0 {
+			d.recentStore.Add(d.recentKey, d.options[d.current].Text)
+		}
+	}
+}
+
+// findPrefix highlights the first selectable option starting with the typed
+// prefix.
+func (d *OptionsDropDown) findPrefix() {
+	for index, option := range d.options {
+		if !option.Header && strings.HasPrefix(strings.ToLower(option.Text), d.prefix) {
+			d.highlighted = index
+			return
+		}
+	}
+
+	// No match. Remove the last rune.
+	r := []rune(d.prefix)
+	d.prefix = string(r[:len(r)-1])
+}
+
+// Draw draws this primitive onto the screen.
+func (d *OptionsDropDown) Draw(screen tcell.Screen) {
+	d.Box.DrawForSubclass(screen, d)
+
+	x, y, width, height := d.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if d.labelWidth > 0 {
+		labelWidth := min(d.labelWidth, rightLimit-x)
+		tview.Print(screen, d.label, x, y, labelWidth, tview.AlignLeft, d.labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := tview.Print(screen, d.label, x, y, rightLimit-x, tview.AlignLeft, d.labelColor)
+		x += drawnWidth
+	}
+
+	// Draw field.
+	fieldWidth := min(d.GetFieldWidth(), rightLimit-x)
+	fieldStyle := tcell.StyleDefault.Background(d.fieldBackgroundColor).Foreground(d.fieldTextColor)
+	if d.HasFocus() && !d.open {
+		fieldStyle = fieldStyle.Background(d.fieldTextColor).Foreground(d.fieldBackgroundColor)
+	}
+	if d.disabled {
+		fieldStyle = fieldStyle.Background(d.GetBackgroundColor())
+	}
+	for index := 0; index < fieldWidth; index++ {
+		screen.SetContent(x+index, y, ' ', nil, fieldStyle)
+	}
+	text := ""
+	if d.open && d.prefix != "" {
+		text = d.prefix
+	} else if d.multiSelect {
+		text = strings.Join(d.GetCheckedTexts(), ", ")
+	} else if _, current := d.GetCurrentOption(); current != "" {
+		text = current
+	}
+	color, _, _ := fieldStyle.Decompose()
+	tview.Print(screen, text, x, y, fieldWidth, tview.AlignLeft, color)
+
+	if d.open && d.HasFocus() {
+		d.drawList(screen, x, y, fieldWidth)
+	}
+}
+
+// drawList draws the open list of options below (or, if there is not enough
+// space, above) the field at the given position.
+func (d *OptionsDropDown) drawList(screen tcell.Screen, fieldX, fieldY, fieldWidth int) {
+	screenWidth, screenHeight := screen.Size()
+	width := fieldWidth
+	for _, option := range d.options {
+		width = max(width, d.optionWidth(option))
+	}
+	rows := d.optionRows
+	height := max(min(len(d.options), maxListHeight/rows), 1) * rows
+	x, y := fieldX, fieldY+1
+	if x+width > screenWidth {
+		x = max(screenWidth-width, 0)
+	}
+	if y+height > screenHeight && fieldY-height >= 0 {
+		y = fieldY - height
+	}
+	height = min(height, screenHeight-y)
+	d.listRect = Rect{X: x, Y: y, Width: width, Height: height}
+
+	// Keep the highlighted option visible.
+	visible := max(height/rows, 1)
+	if d.highlighted >= 0 {
+		if d.highlighted < d.listOffset {
+			d.listOffset = d.highlighted
+		}
+		if d.highlighted >= d.listOffset+visible {
+			d.listOffset = d.highlighted - visible + 1
+		}
+	}
+	d.listOffset = clamp(d.listOffset, 0, max(len(d.options)-visible, 0))
+
+	indent := 0
+	if d.hasHeaders() {
+		indent = 2
+	}
+	for entry := 0; entry < visible && d.listOffset+entry < len(d.options); entry++ {
+		index := d.listOffset + entry
+		option := d.options[index]
+		top := y + entry*rows
+		entryHeight := min(rows, y+height-top)
+		style := tcell.StyleDefault.Background(d.fieldBackgroundColor).Foreground(d.fieldTextColor)
+		if option.Header {
+			style = style.Foreground(d.labelColor).Bold(true)
+		} else if index == d.highlighted {
+			style = style.Reverse(true)
+		}
+		for row := top; row < top+entryHeight; row++ {
+			for column := x; column < x+width; column++ {
+				screen.SetContent(column, row, ' ', nil, style)
+			}
+		}
+		optionX, optionWidth := x+indent, width-indent
+		if d.multiSelect && !option.Header {
+			box := '\u2610'
+			if d.checked[option] {
+				box = '\u2611'
+			}
+			screen.SetContent(optionX, top, box, nil, style)
+			optionX, optionWidth = optionX+2, optionWidth-2
+		}
+		switch {
+		case option.Header:
+			printStyled(screen, option.Text, x, top, width, tview.AlignLeft, style)
+		case d.render != nil:
+			d.render(screen, option, optionX, top, optionWidth, entryHeight, style)
+		default:
+			if option.Description != "" {
+				printStyled(screen, option.Description, optionX, top, optionWidth, tview.AlignRight, style.Dim(true))
+			}
+			printStyled(screen, option.Text, optionX, top, optionWidth, tview.AlignLeft, style)
+		}
+	}
+}
+
+// printStyled prints text like tview.Print but applies the whole style (not
+// only its foreground color) to the printed cells. Centered text is not
+// supported.
+func printStyled(screen tcell.Screen, text string, x, y, width, align int, style tcell.Style) {
+	color, _, _ := style.Decompose()
+	_, printed := tview.Print(screen, text, x, y, width, align, color)
+	if align == tview.AlignRight {
+		x += width - printed
+	}
+	for column := x; column < x+printed; column++ {
+		mainc, combc, _, _ := screen.GetContent(column, y)
+		screen.SetContent(column, y, mainc, combc, style)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (d *OptionsDropDown) Focus(delegate func(p tview.Primitive)) {
+	if d.disabled && d.finished != nil {
+		d.finished(-1)
+		return
+	}
+	d.Box.Focus(delegate)
+}
+
+// Blur is called when this primitive loses focus.
+func (d *OptionsDropDown) Blur() {
+	d.closeList()
+	d.Box.Blur()
+}
+
+// UsesKey returns whether the drop-down uses the given key itself, which it
+// does while it is open.
+func (d *OptionsDropDown) UsesKey(key tcell.Key) bool {
+	return d.open
+}
+
+// InputHandler returns the handler for this primitive.
+func (d *OptionsDropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if d.disabled {
+			return
+		}
+		key := event.Key()
+
+		// Process keys of the closed drop-down.
+		if !d.open {
+			switch key {
+			case tcell.KeyEnter, tcell.KeyDown:
+				d.openList()
+			case tcell.KeyRune:
+				d.openList()
+				if r := event.Rune(); r != ' ' {
+					d.prefix = strings.ToLower(string(r))
+					d.findPrefix()
+				}
+			case tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab:
+				if d.finished != nil {
+					d.finished(key)
+				}
+			}
+			return
+		}
+
+		// Process keys of the open list.
+		switch key {
+		case tcell.KeyUp:
+			d.moveHighlight(-1)
+			d.prefix = ""
+		case tcell.KeyDown:
+			d.moveHighlight(1)
+			d.prefix = ""
+		case tcell.KeyPgUp:
+			d.moveHighlight(-maxListHeight)
+			d.prefix = ""
+		case tcell.KeyPgDn:
+			d.moveHighlight(maxListHeight)
+			d.prefix = ""
+		case tcell.KeyHome:
+			d.highlighted = -1
+			d.moveHighlight(1)
+		case tcell.KeyEnd:
+			d.highlighted = len(d.options)
+			d.moveHighlight(-1)
+		case tcell.KeyEnter, tcell.KeyTab:
+			d.selectHighlighted()
+		case tcell.KeyEscape:
+			d.closeList()
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if r := []rune(d.prefix); len(r) > 0 {
+				d.prefix = string(r[:len(r)-1])
+				d.findPrefix()
+			}
+		case tcell.KeyRune:
+			if d.multiSelect && event.Rune() == ' ' {
+				d.toggleHighlighted()
+				break
+			}
+			d.prefix += strings.ToLower(string(event.Rune()))
+			d.findPrefix()
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (d *OptionsDropDown) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return d.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if d.disabled {
+			return false, nil
+		}
+		x, y := event.Position()
+		rectX, rectY, rectWidth, _ := d.GetInnerRect()
+		inField := y == rectY && x >= rectX && x < rectX+rectWidth
+		if !d.open && !inField {
+			return false, nil
+		}
+
+		// The open list captures all mouse events.
+		if d.open {
+			capture = d
+		}
+		inList := d.open && d.listRect.Contains(x, y)
+		switch action {
+		case tview.MouseLeftDown:
+			consumed, capture = true, d
+			if !d.open {
+				setFocus(d)
+				d.openList()
+				d.dragging = true
+			} else if !inList {
+				d.closeList()
+				capture = nil
+			}
+		case tview.MouseMove:
+			if d.dragging && inList {
+				d.highlightAt(y)
+				consumed = true
+			}
+		case tview.MouseLeftUp:
+			if d.dragging && inList && !d.multiSelect {
+				d.highlightAt(y)
+				d.selectHighlighted()
+				capture = nil
+			}
+			d.dragging = false
+			consumed = true
+		case tview.MouseLeftClick:
+			if inList && d.highlightAt(y) {
+				if d.multiSelect {
+					d.toggleHighlighted()
+				} else {
+					d.selectHighlighted()
+					capture = nil
+				}
+			}
+			consumed = true
+		case tview.MouseScrollUp:
+			if inList {
+				d.listOffset = max(d.listOffset-1, 0)
+				consumed = true
+			}
+		case tview.MouseScrollDown:
+			if inList {
+				d.listOffset = min(d.listOffset+1, max(len(d.options)-d.listRect.Height/d.optionRows, 0))
+				consumed = true
+			}
+		}
+		return
+	})
+}
+
+// highlightAt highlights the option in the given screen row of the open list,
+// unless it's a header. Returns whether an option was highlighted.
+func (d *OptionsDropDown) highlightAt(y int) bool {
+	index := d.listOffset + (y-d.listRect.Y)/d.optionRows
+	if index < 0 || index >= len(d.options) || d.options[index].Header {
+		return false
+	}
+	d.highlighted = index
+	return true
+}
+
+// AddDropDownGrouped adds a drop-down to the form whose options are organized
+// in groups (see OptionsDropDown). Group names are shown as headers which
+// can't be selected. The "selected" function is called with the selected
+// option's text and its index among all options and headers. It may be nil.
+func (f *FormScrollable) AddDropDownGrouped(label string, groups []OptionGroup, selected func(option string, optionIndex int)) *FormScrollable {
+	dropDown := NewOptionsDropDown().SetLabel(label)
+	for _, group := range groups {
+		dropDown.AddHeader(group.Name)
+		for _, option := range group.Options {
+			dropDown.AddOption(option)
+		}
+	}
+	dropDown.SetSelectedFunc(selected)
+	f.addItem(dropDown)
+	return f
+}
+
+// AddMultiSelectDropDown adds a drop-down to the form in which several
+// options can be checked (see OptionsDropDown.SetMultiSelect). The options at
+// the "selected" indices are checked initially. The optional "changed"
+// function is called with the indices of the checked options whenever the
+// user checks or unchecks one.
+func (f *FormScrollable) AddMultiSelectDropDown(label string, options []string, selected []int, changed func(indices []int)) *FormScrollable {
+	dropDown := NewOptionsDropDown().SetLabel(label).SetMultiSelect(true)
+	for _, option := range options {
+		dropDown.AddOption(option)
+	}
+	dropDown.SetCheckedOptions(selected).SetCheckedChangedFunc(changed)
+	f.addItem(dropDown)
+	return f
+}
@@ -0,0 +1,189 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// AddSection adds a section heading with the given title to the form. It is a
+// text view which is not scrollable and can't receive focus. The sections of
+// a form are listed by a SectionOutline.
+func (f *FormScrollable) AddSection(title string) *FormScrollable {
+	heading := tview.NewTextView().
+		SetSize(1, 0).
+		SetTextStyle(tcell.StyleDefault.Bold(true)).
+		SetText(title)
+	f.addItem(heading)
+	state := f.state(heading)
+	state.fixed = true
+	state.section = true
+	return f
+}
+
+// GetSections returns the indices of the form items which are section
+// headings (see AddSection), including hidden ones.
+func (f *FormScrollable) GetSections() []int {
+	var sections []int
+	for index, item := range f.items {
+		if state, ok := f.itemStates[item]; ok && state.section {
+			sections = append(sections, index)
+		}
+	}
+	return sections
+}
+
+// SectionOutline lists the visible sections of a form (see
+// FormScrollable.AddSection), like the outline of a document in an IDE. The
+// section at the top of the form's visible area is highlighted as the form
+// scrolls. Selecting a section scrolls the form to it and moves the form's
+// focus to the first item after it which can receive focus.
+//
+// The outline calls the form's scrolled function (see
+// FormScrollable.SetScrolledFunc) which was set when the outline was created,
+// so a form's own function must be set before.
+type SectionOutline struct {
+	*tview.List
+
+	// The form whose sections are listed.
+	form *FormScrollable
+
+	// The item indices of the listed sections.
+	sections []int
+
+	// An optional function which is called after the outline jumped to a
+	// section.
+	jumped func(index int)
+}
+
+// NewSectionOutline returns a new outline of the sections of the given form.
+func NewSectionOutline(form *FormScrollable) *SectionOutline {
+	o := &SectionOutline{
+		List: tview.NewList().ShowSecondaryText(false),
+		form: form,
+	}
+	o.SetHighlightFullLine(true)
+	o.List.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		o.Jump(index)
+	})
+	scrolled := form.scrolled
+	form.SetScrolledFunc(func(offset int) {
+		o.highlight(offset)
+		if scrolled != nil {
+			scrolled(offset)
+		}
+	})
+	o.update()
+	return o
+}
+
+// SetJumpedFunc sets a handler which is called with the index of the section
+// (in the outline) after the outline jumped to it, e.g. to move the
+// application's focus to the form.
+func (o *SectionOutline) SetJumpedFunc(handler func(index int)) *SectionOutline {
+	o.jumped = handler
+	return o
+}
+
+// GetSectionItemIndex returns the index of the form item which is the heading
+// of the section with the given index in the outline, -1 if there is no such
+// section.
+func (o *SectionOutline) GetSectionItemIndex(index int) int {
+	if index < 0 || index >= len(o.sections) {
+		return -1
+	}
+	return o.sections[index]
+}
+
+// Jump scrolls the form to the section with the given index in the outline
+// and moves the form's focus to the first item after the section's heading
+// which can receive focus.
+func (o *SectionOutline) Jump(index int) *SectionOutline {
+	item := o.GetSectionItemIndex(index)
+	if item < 0 {
+		return o
+	}
+	f := o.form
+	for next := item + 1; next < len(f.items); next++ {
+		if state, ok := f.itemStates[f.items[next]]; ok && state.section {
+			break
+		}
+		if f.IsItemVisible(next) && !f.IsItemDisabled(next) && !f.isSkippedTextView(next) {
+			f.SetFocus(next)
+			break
+		}
+	}
+	if row := f.GetItemRow(item); row >= 0 {
+		f.ScrollTo(row)
+	}
+	o.SetCurrentItem(index)
+	if o.jumped != nil {
+		o.jumped(index)
+	}
+	return o
+}
+
+// update lists the form's visible sections if they changed.
+func (o *SectionOutline) update() {
+	var sections []int
+	for _, index := range o.form.GetSections() {
+		if o.form.IsItemVisible(index) {
+			sections = append(sections, index)
+		}
+	}
+	changed := len(sections) != len(o.sections)
+	for index := 0; !changed && index < len(sections); index++ {
+		changed = sections[index] != o.sections[index] ||
+			o.form.items[sections[index]].(*tview.TextView).GetText(false) != o.title(index)
+	}
+	if !changed {
+		return
+	}
+	current := o.GetCurrentItem()
+	o.sections = sections
+	o.Clear()
+	for _, index := range sections {
+		o.AddItem(o.form.items[index].(*tview.TextView).GetText(false), "", 0, nil)
+	}
+	o.SetCurrentItem(current)
+	o.highlight(o.form.GetScrollOffset())
+}
+
+// title returns the listed title of the section with the given index.
+func (o *SectionOutline) title(index int) string {
+	main, _ := o.GetItemText(index)
+	return main
+}
+
+// highlight highlights the section at the top of the form's visible area,
+// i.e. the last one which starts at or above the given scroll offset. When
+// the form is scrolled to its end, a highlighted section further down, e.g.
+// one the outline jumped to, stays highlighted.
+func (o *SectionOutline) highlight(offset int) {
+	if selected := o.GetCurrentItem(); offset > 0 && offset >= o.form.maxScrollOffset && selected < len(o.sections) {
+		if row := o.form.GetItemRow(o.sections[selected]); row >= offset {
+			return
+		}
+	}
+	current := -1
+	for index, item := range o.sections {
+		row := o.form.GetItemRow(item)
+		if row < 0 {
+			continue
+		}
+		if current < 0 || row <= offset {
+			current = index
+		}
+		if row > offset {
+			break
+		}
+	}
+	if current >= 0 && current != o.GetCurrentItem() {
+		o.SetCurrentItem(current)
+	}
+}
+
+// Draw draws the outline.
+func (o *SectionOutline) Draw(screen tcell.Screen) {
+	o.update()
+	o.List.Draw(screen)
+}
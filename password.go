@@ -0,0 +1,52 @@
+package form
+
+import (
+	"errors"
+
+	. "github.com/rivo/tview"
+)
+
+// ErrPasswordMismatch is the validation error of the fields added with
+// AddPasswordConfirmPair while their texts differ.
+var ErrPasswordMismatch = errors.New("the passwords don't match")
+
+// AddPasswordConfirmPair adds two password fields (see AddPasswordField), one
+// for a new password and one to confirm it. As long as their texts differ,
+// both fields are invalid (with ErrPasswordMismatch), which blocks submitting
+// the form (see SetSubmitFunc). Once the user typed into the confirmation
+// field, the fields are validated whenever one of them changes, so that the
+// mismatch is shown while typing. Setting another validator for one of the
+// fields removes this check.
+//
+// The optional "changed" function is called with the text of the first field
+// whenever it changes.
+func (f *FormScrollable) AddPasswordConfirmPair(label, confirmLabel string, fieldWidth int, mask rune, changed func(password string)) *FormScrollable {
+	var password, confirm *InputField
+	update := func() {
+		if confirm.GetText() != "" || f.itemStates[password].err != nil || f.itemStates[confirm].err != nil {
+			f.validateItem(password)
+			f.validateItem(confirm)
+		}
+	}
+	f.AddPasswordField(label, "", fieldWidth, mask, func(text string) {
+		update()
+		if changed != nil {
+			changed(text)
+		}
+	})
+	password = f.items[len(f.items)-1].(*InputField)
+	f.AddPasswordField(confirmLabel, "", fieldWidth, mask, func(text string) {
+		update()
+	})
+	confirm = f.items[len(f.items)-1].(*InputField)
+
+	validator := func(string) error {
+		if password.GetText() != confirm.GetText() {
+			return ErrPasswordMismatch
+		}
+		return nil
+	}
+	f.SetValidator(len(f.items)-2, validator)
+	f.SetValidator(len(f.items)-1, validator)
+	return f
+}
@@ -0,0 +1,54 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// inputPlaceholder is implemented by input fields and the items based on them
+// (e.g. ComboBox and AutocompleteField).
+type inputPlaceholder interface {
+	SetPlaceholder(text string) *InputField
+	SetPlaceholderStyle(style tcell.Style) *InputField
+}
+
+// SetItemPlaceholder sets the text which is shown in the field of the form
+// item at the given index while it is empty, if the item is an input field
+// (including items based on input fields, e.g. ComboBox) or a text area.
+// Other items are not changed.
+func (f *FormScrollable) SetItemPlaceholder(index int, text string) *FormScrollable {
+	switch item := unwrapItem(f.items[index]).(type) {
+	case inputPlaceholder:
+		item.SetPlaceholder(text)
+	case *TextArea:
+		item.SetPlaceholder(text)
+	}
+	return f
+}
+
+// SetPlaceholderStyle sets the style of the placeholders of all input fields
+// and text areas of the form (see SetItemPlaceholder). If the style's
+// background color is tcell.ColorDefault, the items' field background color is
+// used. By default, the items keep their own placeholder styles.
+func (f *FormScrollable) SetPlaceholderStyle(style tcell.Style) *FormScrollable {
+	f.placeholderStyle = &style
+	return f
+}
+
+// applyPlaceholderStyle applies the form's placeholder style, if any, to the
+// given item, whose field has the given background color.
+func (f *FormScrollable) applyPlaceholderStyle(item FormItem, fieldBackground tcell.Color) {
+	if f.placeholderStyle == nil {
+		return
+	}
+	style := *f.placeholderStyle
+	if _, background, _ := style.Decompose(); background == tcell.ColorDefault {
+		style = style.Background(fieldBackground)
+	}
+	switch item := unwrapItem(item).(type) {
+	case inputPlaceholder:
+		item.SetPlaceholderStyle(style)
+	case *TextArea:
+		item.SetPlaceholderStyle(style)
+	}
+}
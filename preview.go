@@ -0,0 +1,141 @@
+package form
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	. "github.com/rivo/tview"
+)
+
+// SubmitWithPreview validates the form (see Validate) and lets the user review
+// the changes before they are applied, similar to "kubectl diff" before
+// "kubectl apply". The values the items had when their defaults were captured
+// (see CaptureDefaults) and their current values, both keyed by label (see
+// GetFormValues), are serialized with the given function (indented JSON if it
+// is nil) and passed to the preview function, which shows them, e.g. side by
+// side or as a diff, and asks for confirmation.
+//
+// If the user confirms, the preview function calls "confirm", which writes the
+// values back to the bound struct fields, flags, and configuration keys (see
+// Submit), calls the optional "apply" function, and captures the applied
+// values as the new defaults. It returns the first error that occurred, e.g.
+// for the preview to show it. If the user cancels, nothing is changed.
+//
+// If the preview function is nil, the form opens a confirmation dialog which
+// lists the items whose values changed with their values before and after,
+// formatted as JSON. Its "Apply" button confirms the changes. If that fails,
+// the dialog shows the error and offers to try again.
+//
+// Validation and serialization errors are returned and the preview function is
+// not called.
+func (f *FormScrollable) SubmitWithPreview(serialize func(values map[string]any) (string, error), preview func(before, after string, confirm func() error), apply func() error) error {
+	if errs := f.Validate(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if serialize == nil {
+		serialize = serializeJSON
+	}
+	f.captureMissingDefaults()
+	defaults, values := f.defaultValues(), f.GetFormValues()
+	before, err := serialize(defaults)
+	if err != nil {
+		return err
+	}
+	after, err := serialize(values)
+	if err != nil {
+		return err
+	}
+	if preview == nil {
+		preview = f.confirmChanges(defaults, values)
+	}
+	preview(before, after, func() error {
+		if err := f.Submit(); err != nil {
+			return err
+		}
+		if apply != nil {
+			if err := apply(); err != nil {
+				return err
+			}
+		}
+		f.CaptureDefaults()
+		return nil
+	})
+	return nil
+}
+
+// confirmChanges returns a preview function for SubmitWithPreview which asks
+// for confirmation in a dialog listing the changes from the given values
+// before to the given values after.
+func (f *FormScrollable) confirmChanges(before, after map[string]any) func(before, after string, confirm func() error) {
+	return func(_, _ string, confirm func() error) {
+		var apply func()
+		apply = func() {
+			if err := confirm(); err != nil {
+				f.showConfirm("Applying the changes failed:\n"+Escape(err.Error()), "Retry", "Cancel", apply)
+			}
+		}
+		f.showConfirm(describeChanges(before, after), "Apply", "Cancel", apply)
+	}
+}
+
+// describeChanges returns the text of the dialog which asks for confirmation
+// of the changes from the given values before to the given values after,
+// with one line per changed value, ordered by label.
+func describeChanges(before, after map[string]any) string {
+	labels := make([]string, 0, len(after))
+	for label, value := range after {
+		if previous, ok := before[label]; !ok || !reflect.DeepEqual(previous, value) {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 {
+		return "Apply the form without changes?"
+	}
+	sort.Strings(labels)
+	var b strings.Builder
+	b.WriteString("Apply these changes?\n")
+	for _, label := range labels {
+		fmt.Fprintf(&b, "\n%s: %s \u2192 %s", Escape(label), formatChange(before[label]), formatChange(after[label]))
+	}
+	return b.String()
+}
+
+// formatChange returns the given value formatted as compact JSON for the
+// dialog of describeChanges.
+func formatChange(value any) string {
+	if value == nil {
+		return "(none)"
+	}
+	document, err := json.Marshal(value)
+	if err != nil {
+		return Escape(fmt.Sprint(value))
+	}
+	return Escape(string(document))
+}
+
+// defaultValues returns the captured default values of all form items which
+// have a value, keyed by their labels, like GetFormValues.
+func (f *FormScrollable) defaultValues() map[string]any {
+	values := make(map[string]any, len(f.items))
+	for _, item := range f.items {
+		if state, ok := f.itemStates[item]; ok && state.hasDefault {
+			if _, ok := getItemValue(item); ok {
+				values[item.GetLabel()] = snapshotValue(state.defaultValue)
+			}
+		}
+	}
+	return values
+}
+
+// serializeJSON serializes the given values as indented JSON.
+func serializeJSON(values map[string]any) (string, error) {
+	document, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(document), nil
+}
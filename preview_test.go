@@ -0,0 +1,74 @@
+package form
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+func TestSubmitWithPreviewDialog(t *testing.T) {
+	f := NewFormScrollable().
+		AddInputField("Name", "Jane", 20, nil, nil).
+		AddCheckbox("Agree", false, nil).
+		CaptureDefaults()
+	setItemText(f.GetFormItem(0), "John")
+	fc := &focuser{}
+	fc.setFocus(f)
+
+	failures := 1
+	var applied int
+	apply := func() error {
+		if failures > 0 {
+			failures--
+			return errors.New("disk full")
+		}
+		applied++
+		return nil
+	}
+	if err := f.SubmitWithPreview(nil, nil, apply); err != nil {
+		t.Fatal(err)
+	}
+	modal, ok := f.popup.(*Modal)
+	if !ok {
+		t.Fatalf("expected a confirmation dialog, got %T", f.popup)
+	}
+	screen := drawForm(t, f, 60, 12)
+	if text := screenText(screen); !strings.Contains(text, `Name: "Jane" → "John"`) || strings.Contains(text, "Agree:") {
+		t.Fatalf("expected the dialog to list the changed name only:\n%s", text)
+	}
+
+	// The first attempt fails and the dialog offers to try again.
+	fc.press(f, tcell.KeyEnter, 0)
+	if f.popup == nil || f.popup == Primitive(modal) {
+		t.Fatal("expected a dialog showing the error")
+	}
+	if text := screenText(drawForm(t, f, 60, 12)); !strings.Contains(text, "disk full") {
+		t.Fatalf("expected the error to be shown:\n%s", text)
+	}
+	fc.press(f, tcell.KeyEnter, 0)
+	if f.popup != nil || applied != 1 {
+		t.Fatalf("expected the changes to be applied once, got %d", applied)
+	}
+	if f.IsItemDirty(0) {
+		t.Error("expected the applied name to be the new default")
+	}
+}
+
+// screenText returns the contents of the given screen, one line per row.
+func screenText(screen tcell.SimulationScreen) string {
+	screen.Show()
+	cells, width, _ := screen.GetContents()
+	var b strings.Builder
+	for index, cell := range cells {
+		if len(cell.Runes) > 0 {
+			b.WriteRune(cell.Runes[0])
+		}
+		if (index+1)%width == 0 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// ProcessKey passes the given key event to the form as if the form was the
+// root of an application, without running one. The focus is moved the way
+// the application would move it. The form receives focus with the first
+// event processed. Together with ProcessMouse, ProcessPaste, and Draw (e.g.
+// with a tcell.SimulationScreen), this lets tests and fuzzers drive the form
+// deterministically. The form should be given a size with SetRect or Draw
+// first. Events must not be processed this way while the form is part of a
+// running application.
+func (f *FormScrollable) ProcessKey(event *tcell.EventKey) {
+	f.processStart()
+	if handler := f.InputHandler(); handler != nil {
+		handler(event, f.processSetFocus)
+	}
+}
+
+// ProcessMouse passes the given mouse event to the form with the given
+// action, which the application would derive from the events' button states
+// and timing, and returns whether the form consumed it. A primitive which
+// captured the mouse receives the following mouse events until it releases
+// it, as with an application. See ProcessKey for details.
+func (f *FormScrollable) ProcessMouse(action MouseAction, event *tcell.EventMouse) bool {
+	f.processStart()
+	target := f.processCapture
+	if target == nil {
+		target = f
+	}
+	handler := target.MouseHandler()
+	if handler == nil {
+		f.processCapture = nil
+		return false
+	}
+	consumed, capture := handler(action, event, f.processSetFocus)
+	f.processCapture = capture
+	return consumed
+}
+
+// ProcessPaste passes the given pasted text to the form. See ProcessKey for
+// details.
+func (f *FormScrollable) ProcessPaste(text string) {
+	f.processStart()
+	if handler := f.PasteHandler(); handler != nil {
+		handler(text, f.processSetFocus)
+	}
+}
+
+// processStart gives the form focus if no events were processed yet.
+func (f *FormScrollable) processStart() {
+	if f.processFocus == nil {
+		f.processSetFocus(f)
+	}
+}
+
+// processSetFocus moves the focus to the given primitive as
+// tview.Application.SetFocus does.
+func (f *FormScrollable) processSetFocus(p Primitive) {
+	if f.processFocus != nil {
+		f.processFocus.Blur()
+	}
+	f.processFocus = p
+	if p != nil {
+		p.Focus(f.processSetFocus)
+	}
+}
@@ -0,0 +1,83 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// fuzzKeys are the special keys fuzzed events are chosen from.
+var fuzzKeys = []tcell.Key{
+	tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEnter, tcell.KeyEscape,
+	tcell.KeyUp, tcell.KeyDown, tcell.KeyLeft, tcell.KeyRight,
+	tcell.KeyHome, tcell.KeyEnd, tcell.KeyPgUp, tcell.KeyPgDn,
+	tcell.KeyBackspace2, tcell.KeyDelete, tcell.KeyCtrlU, tcell.KeyCtrlW,
+	tcell.KeyCtrlZ, tcell.KeyCtrlY, tcell.KeyCtrlD, tcell.KeyCtrlK,
+}
+
+// fuzzForm returns a form with one item of most types, drawn onto a screen
+// which is smaller than the form.
+func fuzzForm(t *testing.T) (*FormScrollable, tcell.SimulationScreen) {
+	f := NewFormScrollable().
+		AddInputField("Name", "Jane", 20, nil, nil).
+		AddPasswordField("Password", "", 20, '*', nil).
+		AddMaskedInputField("Phone", "###-####", "", nil).
+		AddIPField("Address", "10.0.0.1", true, nil).
+		AddTimeField("Time", 10, 30, nil).
+		AddComboBox("Color", []string{"red", "green", "blue"}, "", nil).
+		AddTagsField("Tags", []string{"a"}, nil).
+		AddKeyValueEditor("Labels", map[string]string{"k": "v"}, nil).
+		AddDropDown("Size", []string{"S", "M", "L"}, 0, nil).
+		AddCheckbox("Agree", false, nil).
+		AddTextArea("Notes", "", 20, 3, 0, nil).
+		AddButton("OK", nil).
+		AddButton("Cancel", nil)
+	return f, drawForm(t, f, 40, 8)
+}
+
+func FuzzProcessKey(f *testing.F) {
+	f.Add([]byte("\x00a\x05b\x04c"))
+	f.Add([]byte("\x00\x00\x00\x001\x0e\x00\x0f\x00"))
+	f.Add([]byte("\x02\x00\x03\x00\x04\x00"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		form, screen := fuzzForm(t)
+		for len(data) >= 2 {
+			selector, r := data[0], rune(data[1])
+			data = data[2:]
+			if int(selector) < len(fuzzKeys) {
+				form.ProcessKey(tcell.NewEventKey(fuzzKeys[selector], 0, tcell.ModNone))
+			} else {
+				form.ProcessKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+			}
+			form.Draw(screen)
+		}
+	})
+}
+
+func FuzzProcessPaste(f *testing.F) {
+	f.Add(uint8(2), "555-123456789")
+	f.Add(uint8(3), "192.168.0.1/24")
+	f.Fuzz(func(t *testing.T, tabs uint8, text string) {
+		form, screen := fuzzForm(t)
+		for i := 0; i < int(tabs%16); i++ {
+			form.ProcessKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+		}
+		form.ProcessPaste(text)
+		form.Draw(screen)
+		form.ProcessKey(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone))
+		form.Draw(screen)
+	})
+}
+
+func TestProcessKeyMovesFocus(t *testing.T) {
+	f := NewFormScrollable().
+		AddInputField("Name", "", 10, nil, nil).
+		AddInputField("Mail", "", 10, nil, nil)
+	drawForm(t, f, 40, 5)
+	f.ProcessKey(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone))
+	f.ProcessKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+	f.ProcessKey(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone))
+	if got := getItemText(f.GetFormItem(0)) + getItemText(f.GetFormItem(1)); got != "xy" {
+		t.Errorf("expected %q, got %q", "xy", got)
+	}
+}
@@ -0,0 +1,310 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// Builder collects the definitions of form items for Rebuild. Its methods
+// take the same arguments as the form's methods of the same name.
+type Builder struct {
+	items []itemDefinition
+}
+
+// itemDefinition defines a form item for Rebuild: its label, the values which
+// determine the item's value (compared with those of the previous definition
+// to find out whether the app changed them), a function which creates the
+// item, and a function which updates an existing item in place. The latter
+// returns false if the item isn't of the defined type. The item's value is
+// only set if setValue is true. The optional setState function adjusts the
+// state the form keeps for the item.
+type itemDefinition struct {
+	label    string
+	value    any
+	create   func() FormItem
+	update   func(item FormItem, setValue bool) bool
+	setState func(state *itemState)
+}
+
+// dropDownDefinition is the value of a drop-down's definition.
+type dropDownDefinition struct {
+	options []string
+	initial int
+}
+
+// AddInputField defines an input field (see FormScrollable.AddInputField).
+func (b *Builder) AddInputField(label, value string, fieldWidth int, accept func(textToCheck string, lastChar rune) bool, changed func(text string)) *Builder {
+	return b.addInputField(label, value, fieldWidth, 0, accept, changed)
+}
+
+// AddPasswordField defines a password field (see
+// FormScrollable.AddPasswordField).
+func (b *Builder) AddPasswordField(label, value string, fieldWidth int, mask rune, changed func(text string)) *Builder {
+	if mask == 0 {
+		mask = '*'
+	}
+	b.addInputField(label, value, fieldWidth, mask, nil, changed)
+	b.items[len(b.items)-1].setState = func(state *itemState) {
+		state.secret = true
+	}
+	return b
+}
+
+// addInputField defines an input field whose text is masked with the given
+// character unless it is 0.
+func (b *Builder) addInputField(label, value string, fieldWidth int, mask rune, accept func(textToCheck string, lastChar rune) bool, changed func(text string)) *Builder {
+	update := func(item FormItem, setValue bool) bool {
+		field, ok := item.(*InputField)
+		if !ok {
+			return false
+		}
+		field.SetFieldWidth(fieldWidth).
+			SetMaskCharacter(mask).
+			SetAcceptanceFunc(accept).
+			SetChangedFunc(changed)
+		if setValue {
+			setInputFieldText(field, value)
+		}
+		return true
+	}
+	b.items = append(b.items, itemDefinition{
+		label: label,
+		value: value,
+		create: func() FormItem {
+			field := NewInputField().SetLabel(label)
+			update(field, true)
+			return field
+		},
+		update: update,
+	})
+	return b
+}
+
+// AddTextArea defines a text area (see FormScrollable.AddTextArea).
+func (b *Builder) AddTextArea(label, text string, fieldWidth, fieldHeight, maxLength int, changed func(text string)) *Builder {
+	if fieldHeight == 0 {
+		fieldHeight = DefaultFormFieldHeight
+	}
+	update := func(item FormItem, setValue bool) bool {
+		textArea, ok := item.(*TextArea)
+		if !ok {
+			return false
+		}
+		textArea.SetSize(fieldHeight, fieldWidth).
+			SetMaxLength(maxLength).
+			SetChangedFunc(nil)
+		if setValue {
+			textArea.SetText(text, true)
+		}
+		if changed != nil {
+			textArea.SetChangedFunc(func() {
+				changed(textArea.GetText())
+			})
+		}
+		return true
+	}
+	b.items = append(b.items, itemDefinition{
+		label: label,
+		value: text,
+		create: func() FormItem {
+			textArea := NewTextArea().SetLabel(label)
+			update(textArea, true)
+			return textArea
+		},
+		update: update,
+	})
+	return b
+}
+
+// AddTextView defines a text view (see FormScrollable.AddTextView).
+func (b *Builder) AddTextView(label, text string, fieldWidth, fieldHeight int, dynamicColors, scrollable bool) *Builder {
+	if fieldHeight == 0 {
+		fieldHeight = DefaultFormFieldHeight
+	}
+	update := func(item FormItem, setValue bool) bool {
+		textView, ok := item.(*TextView)
+		if !ok {
+			return false
+		}
+		textView.SetSize(fieldHeight, fieldWidth).
+			SetDynamicColors(dynamicColors).
+			SetScrollable(scrollable)
+		if setValue {
+			textView.SetText(text)
+		}
+		return true
+	}
+	b.items = append(b.items, itemDefinition{
+		label: label,
+		value: text,
+		create: func() FormItem {
+			textView := NewTextView().SetLabel(label)
+			update(textView, true)
+			return textView
+		},
+		update: update,
+		setState: func(state *itemState) {
+			state.fixed = !scrollable
+		},
+	})
+	return b
+}
+
+// AddCheckbox defines a checkbox (see FormScrollable.AddCheckbox).
+func (b *Builder) AddCheckbox(label string, checked bool, changed func(checked bool)) *Builder {
+	update := func(item FormItem, setValue bool) bool {
+		checkbox, ok := item.(*Checkbox)
+		if !ok {
+			return false
+		}
+		checkbox.SetChangedFunc(changed)
+		if setValue {
+			checkbox.SetChecked(checked)
+		}
+		return true
+	}
+	b.items = append(b.items, itemDefinition{
+		label: label,
+		value: checked,
+		create: func() FormItem {
+			checkbox := NewCheckbox().SetLabel(label)
+			update(checkbox, true)
+			return checkbox
+		},
+		update: update,
+	})
+	return b
+}
+
+// AddDropDown defines a drop-down (see FormScrollable.AddDropDown).
+func (b *Builder) AddDropDown(label string, options []string, initialOption int, selected func(option string, optionIndex int)) *Builder {
+	update := func(item FormItem, setValue bool) bool {
+		dropDown, ok := item.(*DropDown)
+		if !ok {
+			return false
+		}
+		if setValue {
+			dropDown.SetOptions(options, selected).
+				SetCurrentOption(initialOption)
+		} else {
+			dropDown.SetSelectedFunc(selected)
+		}
+		return true
+	}
+	b.items = append(b.items, itemDefinition{
+		label: label,
+		value: dropDownDefinition{options: options, initial: initialOption},
+		create: func() FormItem {
+			dropDown := NewDropDown().SetLabel(label)
+			update(dropDown, true)
+			return dropDown
+		},
+		update: update,
+	})
+	return b
+}
+
+// AddFormItem adds the given form item (see FormScrollable.AddFormItem). It
+// is kept if it already is part of the form.
+func (b *Builder) AddFormItem(item FormItem) *Builder {
+	b.items = append(b.items, itemDefinition{
+		label:  item.GetLabel(),
+		create: func() FormItem { return item },
+		update: func(existing FormItem, setValue bool) bool {
+			return existing == item
+		},
+	})
+	return b
+}
+
+// Rebuild replaces the form items with the items defined by the given
+// function, e.g. to refresh a form whose items depend on changing data.
+// Unlike Clear followed by adding the items again, existing items are reused
+// if an item with the same label and type is defined again, keeping their
+// state (e.g. validators, notices, and captured defaults) and their focus.
+// Reused items are updated in place. Their values are only set if they were
+// defined differently in the previous Rebuild, so that values the user is
+// editing are not overwritten. Other items are removed, new items are
+// created. The buttons and the scroll offset are kept.
+func (f *FormScrollable) Rebuild(build func(b *Builder)) *FormScrollable {
+	b := &Builder{}
+	build(b)
+
+	// Reuse items or create new ones.
+	reused := make(map[FormItem]bool)
+	items := make([]FormItem, 0, len(b.items))
+	for _, definition := range b.items {
+		item := f.reuseItem(definition, reused)
+		if item == nil {
+			item = definition.create()
+		}
+		items = append(items, item)
+		state := f.state(item)
+		state.defined = definition.value
+		if definition.setState != nil {
+			definition.setState(state)
+		}
+	}
+	// Remove the items which were not reused.
+	focused, previous := f.focusIndex(), f.items
+	var focusLost bool
+	for index, item := range previous {
+		if reused[item] {
+			continue
+		}
+		if index == focused {
+			item.Blur()
+			focusLost = true
+		}
+		delete(f.itemStates, item)
+		f.forgetLabel(item)
+		f.unbindItem(item)
+		f.unlinkItem(item)
+	}
+	f.items = items
+	f.labelsChanged()
+	if !f.isLoading() {
+		f.stopLoadingAnimation()
+	}
+
+	// The focus stays with the same element if possible. Without any items,
+	// the focused element is 0 by default, which may not be a button.
+	switch element := f.focusedElement; {
+	case focused >= len(previous):
+		f.focusedElement = len(items) + focused - len(previous)
+	case element >= 0 && element < len(previous) && reused[previous[element]]:
+		f.focusedElement = f.itemIndex(previous[element])
+	case element >= len(previous) && len(previous) > 0:
+		f.focusedElement = len(items) + element - len(previous)
+	default:
+		f.focusedElement = min(element, max(len(items)-1, 0))
+	}
+	if focusLost && f.app != nil {
+		// Move on to the next element (see SetApplication).
+		f.lastFinishedKey = tcell.KeyTab
+		f.app.SetFocus(f)
+	}
+	return f
+}
+
+// reuseItem returns the first form item which wasn't reused yet and which can
+// be updated to the given definition, after updating it, or nil if there is
+// none.
+func (f *FormScrollable) reuseItem(definition itemDefinition, reused map[FormItem]bool) FormItem {
+	for _, item := range f.items {
+		if reused[item] || item.GetLabel() != definition.label {
+			continue
+		}
+		setValue := true
+		if state, ok := f.itemStates[item]; ok && state.defined != nil {
+			setValue = !reflect.DeepEqual(state.defined, definition.value)
+		}
+		if definition.update(item, setValue) {
+			reused[item] = true
+			return item
+		}
+	}
+	return nil
+}
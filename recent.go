@@ -0,0 +1,154 @@
+package form
+
+import "sync"
+
+// maxRecentOptions is the maximum number of options shown in the "Recent"
+// section of an OptionsDropDown.
+const maxRecentOptions = 5
+
+// RecentStore remembers the options which were recently selected in
+// drop-downs, e.g. in a file, so that they can be offered first the next time.
+// See OptionsDropDown.SetRecentStore.
+type RecentStore interface {
+	// Recent returns the texts of the options recently selected in the
+	// drop-down with the given key, most recent first.
+	Recent(key string) []string
+
+	// Add records that the option with the given text was selected in the
+	// drop-down with the given key.
+	Add(key, text string)
+}
+
+// MemoryRecentStore is a RecentStore which keeps the recently selected
+// options in memory. It is safe for concurrent use.
+type MemoryRecentStore struct {
+	mutex  sync.Mutex
+	limit  int
+	recent map[string][]string
+}
+
+var _ RecentStore = (*MemoryRecentStore)(nil)
+
+// NewMemoryRecentStore returns a new store which remembers up to the given
+// number of options per drop-down (maxRecentOptions if limit is 0 or less).
+func NewMemoryRecentStore(limit int) *MemoryRecentStore {
+	if limit <= 0 {
+		limit = maxRecentOptions
+	}
+	return &MemoryRecentStore{
+		limit:  limit,
+		recent: make(map[string][]string),
+	}
+}
+
+// Recent returns the texts of the options recently selected in the drop-down
+// with the given key, most recent first.
+func (s *MemoryRecentStore) Recent(key string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.recent[key]...)
+}
+
+// Add records that the option with the given text was selected in the
+// drop-down with the given key.
+func (s *MemoryRecentStore) Add(key, text string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	recent := []string{text}
+	for _, previous := range s.recent[key] {
+		if previous != text && len(recent) < s.limit {
+			recent = append(recent, previous)
+		}
+	}
+	s.recent[key] = recent
+}
+
+// SetRecentStore sets the store which remembers the options selected by the
+// user. While the list is open, up to five of the recently selected options
+// are shown in a "Recent" section above the other options. The key identifies
+// this drop-down in the store. A nil store removes the section.
+//
+// Like all sections, the "Recent" section is counted by option indices.
+func (d *OptionsDropDown) SetRecentStore(store RecentStore, key string) *OptionsDropDown {
+	d.recentStore, d.recentKey = store, key
+	return d
+}
+
+// SetPinnedOptions sets the texts of the options which are always shown in a
+// "Pinned" section above the other options (and above the "Recent" section)
+// while the list is open. Texts which don't match any option are ignored.
+//
+// Like all sections, the "Pinned" section is counted by option indices.
+func (d *OptionsDropDown) SetPinnedOptions(texts ...string) *OptionsDropDown {
+	d.pinned = texts
+	return d
+}
+
+// updateShortcuts replaces the "Pinned" and "Recent" sections at the top of
+// the options with the current ones. The shortcut options are the same as
+// the options they refer to. The current selection is kept.
+func (d *OptionsDropDown) updateShortcuts() {
+	var current *DropDownOption
+	if d.current >= 0 && d.current < len(d.options) {
+		current = d.options[d.current]
+	}
+	d.options = d.options[d.shortcuts:]
+	d.shortcuts = 0
+
+	// Find the options to show.
+	find := func(text string) *DropDownOption {
+		for _, option := range d.options {
+			if !option.Header && option.Text == text {
+				return option
+			}
+		}
+		return nil
+	}
+	var pinned, recent []*DropDownOption
+	for _, text := range d.pinned {
+		if option := find(text); option != nil {
+			pinned = append(pinned, option)
+		}
+	}
+	if d.recentStore != nil {
+		for _, text := range d.recentStore.Recent(d.recentKey) {
+			option := find(text)
+			if option == nil || len(recent) >= maxRecentOptions || containsOption(pinned, option) {
+				continue
+			}
+			recent = append(recent, option)
+		}
+	}
+
+	// Prepend the sections.
+	var shortcuts []*DropDownOption
+	if len(pinned) > 0 {
+		shortcuts = append(shortcuts, &DropDownOption{Text: "Pinned", Header: true})
+		shortcuts = append(shortcuts, pinned...)
+	}
+	if len(recent) > 0 {
+		shortcuts = append(shortcuts, &DropDownOption{Text: "Recent", Header: true})
+		shortcuts = append(shortcuts, recent...)
+	}
+	d.shortcuts = len(shortcuts)
+	d.options = append(shortcuts, d.options...)
+
+	// Keep the selection in the main list.
+	d.current = -1
+	for index, option := range d.options {
+		if option == current && index >= d.shortcuts {
+			d.current = index
+			break
+		}
+	}
+}
+
+// containsOption returns whether the given option is in the list.
+func containsOption(options []*DropDownOption, option *DropDownOption) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
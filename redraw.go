@@ -0,0 +1,67 @@
+package form
+
+import (
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// RedrawCoordinator batches the redraws requested by many live widgets, e.g.
+// tickers, watched items (see FormScrollable.BindWatch), or log views, so that
+// the application is redrawn at most a given number of times per second.
+// Without it, widgets which update concurrently can flood the application with
+// redraws, which delays the processing of user input.
+//
+// Updates are run on the application's goroutine right away. Only the redraws
+// are delayed: all redraws requested within one interval are done as one.
+// Forms use a coordinator for the redraws they trigger on their own (see
+// FormScrollable.SetRedrawCoordinator). Like tview.Application.QueueUpdate,
+// the coordinator may be used from any goroutine but the application's.
+type RedrawCoordinator struct {
+	app     *tview.Application
+	limiter *redrawLimiter
+}
+
+// NewRedrawCoordinator returns a new coordinator which redraws the given
+// application at most the given number of times per second. Values less than
+// 1 are treated as 1.
+func NewRedrawCoordinator(app *tview.Application, fps int) *RedrawCoordinator {
+	c := &RedrawCoordinator{
+		app:     app,
+		limiter: &redrawLimiter{enabled: true},
+	}
+	return c.SetMaxRedrawRate(fps)
+}
+
+// SetMaxRedrawRate sets the maximum number of redraws per second. Values less
+// than 1 are treated as 1.
+func (c *RedrawCoordinator) SetMaxRedrawRate(fps int) *RedrawCoordinator {
+	c.limiter.mutex.Lock()
+	c.limiter.interval = time.Second / time.Duration(max(fps, 1))
+	c.limiter.mutex.Unlock()
+	return c
+}
+
+// QueueUpdateDraw runs the given function on the application's goroutine, like
+// tview.Application.QueueUpdateDraw, and redraws the application as soon as
+// the maximum redraw rate allows it. It returns after the function was run.
+func (c *RedrawCoordinator) QueueUpdateDraw(update func()) {
+	c.limiter.queueUpdateDraw(c.app, update)
+}
+
+// RequestDraw redraws the application as soon as the maximum redraw rate
+// allows it.
+func (c *RedrawCoordinator) RequestDraw() {
+	c.QueueUpdateDraw(func() {})
+}
+
+// SetRedrawCoordinator sets the coordinator which limits the redraws the form
+// triggers on its own, e.g. when notices expire, items finished loading, or
+// watched values changed (see BindWatch), so that they are batched with the
+// redraws of other widgets. With a coordinator, the maximum redraw rate of the
+// low-bandwidth mode (see SetMaxRedrawRate) doesn't apply. A nil coordinator
+// removes it.
+func (f *FormScrollable) SetRedrawCoordinator(coordinator *RedrawCoordinator) *FormScrollable {
+	f.coordinator = coordinator
+	return f
+}
@@ -0,0 +1,67 @@
+package form
+
+import (
+	"strings"
+
+	. "github.com/rivo/tview"
+)
+
+// defaultRequiredMarker is appended to the labels of required items by
+// default.
+const defaultRequiredMarker = "*"
+
+// SetItemRequired sets whether the form item at the given index requires a
+// value. The labels of required items end with a marker (see
+// SetRequiredMarker). Empty required items fail validation (see Validate) with
+// ErrRequired, which also keeps the form from being submitted. Items are empty
+// if their text is blank, if they are lists without elements, or if they are
+// unchecked checkboxes.
+func (f *FormScrollable) SetItemRequired(index int, required bool) *FormScrollable {
+	state := f.state(f.items[index])
+	state.required = required
+	state.err = nil
+	f.labelChanged(f.items[index])
+	return f
+}
+
+// IsItemRequired returns whether the form item at the given index requires a
+// value (see SetItemRequired).
+func (f *FormScrollable) IsItemRequired(index int) bool {
+	state, ok := f.itemStates[f.items[index]]
+	return ok && state.required
+}
+
+// SetRequiredMarker sets the text which is appended to the labels of required
+// items, "*" by default. It may contain style tags, e.g. "[red]*".
+func (f *FormScrollable) SetRequiredMarker(marker string) *FormScrollable {
+	f.requiredMarker = marker
+	f.labelsChanged()
+	return f
+}
+
+// requiredMarkerOf returns the marker which is appended to the given item's
+// label, an empty string if the item is not required.
+func (f *FormScrollable) requiredMarkerOf(item FormItem) string {
+	if state, ok := f.itemStates[item]; ok && state.required {
+		return f.requiredMarker
+	}
+	return ""
+}
+
+// isEmptyItem returns whether the given item has no value (see
+// SetItemRequired).
+func isEmptyItem(item FormItem) bool {
+	value, ok := getItemValue(unwrapItem(item))
+	if !ok {
+		return strings.TrimSpace(getItemText(item)) == ""
+	}
+	switch value := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !value
+	case []string:
+		return len(value) == 0
+	}
+	return strings.TrimSpace(getItemText(item)) == ""
+}
@@ -0,0 +1,204 @@
+package form
+
+import (
+	"reflect"
+
+	. "github.com/rivo/tview"
+)
+
+// CaptureDefaults remembers the current values of all form items as their
+// defaults, replacing previously captured ones. Items whose value differs
+// from their default are dirty (see IsItemDirty) and can be reverted. Items
+// which have no default yet get their value at the time they are first drawn
+// as their default.
+func (f *FormScrollable) CaptureDefaults() *FormScrollable {
+	for _, item := range f.items {
+		state := f.state(item)
+		state.defaultValue, state.hasDefault = f.snapshot(item)
+	}
+	f.notifyDirtyChanged()
+	return f
+}
+
+// captureMissingDefaults captures the defaults of the items which have none.
+func (f *FormScrollable) captureMissingDefaults() {
+	for _, item := range f.items {
+		if state := f.state(item); !state.hasDefault {
+			state.defaultValue, state.hasDefault = f.snapshot(item)
+		}
+	}
+}
+
+// IsItemDirty returns whether the value of the form item at the given index
+// differs from its captured default (see CaptureDefaults).
+func (f *FormScrollable) IsItemDirty(index int) bool {
+	if index < 0 || index >= len(f.items) {
+		return false
+	}
+	return f.isDirty(f.items[index])
+}
+
+// isDirty returns whether the value of the given item differs from its
+// captured default.
+func (f *FormScrollable) isDirty(item FormItem) bool {
+	state, ok := f.itemStates[item]
+	if !ok || !state.hasDefault {
+		return false
+	}
+	value, _ := f.snapshot(item)
+	return !reflect.DeepEqual(value, state.defaultValue)
+}
+
+// RevertItem sets the form item at the given index back to its captured
+// default (see CaptureDefaults) and calls its revert handler (see
+// SetItemRevertFunc). Nothing happens if the item is not dirty.
+func (f *FormScrollable) RevertItem(index int) *FormScrollable {
+	item := f.items[index]
+	if !f.isDirty(item) {
+		return f
+	}
+	state := f.state(item)
+	restoreItemSnapshot(item, state.defaultValue)
+	if state.err != nil {
+		f.validateItem(item)
+	}
+	if state.revert != nil {
+		state.revert()
+	}
+	f.notifyDirtyChanged()
+	return f
+}
+
+// Reset reverts all dirty form items to their captured defaults (see
+// RevertItem).
+func (f *FormScrollable) Reset() *FormScrollable {
+	for index := range f.items {
+		f.RevertItem(index)
+	}
+	return f
+}
+
+// ResetToOriginal reverts all dirty form items to their original values, i.e.
+// their captured defaults. It is the same as Reset.
+func (f *FormScrollable) ResetToOriginal() *FormScrollable {
+	return f.Reset()
+}
+
+// IsDirty returns whether the value of any form item differs from its
+// captured default (see CaptureDefaults).
+func (f *FormScrollable) IsDirty() bool {
+	for _, item := range f.items {
+		if f.isDirty(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetChangedItems returns the indices of the form items whose value differs
+// from their captured default (see CaptureDefaults), in ascending order.
+func (f *FormScrollable) GetChangedItems() []int {
+	var indices []int
+	for index, item := range f.items {
+		if f.isDirty(item) {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// SetDirtyChangedFunc sets a handler which is called with true when the form
+// becomes dirty (see IsDirty), e.g. to enable a "Save" button or to ask for
+// confirmation before leaving, and with false when it becomes clean again,
+// e.g. after the items were reverted or new defaults were captured. Changes
+// made by the user are detected when the form is drawn next.
+func (f *FormScrollable) SetDirtyChangedFunc(handler func(dirty bool)) *FormScrollable {
+	f.dirtyChanged = handler
+	f.dirty = f.IsDirty()
+	return f
+}
+
+// notifyDirtyChanged calls the "dirty changed" handler if the form became
+// dirty or clean since it was last called.
+func (f *FormScrollable) notifyDirtyChanged() {
+	if f.dirtyChanged == nil {
+		return
+	}
+	if dirty := f.IsDirty(); dirty != f.dirty {
+		f.dirty = dirty
+		f.dirtyChanged(dirty)
+	}
+}
+
+// SetRevertControls sets whether a control ("↺") is shown right of each dirty
+// form item which reverts the item to its captured default when clicked (see
+// RevertItem).
+func (f *FormScrollable) SetRevertControls(show bool) *FormScrollable {
+	f.revertControls = show
+	return f
+}
+
+// SetItemRevertFunc sets a handler which is called after the form item at the
+// given index was reverted to its captured default.
+func (f *FormScrollable) SetItemRevertFunc(index int, handler func()) *FormScrollable {
+	f.state(f.items[index]).revert = handler
+	return f
+}
+
+// revertControlIndexAt returns the index of the dirty item whose revert
+// control is at the given screen position or -1 if there is none.
+func (f *FormScrollable) revertControlIndexAt(x, y int) int {
+	for index, item := range f.items {
+		itemX, itemY, itemWidth, _ := item.GetRect()
+		if x == itemX+itemWidth+1 && y == itemY && f.isDirty(item) {
+			return index
+		}
+	}
+	return -1
+}
+
+// dropDownSnapshot is the snapshot of a tview drop-down: the index of its
+// current option, used to restore it, and the option's text.
+type dropDownSnapshot struct {
+	index int
+	text  string
+}
+
+// itemSnapshot returns the value of the given item as it is captured as a
+// default, and whether the item has a value. For tview drop-downs, this is a
+// dropDownSnapshot (see setItemValue).
+func itemSnapshot(item FormItem) (any, bool) {
+	if wrapped, ok := item.(*WrappedItem); ok {
+		item = wrapped.GetItem()
+	}
+	switch item := item.(type) {
+	case *DropDown:
+		index, text := item.GetCurrentOption()
+		return dropDownSnapshot{index: index, text: text}, true
+	case *LazyDropDown:
+		index, text := item.GetCurrentOption()
+		return dropDownSnapshot{index: index, text: text}, true
+	}
+	return getItemValue(item)
+}
+
+// restoreItemSnapshot sets the value of the given item to a value returned by
+// itemSnapshot.
+func restoreItemSnapshot(item FormItem, value any) {
+	if wrapped, ok := item.(*WrappedItem); ok {
+		item = wrapped.GetItem()
+	}
+	if snapshot, ok := value.(dropDownSnapshot); ok {
+		value = snapshot.index
+	}
+	setItemValue(item, value)
+}
+
+// snapshotValue converts a value returned by itemSnapshot to the item's value
+// as returned by getItemValue.
+func snapshotValue(value any) any {
+	if snapshot, ok := value.(dropDownSnapshot); ok {
+		return snapshot.text
+	}
+	return value
+}
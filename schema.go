@@ -0,0 +1,350 @@
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	. "github.com/rivo/tview"
+)
+
+// SchemaValidators are the validators which the items of a form schema (see
+// FromSchema) refer to by name, in addition to the built-in ones
+// "minlength=N", "maxlength=N", and "pattern=REGEXP". Apps may add their own
+// validators before calling FromSchema. The map is not safe for concurrent
+// modification.
+var SchemaValidators = map[string]func(value string) error{
+	"integer": func(value string) error {
+		if value == "" {
+			return nil
+		}
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		return nil
+	},
+	"number": func(value string) error {
+		if value == "" {
+			return nil
+		}
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		return nil
+	},
+	"email": func(value string) error {
+		if value == "" {
+			return nil
+		}
+		if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+			return fmt.Errorf("%q is not an email address", value)
+		}
+		return nil
+	},
+}
+
+// formSchema is the document read by FromSchema.
+type formSchema struct {
+	Title   string       `json:"title"`
+	Items   []itemSchema `json:"items"`
+	Buttons []string     `json:"buttons"`
+}
+
+// itemSchema is a form item in the document read by FromSchema.
+type itemSchema struct {
+	Type       string   `json:"type"`
+	Label      string   `json:"label"`
+	Value      any      `json:"value"`
+	Width      int      `json:"width"`
+	Height     int      `json:"height"`
+	MaxLength  int      `json:"maxLength"`
+	Options    []string `json:"options"`
+	Mask       string   `json:"mask"`
+	Accept     string   `json:"accept"`
+	CIDR       bool     `json:"cidr"`
+	Scrollable bool     `json:"scrollable"`
+	Required   bool     `json:"required"`
+	Secret     bool     `json:"secret"`
+	Help       string   `json:"help"`
+	Validators []string `json:"validators"`
+}
+
+// FromSchema returns a new form built from the given JSON or YAML document, so
+// that the layout of a form can be configured without recompiling. For
+// example:
+//
+//	{
+//	  "title": "Sign up",
+//	  "items": [
+//	    {"type": "section", "label": "Account"},
+//	    {"type": "input", "label": "Email", "width": 30, "required": true, "validators": ["email"]},
+//	    {"type": "password", "label": "Password", "validators": ["minlength=8"]},
+//	    {"type": "dropdown", "label": "Plan", "options": ["Free", "Pro"], "value": "Free"},
+//	    {"type": "checkbox", "label": "Newsletter", "value": true}
+//	  ],
+//	  "buttons": ["Sign up", "Cancel"]
+//	}
+//
+// The item types are "input", "password", "masked" (with a "mask", see
+// MaskedInputField), "textarea", "textview", "checkbox", "dropdown",
+// "combobox", "tags", "time", "ip" (optionally with "cidr"), and "section"
+// (see AddSection). Items take the "value" (text, a boolean for checkboxes, a
+// list of texts for tags fields, the text of the selected option for
+// drop-downs), "width", "height", and "maxLength" the form's methods of the
+// same name take. Input fields may restrict typing to an "integer" or a
+// "float" ("accept"). Items may be "required" (see SetItemRequired) or
+// "secret" (see SetItemSecret) and have a "help" text (see SetItemHelp).
+// Their "validators" are looked up in SchemaValidators and run in order.
+//
+// Buttons are added without handlers, they are set with GetButton. Unknown
+// fields, item types, and validators are errors, as are values which the items
+// don't accept, e.g. invalid times and IP addresses.
+//
+// The same schema in YAML:
+//
+//	title: Sign up
+//	items:
+//	  - {type: section, label: Account}
+//	  - {type: input, label: Email, width: 30, required: true, validators: [email]}
+//	buttons: [Sign up, Cancel]
+//
+// Documents starting with "{" are read as JSON, others as YAML.
+func FromSchema(data []byte) (*FormScrollable, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		converted, err := yamlToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		data = converted
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var schema formSchema
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, err
+	}
+
+	f := NewFormScrollable()
+	f.SetTitle(schema.Title)
+	for index, item := range schema.Items {
+		if err := f.addSchemaItem(item); err != nil {
+			return nil, fmt.Errorf("item %d (%s): %w", index, item.Label, err)
+		}
+	}
+	for _, label := range schema.Buttons {
+		f.AddButton(label, nil)
+	}
+	return f, nil
+}
+
+// addSchemaItem adds the given item of a form schema.
+func (f *FormScrollable) addSchemaItem(item itemSchema) error {
+	validator, err := schemaValidator(item.Validators)
+	if err != nil {
+		return err
+	}
+	text, err := schemaText(item.Value)
+	if err != nil {
+		return err
+	}
+	switch item.Type {
+	case "input":
+		var accept func(textToCheck string, lastChar rune) bool
+		switch item.Accept {
+		case "":
+		case "integer":
+			accept = InputFieldInteger
+		case "float":
+			accept = InputFieldFloat
+		default:
+			return fmt.Errorf("unknown accept value %q", item.Accept)
+		}
+		f.AddInputField(item.Label, text, item.Width, accept, nil)
+		if item.MaxLength > 0 {
+			f.items[len(f.items)-1].(*InputField).SetAcceptanceFunc(schemaMaxLength(accept, item.MaxLength))
+		}
+	case "password":
+		f.AddPasswordField(item.Label, text, item.Width, 0, nil)
+	case "masked":
+		f.AddMaskedInputField(item.Label, item.Mask, text, nil)
+	case "textarea":
+		f.AddTextArea(item.Label, text, item.Width, item.Height, item.MaxLength, nil)
+	case "textview":
+		f.AddTextView(item.Label, text, item.Width, item.Height, false, item.Scrollable)
+	case "checkbox":
+		checked, _ := item.Value.(bool)
+		f.AddCheckbox(item.Label, checked, nil)
+	case "dropdown":
+		initial := -1
+		for index, option := range item.Options {
+			if option == text {
+				initial = index
+			}
+		}
+		f.AddDropDown(item.Label, item.Options, initial, nil)
+	case "combobox":
+		f.AddComboBox(item.Label, item.Options, text, nil)
+	case "tags":
+		tags, err := schemaTexts(item.Value)
+		if err != nil {
+			return err
+		}
+		f.AddTagsField(item.Label, tags, nil)
+	case "time":
+		f.AddTimeField(item.Label, 0, 0, nil)
+		if text != "" && !setItemText(f.items[len(f.items)-1], text) {
+			return fmt.Errorf("invalid time %q", text)
+		}
+	case "ip":
+		f.AddIPField(item.Label, "", item.CIDR, nil)
+		if err := f.items[len(f.items)-1].(*IPField).SetText(text); err != nil {
+			return err
+		}
+	case "section":
+		f.AddSection(item.Label)
+	default:
+		return fmt.Errorf("unknown item type %q", item.Type)
+	}
+
+	index := len(f.items) - 1
+	if validator != nil {
+		if existing := f.itemStates[f.items[index]]; existing != nil && existing.validator != nil {
+			validator = chainValidators(existing.validator, validator)
+		}
+		f.SetValidator(index, validator)
+	}
+	if item.Required {
+		f.SetItemRequired(index, true)
+	}
+	if item.Secret {
+		f.SetItemSecret(index, true)
+	}
+	if item.Help != "" {
+		f.SetItemHelp(index, item.Help)
+	}
+	return nil
+}
+
+// schemaText returns the given value of a schema item as text.
+func schemaText(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case []any:
+		texts, err := schemaTexts(value)
+		return strings.Join(texts, ", "), err
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+// schemaTexts returns the given value of a schema item, a list of texts or a
+// single text, as a list of texts.
+func schemaTexts(value any) ([]string, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{value}, nil
+	case []any:
+		texts := make([]string, 0, len(value))
+		for _, element := range value {
+			text, ok := element.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of texts, got %v", value)
+			}
+			texts = append(texts, text)
+		}
+		return texts, nil
+	}
+	return nil, fmt.Errorf("expected a list of texts, got %v", value)
+}
+
+// schemaMaxLength returns an acceptance function which only accepts texts of
+// at most the given length that the given function (if any) accepts.
+func schemaMaxLength(accept func(textToCheck string, lastChar rune) bool, maxLength int) func(textToCheck string, lastChar rune) bool {
+	return func(textToCheck string, lastChar rune) bool {
+		if utf8.RuneCountInString(textToCheck) > maxLength {
+			return false
+		}
+		return accept == nil || accept(textToCheck, lastChar)
+	}
+}
+
+// schemaValidator returns a validator which runs the validators with the given
+// names in order, nil if there are none.
+func schemaValidator(names []string) (func(value string) error, error) {
+	var validators []func(value string) error
+	for _, name := range names {
+		key, argument, _ := strings.Cut(name, "=")
+		var validator func(value string) error
+		switch key {
+		case "minlength", "maxlength":
+			length, err := strconv.Atoi(argument)
+			if err != nil {
+				return nil, fmt.Errorf("validator %s: %w", name, err)
+			}
+			validator = lengthValidator(key == "minlength", length)
+		case "pattern":
+			pattern, err := regexp.Compile(argument)
+			if err != nil {
+				return nil, fmt.Errorf("validator %s: %w", name, err)
+			}
+			validator = func(value string) error {
+				if value != "" && !pattern.MatchString(value) {
+					return fmt.Errorf("%q doesn't match %s", value, argument)
+				}
+				return nil
+			}
+		default:
+			var ok bool
+			if validator, ok = SchemaValidators[name]; !ok {
+				return nil, fmt.Errorf("unknown validator %q", name)
+			}
+		}
+		validators = append(validators, validator)
+	}
+	if len(validators) == 0 {
+		return nil, nil
+	}
+	return chainValidators(validators...), nil
+}
+
+// lengthValidator returns a validator which checks that non-empty values have
+// at least (if atLeast is true) or at most the given number of characters.
+func lengthValidator(atLeast bool, length int) func(value string) error {
+	return func(value string) error {
+		count := utf8.RuneCountInString(value)
+		switch {
+		case value == "":
+		case atLeast && count < length:
+			return fmt.Errorf("at least %d characters are required", length)
+		case !atLeast && count > length:
+			return fmt.Errorf("at most %d characters are allowed", length)
+		}
+		return nil
+	}
+}
+
+// chainValidators returns a validator which runs the given validators in
+// order and returns the first error.
+func chainValidators(validators ...func(value string) error) func(value string) error {
+	return func(value string) error {
+		for _, validator := range validators {
+			if err := validator(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package form
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromSchema(t *testing.T) {
+	f, err := FromSchema([]byte(`{
+		"title": "Sign up",
+		"items": [
+			{"type": "section", "label": "Account"},
+			{"type": "input", "label": "Email", "width": 30, "required": true, "validators": ["email"]},
+			{"type": "password", "label": "Password", "validators": ["minlength=8"]},
+			{"type": "dropdown", "label": "Plan", "options": ["Free", "Pro"], "value": "Pro"},
+			{"type": "checkbox", "label": "Newsletter", "value": true},
+			{"type": "time", "label": "Alarm", "value": "07:30"},
+			{"type": "ip", "label": "Address", "value": "10.0.0.0/8", "cidr": true}
+		],
+		"buttons": ["Sign up", "Cancel"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.GetTitle() != "Sign up" || f.GetButtonCount() != 2 {
+		t.Errorf("unexpected title %q or button count %d", f.GetTitle(), f.GetButtonCount())
+	}
+	email := f.GetFormItemIndex("Email")
+	for label, expected := range map[string]string{
+		"Plan":       "Pro",
+		"Newsletter": "true",
+		"Alarm":      "07:30",
+		"Address":    "10.0.0.0/8",
+	} {
+		if text := getItemText(f.GetFormItemByLabel(label)); text != expected {
+			t.Errorf("%s: expected %q, got %q", label, expected, text)
+		}
+	}
+
+	if errs := f.Validate(); len(errs) != 1 {
+		t.Fatalf("expected the required email to be missing, got %v", errs)
+	}
+	setItemText(f.GetFormItem(email), "not an address")
+	setItemText(f.GetFormItemByLabel("Password"), "short")
+	if errs := f.Validate(); len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+}
+
+func TestFromSchemaYAML(t *testing.T) {
+	f, err := FromSchema([]byte(`
+title: Sign up
+items:
+  - {type: input, label: Email, required: true, validators: [email]}
+  - type: tags
+    label: Tags
+    value: [a, b]
+  - {type: time, label: Alarm, value: "07:30"}
+  - {type: input, label: Port, value: 8080, accept: integer}
+buttons: [Sign up]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.GetTitle() != "Sign up" || f.GetFormItemCount() != 4 || f.GetButtonCount() != 1 {
+		t.Fatalf("unexpected form %q with %d items and %d buttons", f.GetTitle(), f.GetFormItemCount(), f.GetButtonCount())
+	}
+	for label, expected := range map[string]string{"Tags": "a, b", "Alarm": "07:30", "Port": "8080"} {
+		if text := getItemText(f.GetFormItemByLabel(label)); text != expected {
+			t.Errorf("%s: expected %q, got %q", label, expected, text)
+		}
+	}
+	if !f.IsItemRequired(0) {
+		t.Error("expected the email to be required")
+	}
+	if _, err := FromSchema([]byte("items:\n  - {type: input, color: red}\n")); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+	if _, err := FromSchema([]byte("items: [")); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestFromSchemaErrors(t *testing.T) {
+	for name, schema := range map[string]string{
+		"syntax":          `{`,
+		"unknown field":   `{"items": [{"type": "input", "color": "red"}]}`,
+		"unknown type":    `{"items": [{"type": "slider"}]}`,
+		"unknown check":   `{"items": [{"type": "input", "validators": ["zip"]}]}`,
+		"invalid time":    `{"items": [{"type": "time", "value": "25:99"}]}`,
+		"invalid accept":  `{"items": [{"type": "input", "accept": "hex"}]}`,
+		"invalid ip":      `{"items": [{"type": "ip", "value": "1.2.3.4.5"}]}`,
+		"ip without cidr": `{"items": [{"type": "ip", "value": "10.0.0.0/8"}]}`,
+	} {
+		if _, err := FromSchema([]byte(schema)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		} else if strings.TrimSpace(err.Error()) == "" {
+			t.Errorf("%s: empty error message", name)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ScrollBarVisibility determines when a scroll bar is shown.
+type ScrollBarVisibility int
+
+// Scroll bar visibility options.
+const (
+	ScrollBarAuto   ScrollBarVisibility = iota // Only if the content doesn't fit.
+	ScrollBarAlways                            // Always, even if there is nothing to scroll.
+	ScrollBarNever                             // Never.
+)
+
+// scrollBar is a vertical scroll bar track with a thumb whose size and
+// position reflect the visible portion of the content.
+type scrollBar struct {
+	// The track's position on screen.
+	x, y, height int
+
+	// The thumb's position (relative to the track) and size.
+	thumbPosition, thumbSize int
+
+	// The number of scrollable rows, i.e. content height minus the height of
+	// the visible area.
+	scrollable int
+
+	// While the thumb is dragged, the row within the thumb where it was
+	// grabbed. -1 otherwise.
+	grab int
+
+	// The styles of the track and the thumb.
+	trackStyle, thumbStyle tcell.Style
+}
+
+// newScrollBar returns a new scroll bar using the default styles.
+func newScrollBar() *scrollBar {
+	return &scrollBar{
+		grab:       -1,
+		trackStyle: tcell.StyleDefault.Background(tview.Styles.PrimitiveBackgroundColor).Foreground(tview.Styles.ContrastSecondaryTextColor),
+		thumbStyle: tcell.StyleDefault.Background(tview.Styles.PrimitiveBackgroundColor).Foreground(tview.Styles.PrimaryTextColor),
+	}
+}
+
+// update recalculates the track and the thumb for the given content height,
+// visible height, and scroll offset. The track is placed at the given position.
+func (s *scrollBar) update(x, y, height, contentHeight, visibleHeight, offset int) {
+	s.x, s.y, s.height = x, y, height
+	s.scrollable = contentHeight - visibleHeight
+	if s.height <= 0 {
+		return
+	}
+	if s.scrollable <= 0 || contentHeight <= 0 {
+		s.thumbPosition, s.thumbSize = 0, s.height
+		return
+	}
+	s.thumbSize = clamp(s.height*visibleHeight/contentHeight, 1, s.height)
+	s.thumbPosition = clamp((s.height-s.thumbSize)*offset/s.scrollable, 0, s.height-s.thumbSize)
+}
+
+// draw draws the track and the thumb.
+func (s *scrollBar) draw(screen tcell.Screen) {
+	for row := 0; row < s.height; row++ {
+		if row >= s.thumbPosition && row < s.thumbPosition+s.thumbSize {
+			screen.SetContent(s.x, s.y+row, '█', nil, s.thumbStyle)
+		} else {
+			screen.SetContent(s.x, s.y+row, '░', nil, s.trackStyle)
+		}
+	}
+}
+
+// inRect returns whether the given screen position is on the track.
+func (s *scrollBar) inRect(x, y int) bool {
+	return s.height > 0 && x == s.x && y >= s.y && y < s.y+s.height
+}
+
+// mouse processes a mouse event. It returns the new scroll offset (given the
+// current one and the height of a page) and whether the event was consumed.
+// While the thumb is dragged, all mouse events should be sent here.
+func (s *scrollBar) mouse(action tview.MouseAction, x, y, offset, pageHeight int) (int, bool) {
+	row := y - s.y
+	if s.grab >= 0 {
+		switch action {
+		case tview.MouseMove:
+			if free := s.height - s.thumbSize; free > 0 {
+				offset = clamp(row-s.grab, 0, free) * s.scrollable / free
+			}
+		case tview.MouseLeftUp:
+			s.grab = -1
+		}
+		return offset, true
+	}
+	if !s.inRect(x, y) {
+		return offset, false
+	}
+	switch action {
+	case tview.MouseLeftDown:
+		switch {
+		case row < s.thumbPosition:
+			offset -= pageHeight
+		case row >= s.thumbPosition+s.thumbSize:
+			offset += pageHeight
+		default:
+			s.grab = row - s.thumbPosition
+		}
+	}
+	return offset, true
+}
@@ -0,0 +1,127 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// SecretProvider stores secret values such as passwords or tokens by key, e.g.
+// in the operating system's keyring or in a vault.
+type SecretProvider interface {
+	// LookupSecret returns the secret stored under the given key.
+	LookupSecret(key string) (string, error)
+
+	// StoreSecret stores the given secret under the given key.
+	StoreSecret(key, value string) error
+}
+
+// SetSecretProvider sets the provider secret items (see SetItemSecret) are
+// loaded from and saved to. If set, secret items have a control right of them
+// (and after their revert and delete controls) which opens a menu to load the
+// item's value from the provider or to save it there. The menu is also opened
+// with Ctrl+K while a secret item has focus. The outcome is shown as a notice
+// below the item (see SetItemNotice). Set to nil to remove the controls.
+func (f *FormScrollable) SetSecretProvider(provider SecretProvider) *FormScrollable {
+	f.secretProvider = provider
+	return f
+}
+
+// SetItemSecretKey sets the key under which the value of the secret item at
+// the given index is kept by the secret provider (see SetSecretProvider). The
+// item's label is used if the key is empty, which is the default.
+func (f *FormScrollable) SetItemSecretKey(index int, key string) *FormScrollable {
+	f.state(f.items[index]).secretKey = key
+	return f
+}
+
+// isSecret returns whether the value of the given item is secret.
+func (f *FormScrollable) isSecret(item FormItem) bool {
+	state, ok := f.itemStates[item]
+	return ok && state.secret
+}
+
+// secretKey returns the key under which the value of the given item is kept by
+// the secret provider.
+func (f *FormScrollable) secretKey(item FormItem) string {
+	if state, ok := f.itemStates[item]; ok && state.secretKey != "" {
+		return state.secretKey
+	}
+	return item.GetLabel()
+}
+
+// secretControlIndexAt returns the index of the secret item whose secret
+// control is at the given screen position or -1 if there is none.
+func (f *FormScrollable) secretControlIndexAt(x, y int) int {
+	for index, item := range f.items {
+		itemX, itemY, itemWidth, _ := item.GetRect()
+		if f.revertControls {
+			itemX += 2
+		}
+		if f.deletable {
+			itemX += 2
+		}
+		if x == itemX+itemWidth+1 && y == itemY && f.isSecret(item) {
+			return index
+		}
+	}
+	return -1
+}
+
+// showSecretMenu opens the menu to load the value of the secret item at the
+// given index from the secret provider or to save it there.
+func (f *FormScrollable) showSecretMenu(index int) {
+	item := f.items[index]
+	entries := []string{"Load secret", "Save secret"}
+	x, y, width, _ := item.GetRect()
+	f.showMenu(x, y, width, entries, func(choice int) {
+		if index := f.itemIndex(item); index >= 0 {
+			if choice == 0 {
+				f.loadSecret(index)
+			} else {
+				f.saveSecret(index)
+			}
+		}
+	})
+}
+
+// loadSecret sets the value of the item at the given index to the secret the
+// secret provider stores under the item's key.
+func (f *FormScrollable) loadSecret(index int) {
+	item := f.items[index]
+	value, err := f.secretProvider.LookupSecret(f.secretKey(item))
+	if err != nil {
+		f.SetItemNotice(index, Escape(err.Error()), NoticeError)
+		return
+	}
+	if !setItemText(item, value) {
+		f.SetItemNotice(index, "The secret can't be set", NoticeError)
+		return
+	}
+	f.validateItem(item)
+	f.SetItemNotice(index, "Secret loaded", NoticeInfo)
+}
+
+// saveSecret stores the value of the item at the given index with the secret
+// provider under the item's key.
+func (f *FormScrollable) saveSecret(index int) {
+	item := f.items[index]
+	if err := f.secretProvider.StoreSecret(f.secretKey(item), getItemText(item)); err != nil {
+		f.SetItemNotice(index, Escape(err.Error()), NoticeError)
+		return
+	}
+	f.SetItemNotice(index, "Secret saved", NoticeInfo)
+}
+
+// openFocusedSecretMenu opens the secret menu of the focused item if Ctrl+K
+// was pressed. Returns whether the menu was opened.
+func (f *FormScrollable) openFocusedSecretMenu(event *tcell.EventKey) bool {
+	if event.Key() != tcell.KeyCtrlK || f.secretProvider == nil {
+		return false
+	}
+	index := f.focusIndex()
+	if index < 0 || index >= len(f.items) || !f.isSecret(f.items[index]) {
+		return false
+	}
+	f.showSecretMenu(index)
+	return true
+}
@@ -0,0 +1,180 @@
+package form
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	. "github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+// serializedForm is the document written by Marshal.
+type serializedForm struct {
+	Title string           `json:"title,omitempty" yaml:"title,omitempty"`
+	Items []serializedItem `json:"items" yaml:"items"`
+}
+
+// serializedItem is a form item in the document written by Marshal.
+type serializedItem struct {
+	// The item's label and type, e.g. "InputField".
+	Label string `json:"label" yaml:"label"`
+	Type  string `json:"type" yaml:"type"`
+
+	// The item's value, nil if it has none or if it is secret, and for tview
+	// drop-downs, whose options can't be looked up by text, the index of the
+	// current option.
+	Value  any  `json:"value,omitempty" yaml:"value,omitempty"`
+	Option *int `json:"option,omitempty" yaml:"option,omitempty"`
+}
+
+// Marshal serializes the form's title and the labels, types, and current
+// values of its items as JSON, e.g. to persist them between runs or to send
+// them over the wire. Strings, booleans (checkboxes), and lists of strings
+// (multi-select drop-downs, tags fields) are kept as such, other values are
+// written as text like in SetValidator. Values of secret items (see
+// SetItemSecret) are left out. The values can be restored with Unmarshal.
+// MarshalYAML writes the same document as YAML.
+func (f *FormScrollable) Marshal() ([]byte, error) {
+	return json.Marshal(f.serialize())
+}
+
+// MarshalYAML is like Marshal but writes the document as YAML. The values can
+// be restored with UnmarshalYAML.
+func (f *FormScrollable) MarshalYAML() ([]byte, error) {
+	return yaml.Marshal(f.serialize())
+}
+
+// serialize returns the document written by Marshal and MarshalYAML.
+func (f *FormScrollable) serialize() serializedForm {
+	document := serializedForm{
+		Title: f.GetTitle(),
+		Items: make([]serializedItem, 0, len(f.items)),
+	}
+	for _, item := range f.items {
+		serialized := serializedItem{
+			Label: item.GetLabel(),
+			Type:  itemTypeName(item),
+		}
+		if state, ok := f.itemStates[item]; !ok || !state.secret {
+			serialized.Value, serialized.Option = serializeValue(item)
+		}
+		document.Items = append(document.Items, serialized)
+	}
+	return document
+}
+
+// Unmarshal restores the item values of a document written by Marshal. Items
+// are matched by label and type, in order if several items share them. Items
+// of the document which the form doesn't have are ignored, items which the
+// document doesn't have (or has without a value) keep their values. The title
+// is not restored.
+//
+// An error is returned if the document is not valid JSON. Values which can't
+// be restored are skipped and their errors are returned, joined.
+func (f *FormScrollable) Unmarshal(data []byte) error {
+	var document serializedForm
+	if err := json.Unmarshal(data, &document); err != nil {
+		return err
+	}
+	var (
+		errs []error
+		used = make(map[FormItem]bool, len(document.Items))
+	)
+	for _, serialized := range document.Items {
+		if serialized.Value == nil && serialized.Option == nil {
+			continue
+		}
+		for _, item := range f.items {
+			if used[item] || item.GetLabel() != serialized.Label || itemTypeName(item) != serialized.Type {
+				continue
+			}
+			used[item] = true
+			if !f.restoreValue(item, serialized) {
+				errs = append(errs, fmt.Errorf("%s: can't restore value %v", serialized.Label, serialized.Value))
+			}
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UnmarshalYAML is like Unmarshal but reads a document written by
+// MarshalYAML. An error is returned if the document is not valid YAML.
+func (f *FormScrollable) UnmarshalYAML(data []byte) error {
+	converted, err := yamlToJSON(data)
+	if err != nil {
+		return err
+	}
+	return f.Unmarshal(converted)
+}
+
+// itemTypeName returns the name of the type of the given item, without its
+// package, e.g. "InputField".
+func itemTypeName(item FormItem) string {
+	if wrapped, ok := item.(*WrappedItem); ok {
+		item = wrapped.GetItem()
+	}
+	itemType := reflect.TypeOf(item)
+	if itemType.Kind() == reflect.Pointer {
+		itemType = itemType.Elem()
+	}
+	return itemType.Name()
+}
+
+// serializeValue returns the value of the given item for Marshal and, for
+// tview drop-downs, the index of the current option.
+func serializeValue(item FormItem) (any, *int) {
+	value, ok := getItemValue(item)
+	if !ok {
+		return nil, nil
+	}
+	if snapshot, ok := itemSnapshot(item); ok {
+		if dropDown, ok := snapshot.(dropDownSnapshot); ok {
+			index := dropDown.index
+			return dropDown.text, &index
+		}
+	}
+	switch value.(type) {
+	case string, bool, []string:
+		return value, nil
+	}
+	return getItemText(item), nil
+}
+
+// restoreValue sets the value of the given item to the value of the given
+// serialized item. It returns whether the value could be set.
+func (f *FormScrollable) restoreValue(item FormItem, serialized serializedItem) bool {
+	var ok bool
+	switch value := serialized.Value.(type) {
+	case string:
+		if textView, isTextView := item.(*TextView); isTextView {
+			textView.SetText(value)
+			ok = true
+		} else if serialized.Option != nil {
+			ok = setItemValue(item, *serialized.Option)
+		} else {
+			ok = setItemText(item, value)
+		}
+	case bool:
+		ok = setItemText(item, strconv.FormatBool(value))
+	case []any:
+		texts := make([]string, 0, len(value))
+		for _, text := range value {
+			text, isText := text.(string)
+			if !isText {
+				return false
+			}
+			texts = append(texts, text)
+		}
+		ok = setItemValue(item, texts)
+	case nil:
+		ok = setItemValue(item, *serialized.Option)
+	}
+	if state, exists := f.itemStates[item]; ok && exists && state.err != nil {
+		f.validateItem(item)
+	}
+	return ok
+}
@@ -0,0 +1,81 @@
+package form
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	newForm := func() *FormScrollable {
+		return NewFormScrollable().
+			AddInputField("Name", "Jane", 20, nil, nil).
+			AddPasswordField("Password", "secret", 20, '*', nil).
+			AddCheckbox("Agree", true, nil).
+			AddTagsField("Tags", []string{"a", "b"}, nil).
+			SetItemSecret(1, true)
+	}
+	data, err := newForm().Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewFormScrollable().
+		AddInputField("Name", "John", 20, nil, nil).
+		AddPasswordField("Password", "kept", 20, '*', nil).
+		AddCheckbox("Agree", false, nil).
+		AddTagsField("Tags", nil, nil).
+		SetItemSecret(1, true)
+	if err := f.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	for index, expected := range []string{"Jane", "kept", "true", "a, b"} {
+		if text := getItemText(f.GetFormItem(index)); text != expected {
+			t.Errorf("item %d: expected %q, got %q", index, expected, text)
+		}
+	}
+}
+
+func TestMarshalUnmarshalYAML(t *testing.T) {
+	data, err := NewFormScrollable().
+		AddInputField("Name", "Jane", 20, nil, nil).
+		AddInputField("Port", "8080", 6, nil, nil).
+		AddDropDown("Plan", []string{"Free", "Pro"}, 1, nil).
+		AddTagsField("Tags", []string{"a", "b"}, nil).
+		MarshalYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "label: Name") {
+		t.Fatalf("expected a YAML document, got:\n%s", data)
+	}
+
+	f := NewFormScrollable().
+		AddInputField("Name", "", 20, nil, nil).
+		AddInputField("Port", "", 6, nil, nil).
+		AddDropDown("Plan", []string{"Free", "Pro"}, 0, nil).
+		AddTagsField("Tags", nil, nil)
+	if err := f.UnmarshalYAML(data); err != nil {
+		t.Fatal(err)
+	}
+	for index, expected := range []string{"Jane", "8080", "Pro", "a, b"} {
+		if text := getItemText(f.GetFormItem(index)); text != expected {
+			t.Errorf("item %d: expected %q, got %q", index, expected, text)
+		}
+	}
+	if err := f.UnmarshalYAML([]byte("items: [")); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	f := NewFormScrollable().AddCheckbox("Agree", true, nil)
+	if err := f.Unmarshal([]byte("{")); err == nil {
+		t.Error("expected an error for invalid input")
+	}
+	if err := f.Unmarshal([]byte(`{"items":[{"label":"Agree","type":"Checkbox","value":"maybe"}]}`)); err == nil {
+		t.Error("expected an error for a value of the wrong type")
+	}
+	if text := getItemText(f.GetFormItem(0)); text != "true" {
+		t.Errorf("expected the value to be kept, got %q", text)
+	}
+}
@@ -0,0 +1,270 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// settingsCategory is a category of a SettingsView.
+type settingsCategory struct {
+	name string
+	form *FormScrollable
+}
+
+// SettingsView is a preferences dialog: a list of categories on the left and
+// the form of the selected category on the right, above a bar with "Save",
+// "Apply", and "Discard" buttons. Categories whose forms were changed (see
+// FormScrollable.IsDirty) are marked in the list.
+//
+// "Apply" calls the apply function (see SetApplyFunc) with the changed
+// categories, after which the forms' values become their defaults. "Save"
+// does the same and then calls the done function (see SetDoneFunc).
+// "Discard" reverts the changes of all forms (see FormScrollable.Reset) and
+// calls the done function.
+//
+// Enter and Tab in the list move the focus to the form, Tab and Backtab
+// cycle through the form and the buttons, and Escape in the form moves the
+// focus back to the list unless the form has a cancel handler (see
+// FormScrollable.SetCancelFunc).
+type SettingsView struct {
+	*tview.Flex
+
+	// The list of categories, the forms shown one at a time, the button bar,
+	// and its "Apply" button.
+	list        *tview.List
+	pages       *tview.Pages
+	buttons     *FormScrollable
+	applyButton *tview.Button
+
+	// The categories and the index of the current one.
+	categories []*settingsCategory
+	current    int
+
+	// Chains the current form and the button bar into one focus cycle.
+	focus *FocusGroup
+
+	// The marker appended to the names of changed categories.
+	dirtyMarker string
+
+	// Optional functions which apply the changes of the given categories and
+	// which are called when the dialog is closed with "Save" or "Discard".
+	apply func(categories []int) error
+	done  func(saved bool)
+
+	// The delegate of the last call to Focus, used to move the focus.
+	delegate func(p tview.Primitive)
+}
+
+// NewSettingsView returns a new settings dialog without categories.
+func NewSettingsView() *SettingsView {
+	s := &SettingsView{
+		Flex:        tview.NewFlex().SetDirection(tview.FlexRow),
+		list:        tview.NewList().ShowSecondaryText(false),
+		pages:       tview.NewPages(),
+		buttons:     NewFormScrollable(),
+		focus:       NewFocusGroup(),
+		dirtyMarker: " *",
+	}
+	s.list.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		s.SetCurrentCategory(index)
+	})
+	s.list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		s.focusForm()
+	})
+	s.buttons.SetButtonsAlign(tview.AlignRight).
+		SetBorderPadding(0, 0, 1, 1)
+	s.buttons.AddButton("Save", func() { s.close(true) }).
+		AddButton("Apply", func() { s.Apply() }).
+		AddButton("Discard", func() { s.close(false) })
+	s.applyButton = s.buttons.GetButton(1)
+	s.AddItem(tview.NewFlex().
+		AddItem(s.list, 20, 0, true).
+		AddItem(s.pages, 0, 1, false), 0, 1, true).
+		AddItem(s.buttons, 1, 0, false)
+	return s
+}
+
+// AddCategory adds a category with the given name and form to the end of the
+// list.
+func (s *SettingsView) AddCategory(name string, form *FormScrollable) *SettingsView {
+	s.categories = append(s.categories, &settingsCategory{name: name, form: form})
+	s.pages.AddPage(name, form, true, len(s.categories) == 1)
+	s.list.AddItem(name, "", 0, nil)
+	if len(s.categories) == 1 {
+		s.chain(form)
+	}
+	return s
+}
+
+// GetCategoryCount returns the number of categories.
+func (s *SettingsView) GetCategoryCount() int {
+	return len(s.categories)
+}
+
+// GetCategory returns the form of the category at the given index.
+func (s *SettingsView) GetCategory(index int) *FormScrollable {
+	return s.categories[index].form
+}
+
+// GetCurrentCategory returns the index of the selected category.
+func (s *SettingsView) GetCurrentCategory() int {
+	return s.current
+}
+
+// SetCurrentCategory selects the category at the given index.
+func (s *SettingsView) SetCurrentCategory(index int) *SettingsView {
+	if index < 0 || index >= len(s.categories) || index == s.current {
+		return s
+	}
+	s.current = index
+	s.list.SetCurrentItem(index)
+	s.pages.SwitchToPage(s.categories[index].name)
+	s.chain(s.categories[index].form)
+	return s
+}
+
+// SetSidebarWidth sets the screen width of the list of categories. The
+// default is 20.
+func (s *SettingsView) SetSidebarWidth(width int) *SettingsView {
+	s.GetItem(0).(*tview.Flex).ResizeItem(s.list, width, 0)
+	return s
+}
+
+// SetDirtyMarker sets the text appended to the names of changed categories in
+// the list. The default is " *".
+func (s *SettingsView) SetDirtyMarker(marker string) *SettingsView {
+	s.dirtyMarker = marker
+	return s
+}
+
+// SetApplyFunc sets a function which applies the changes of the categories
+// with the given indices. If it returns an error, the changes are kept and
+// the dialog stays open.
+func (s *SettingsView) SetApplyFunc(handler func(categories []int) error) *SettingsView {
+	s.apply = handler
+	return s
+}
+
+// SetDoneFunc sets a function which is called when the dialog is closed,
+// with true after "Save" and false after "Discard".
+func (s *SettingsView) SetDoneFunc(handler func(saved bool)) *SettingsView {
+	s.done = handler
+	return s
+}
+
+// GetButtons returns the form holding the button bar, e.g. to change the
+// buttons' labels or to add buttons.
+func (s *SettingsView) GetButtons() *FormScrollable {
+	return s.buttons
+}
+
+// IsDirty returns whether the form of any category was changed.
+func (s *SettingsView) IsDirty() bool {
+	return len(s.dirtyCategories()) > 0
+}
+
+// Apply calls the apply function with the changed categories. Afterwards,
+// the forms' values become their defaults. An error returned by the apply
+// function is returned.
+func (s *SettingsView) Apply() error {
+	categories := s.dirtyCategories()
+	if len(categories) == 0 {
+		return nil
+	}
+	if s.apply != nil {
+		if err := s.apply(categories); err != nil {
+			return err
+		}
+	}
+	for _, index := range categories {
+		s.categories[index].form.CaptureDefaults()
+	}
+	return nil
+}
+
+// dirtyCategories returns the indices of the categories whose forms were
+// changed.
+func (s *SettingsView) dirtyCategories() []int {
+	var indices []int
+	for index, category := range s.categories {
+		if category.form.IsDirty() {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// close applies ("Save") or discards the changes and calls the done function.
+func (s *SettingsView) close(save bool) {
+	if save {
+		if s.Apply() != nil {
+			return
+		}
+	} else {
+		for _, category := range s.categories {
+			category.form.Reset()
+		}
+	}
+	if s.done != nil {
+		s.done(save)
+	}
+}
+
+// chain makes the given form and the button bar one focus cycle.
+func (s *SettingsView) chain(form *FormScrollable) {
+	for _, f := range s.focus.GetForms() {
+		s.focus.RemoveForm(f)
+	}
+	s.focus.AddForm(form).AddForm(s.buttons)
+}
+
+// focusForm moves the focus to the current category's form.
+func (s *SettingsView) focusForm() {
+	if s.delegate != nil && len(s.categories) > 0 {
+		s.delegate(s.categories[s.current].form)
+	}
+}
+
+// Draw draws the dialog. The names of changed categories are marked and the
+// "Apply" button is only enabled if there are changes.
+func (s *SettingsView) Draw(screen tcell.Screen) {
+	dirty := false
+	for index, category := range s.categories {
+		name := category.name
+		if category.form.IsDirty() {
+			name += s.dirtyMarker
+			dirty = true
+		}
+		if main, _ := s.list.GetItemText(index); main != name {
+			s.list.SetItemText(index, name, "")
+		}
+	}
+	s.applyButton.SetDisabled(!dirty)
+	s.Flex.Draw(screen)
+}
+
+// Focus is called when this primitive receives focus.
+func (s *SettingsView) Focus(delegate func(p tview.Primitive)) {
+	s.delegate = delegate
+	s.Flex.Focus(delegate)
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *SettingsView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if len(s.categories) > 0 {
+			form := s.categories[s.current].form
+			switch {
+			case event.Key() == tcell.KeyTab && s.list.HasFocus():
+				s.focusForm()
+				return
+			case event.Key() == tcell.KeyEscape && (form.HasFocus() && form.cancel == nil && form.popup == nil || s.buttons.HasFocus()):
+				setFocus(s.list)
+				return
+			}
+		}
+		if handler := s.Flex.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package form
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// SetItemShortcut sets the letter with which the user jumps to the form
+// element with the given index, counting form items first and buttons last,
+// while the form has focus: pressing the letter with the shortcut modifier
+// (Alt by default, see SetShortcutModifier) moves the focus to a form item or
+// presses a button. The letter is matched regardless of case. Its first
+// occurrence in the element's label is underlined. A letter of 0 removes the
+// shortcut. Disabled and hidden elements ignore their shortcuts.
+func (f *FormScrollable) SetItemShortcut(index int, r rune) *FormScrollable {
+	if index < len(f.items) {
+		f.state(f.items[index]).shortcut = r
+		return f
+	}
+	button := f.buttons[index-len(f.items)]
+	if r == 0 {
+		delete(f.buttonShortcuts, button)
+	} else {
+		f.buttonShortcuts[button] = r
+	}
+	return f
+}
+
+// SetShortcutModifier sets the modifier keys which must be held with the
+// letters of shortcuts (see SetItemShortcut), e.g. tcell.ModCtrl. The default
+// is tcell.ModAlt.
+func (f *FormScrollable) SetShortcutModifier(modifiers tcell.ModMask) *FormScrollable {
+	f.shortcutModifier = modifiers
+	return f
+}
+
+// handleShortcut focuses the form item or presses the button whose shortcut
+// matches the given event. It returns whether there was such an element.
+func (f *FormScrollable) handleShortcut(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	r := event.Rune()
+	modifiers := event.Modifiers()
+	switch key := event.Key(); {
+	case key >= tcell.KeyCtrlA && key <= tcell.KeyCtrlZ:
+		// Terminals report Ctrl+letter as control keys.
+		r = 'a' + rune(key-tcell.KeyCtrlA)
+		modifiers |= tcell.ModCtrl
+	case key != tcell.KeyRune:
+		return false
+	}
+	if modifiers != f.shortcutModifier || f.shortcutModifier == tcell.ModNone {
+		return false
+	}
+	for index, item := range f.items {
+		state, ok := f.itemStates[item]
+		if !ok || !sameLetter(state.shortcut, r) || f.IsItemDisabled(index) || !f.IsItemVisible(index) {
+			continue
+		}
+		f.focusedElement = index
+		f.Focus(setFocus)
+		return true
+	}
+	for _, button := range f.buttons {
+		if !sameLetter(f.buttonShortcuts[button], r) || button.IsDisabled() {
+			continue
+		}
+		button.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+		return true
+	}
+	return false
+}
+
+// sameLetter returns whether the given shortcut letter matches the given
+// letter, regardless of case.
+func sameLetter(shortcut, r rune) bool {
+	return shortcut != 0 && unicode.ToLower(shortcut) == unicode.ToLower(r)
+}
+
+// itemShortcut returns the shortcut letter of the given item, 0 if it has
+// none.
+func (f *FormScrollable) itemShortcut(item FormItem) rune {
+	if state, ok := f.itemStates[item]; ok {
+		return state.shortcut
+	}
+	return 0
+}
+
+// withShortcutLabel calls the given function, which draws the given button,
+// with the shortcut letter underlined in the button's label.
+func (f *FormScrollable) withShortcutLabel(button *Button, do func()) {
+	if r, ok := f.buttonShortcuts[button]; ok {
+		label := button.GetLabel()
+		button.SetLabel(underlineShortcut(label, r))
+		defer button.SetLabel(label)
+	}
+	do()
+}
+
+// underlineShortcut returns the given label (which may contain style tags)
+// with the first occurrence of the given shortcut letter underlined. Style
+// tags are skipped.
+func underlineShortcut(label string, shortcut rune) string {
+	if shortcut == 0 {
+		return label
+	}
+	for index := 0; index < len(label); {
+		r, size := utf8.DecodeRuneInString(label[index:])
+		if r == '[' {
+			if closing := strings.IndexByte(label[index:], ']'); closing > 0 && TaggedStringWidth(label[index:index+closing+1]) == 0 {
+				index += closing + 1
+				continue
+			}
+		}
+		if sameLetter(shortcut, r) {
+			return label[:index] + "[::u]" + label[index:index+size] + "[::U]" + label[index+size:]
+		}
+		index += size
+	}
+	return label
+}
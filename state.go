@@ -0,0 +1,59 @@
+package form
+
+import . "github.com/rivo/tview"
+
+// FormState is a snapshot of a form's item values, focus, and scroll position
+// (see GetState) which can be restored later (see SetState), e.g. when the
+// user returns to the form.
+type FormState struct {
+	// The index of the focused element, counting items first and buttons last
+	// (see SetFocus).
+	Focus int
+
+	// The number of rows the form was scrolled down (see GetScrollOffset).
+	ScrollOffset int
+
+	// The values of the items which have one, nil if the state doesn't
+	// include them.
+	values map[FormItem]any
+}
+
+// GetState returns the form's current state: the values of its items, the
+// focused element, and the scroll position.
+func (f *FormScrollable) GetState() FormState {
+	state := FormState{
+		Focus:        f.focusedElement,
+		ScrollOffset: f.GetScrollOffset(),
+		values:       make(map[FormItem]any),
+	}
+	if index := f.focusIndex(); index >= 0 {
+		state.Focus = index
+	}
+	for _, item := range f.items {
+		if value, ok := itemSnapshot(item); ok {
+			state.values[item] = value
+		}
+	}
+	return state
+}
+
+// SetState restores a state returned by GetState. Values of items which were
+// removed since are ignored, items which were added since keep theirs. The
+// scroll position is kept until the user moves the focus (see ScrollTo).
+func (f *FormScrollable) SetState(state FormState) *FormScrollable {
+	for _, item := range f.items {
+		if value, ok := state.values[item]; ok {
+			f.applyChange(item, value)
+		}
+	}
+	f.SetFocus(state.Focus)
+	f.ScrollTo(state.ScrollOffset)
+	return f
+}
+
+// viewState returns the given state without the items' values, so that only
+// the focus and the scroll position are restored.
+func (s FormState) viewState() FormState {
+	s.values = nil
+	return s
+}
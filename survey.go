@@ -0,0 +1,375 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// surveyQuestion is a question of a SurveyRunner.
+type surveyQuestion struct {
+	id   string
+	form *FormScrollable
+
+	// The question's navigation buttons.
+	back, next *tview.Button
+
+	// An optional function which returns whether the question is asked,
+	// given the answers to the questions asked before.
+	condition func(answers map[string]any) bool
+}
+
+// SurveyRunner presents a questionnaire one question at a time, e.g. for
+// interactive setup or on-boarding flows. Each question is a form whose items
+// are defined like those of FormScrollable.Rebuild, usually a single item.
+// Questions are asked in the order they were added, skipping those whose
+// condition (see SetQuestionCondition) rejects the answers given so far, so
+// that later questions may branch on earlier answers. A progress line above
+// the question shows how many questions were asked and how many remain.
+//
+// Each question's form gets a "Back" and a "Next" button ("Finish" on the
+// last question). The user can only move on if the question's form
+// validates (see FormScrollable.Validate). Enter in a single-line input field
+// moves on, too. Going back keeps the answers.
+type SurveyRunner struct {
+	*tview.Box
+
+	// The questions, the index of the current one (-1 before the survey
+	// started), and the indices of the questions asked before it.
+	questions []*surveyQuestion
+	current   int
+	history   []int
+
+	// The labels of the navigation buttons.
+	backLabel, nextLabel, finishLabel string
+
+	// The delegate of the last call to Focus, used to focus a new question.
+	delegate func(p tview.Primitive)
+
+	// The colors of the progress line's text and of its bar.
+	progressColor, barColor tcell.Color
+
+	// Optional functions which are called when the user moved to another
+	// question and when the user finished the last question.
+	changed  func(id string)
+	finished func(answers map[string]any)
+}
+
+// NewSurveyRunner returns a new survey without questions.
+func NewSurveyRunner() *SurveyRunner {
+	return &SurveyRunner{
+		Box:           tview.NewBox(),
+		current:       -1,
+		backLabel:     "Back",
+		nextLabel:     "Next",
+		finishLabel:   "Finish",
+		progressColor: tview.Styles.SecondaryTextColor,
+		barColor:      tview.Styles.TertiaryTextColor,
+	}
+}
+
+// AddQuestion adds a question with the given ID to the end of the survey. Its
+// form items are defined by the given function (see FormScrollable.Rebuild).
+// The question's answer is the value of its item or, if it has several, a
+// map of their values keyed by their labels (see
+// FormScrollable.GetFormValues).
+func (s *SurveyRunner) AddQuestion(id string, build func(b *Builder)) *SurveyRunner {
+	question := &surveyQuestion{id: id, form: NewFormScrollable().Rebuild(build)}
+	question.back = tview.NewButton(s.backLabel).SetSelectedFunc(func() { s.Back() })
+	question.next = tview.NewButton(s.nextLabel).SetSelectedFunc(func() { s.Next() })
+	question.form.buttons = append(question.form.buttons, question.back, question.next)
+	question.form.SetSubmitFunc(func() { s.Next() })
+	s.questions = append(s.questions, question)
+	return s
+}
+
+// SetQuestionCondition sets a function which returns whether the question
+// with the given ID is asked, given the answers to the questions asked before
+// it (see GetAnswers). Questions without a condition are always asked.
+func (s *SurveyRunner) SetQuestionCondition(id string, condition func(answers map[string]any) bool) *SurveyRunner {
+	if question := s.question(id); question != nil {
+		question.condition = condition
+	}
+	return s
+}
+
+// GetQuestion returns the form of the question with the given ID, e.g. to add
+// validators, or nil if there is no such question.
+func (s *SurveyRunner) GetQuestion(id string) *FormScrollable {
+	if question := s.question(id); question != nil {
+		return question.form
+	}
+	return nil
+}
+
+// question returns the question with the given ID or nil.
+func (s *SurveyRunner) question(id string) *surveyQuestion {
+	for _, question := range s.questions {
+		if question.id == id {
+			return question
+		}
+	}
+	return nil
+}
+
+// SetButtonLabels sets the labels of the navigation buttons of all questions,
+// "Back", "Next", and "Finish" by default.
+func (s *SurveyRunner) SetButtonLabels(back, next, finish string) *SurveyRunner {
+	s.backLabel, s.nextLabel, s.finishLabel = back, next, finish
+	for _, question := range s.questions {
+		question.back.SetLabel(back)
+		question.next.SetLabel(next)
+	}
+	return s
+}
+
+// SetColors sets the colors of the progress line's text and of its bar.
+func (s *SurveyRunner) SetColors(progress, bar tcell.Color) *SurveyRunner {
+	s.progressColor, s.barColor = progress, bar
+	return s
+}
+
+// SetChangedFunc sets a handler which is called with the ID of the new
+// current question when the user moved to another question.
+func (s *SurveyRunner) SetChangedFunc(handler func(id string)) *SurveyRunner {
+	s.changed = handler
+	return s
+}
+
+// SetFinishedFunc sets a handler which is called with the answers (see
+// GetAnswers) when the user finished the last question.
+func (s *SurveyRunner) SetFinishedFunc(handler func(answers map[string]any)) *SurveyRunner {
+	s.finished = handler
+	return s
+}
+
+// GetCurrentQuestion returns the ID of the current question or an empty
+// string if there is none.
+func (s *SurveyRunner) GetCurrentQuestion() string {
+	s.begin()
+	if s.current < 0 {
+		return ""
+	}
+	return s.questions[s.current].id
+}
+
+// GetAnswers returns the answers to the questions asked so far, including the
+// current one, keyed by the questions' IDs. Questions which were skipped or
+// which the user went back from are not included.
+func (s *SurveyRunner) GetAnswers() map[string]any {
+	s.begin()
+	answers := make(map[string]any)
+	for _, index := range s.history {
+		s.questions[index].answer(answers)
+	}
+	if s.current >= 0 {
+		s.questions[s.current].answer(answers)
+	}
+	return answers
+}
+
+// answer adds the question's answer to the given answers.
+func (q *surveyQuestion) answer(answers map[string]any) {
+	if q.form.GetFormItemCount() == 1 {
+		if value, ok := getItemValue(q.form.GetFormItem(0)); ok {
+			answers[q.id] = value
+		}
+		return
+	}
+	answers[q.id] = q.form.GetFormValues()
+}
+
+// Restart goes back to the first question. The answers are kept.
+func (s *SurveyRunner) Restart() *SurveyRunner {
+	s.history = nil
+	s.show(s.following(-1, map[string]any{}))
+	return s
+}
+
+// Next moves on to the next question or, on the last one, calls the
+// "finished" handler, provided that the current question's form validates.
+// Otherwise, the focus moves to the first invalid item. Returns whether the
+// current question was accepted.
+func (s *SurveyRunner) Next() bool {
+	s.begin()
+	if s.current < 0 {
+		return false
+	}
+	form := s.questions[s.current].form
+	if errs := form.Validate(); len(errs) > 0 {
+		var validationErr *ValidationError
+		if errors.As(errs[0], &validationErr) {
+			form.SetFocus(validationErr.Index)
+			if s.delegate != nil && s.HasFocus() {
+				s.delegate(form)
+			}
+		}
+		return false
+	}
+	answers := s.GetAnswers()
+	next := s.following(s.current, answers)
+	if next < 0 {
+		if s.finished != nil {
+			s.finished(answers)
+		}
+		return true
+	}
+	s.history = append(s.history, s.current)
+	s.show(next)
+	return true
+}
+
+// Back moves to the previous question. Returns false on the first question.
+func (s *SurveyRunner) Back() bool {
+	if len(s.history) == 0 {
+		return false
+	}
+	previous := s.history[len(s.history)-1]
+	s.history = s.history[:len(s.history)-1]
+	s.show(previous)
+	return true
+}
+
+// begin shows the first question which is asked if the survey hasn't started
+// yet.
+func (s *SurveyRunner) begin() {
+	if s.current < 0 && len(s.history) == 0 {
+		s.current = s.following(-1, map[string]any{})
+	}
+}
+
+// following returns the index of the first question after the one at the
+// given index which is asked, given the answers, or -1 if there is none.
+func (s *SurveyRunner) following(index int, answers map[string]any) int {
+	for index++; index < len(s.questions); index++ {
+		if condition := s.questions[index].condition; condition == nil || condition(answers) {
+			return index
+		}
+	}
+	return -1
+}
+
+// remaining returns the number of questions after the current one which are
+// asked, given the current answers. As conditions may depend on answers which
+// weren't given yet, the number is an estimate.
+func (s *SurveyRunner) remaining() int {
+	answers := s.GetAnswers()
+	var count int
+	for index := s.following(s.current, answers); index >= 0; index = s.following(index, answers) {
+		count++
+	}
+	return count
+}
+
+// show makes the question at the given index the current question.
+func (s *SurveyRunner) show(index int) {
+	if index == s.current {
+		return
+	}
+	hadFocus := s.HasFocus()
+	s.current = index
+	if index < 0 {
+		return
+	}
+	if hadFocus && s.delegate != nil {
+		s.delegate(s.questions[index].form)
+	}
+	if s.changed != nil {
+		s.changed(s.questions[index].id)
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (s *SurveyRunner) Draw(screen tcell.Screen) {
+	s.Box.DrawForSubclass(screen, s)
+	s.begin()
+	x, y, width, height := s.GetInnerRect()
+	if s.current < 0 || height <= 0 {
+		return
+	}
+	question := s.questions[s.current]
+
+	// Update the navigation buttons.
+	remaining := s.remaining()
+	question.back.SetDisabled(len(s.history) == 0)
+	if remaining == 0 {
+		question.next.SetLabel(s.finishLabel)
+	} else {
+		question.next.SetLabel(s.nextLabel)
+	}
+
+	// Draw the progress line.
+	asked := len(s.history) + 1
+	total := asked + remaining
+	text := fmt.Sprintf("Question %d of %d ", asked, total)
+	_, printed := tview.Print(screen, text, x, y, width, tview.AlignLeft, s.progressColor)
+	if bar := width - printed; bar > 0 {
+		done := bar * asked / total
+		tview.Print(screen, strings.Repeat("█", done)+strings.Repeat("░", bar-done), x+printed, y, bar, tview.AlignLeft, s.barColor)
+	}
+
+	// Draw the current question.
+	question.form.SetRect(x, y+1, width, max(height-1, 0))
+	question.form.Draw(screen)
+}
+
+// Focus is called when this primitive receives focus.
+func (s *SurveyRunner) Focus(delegate func(p tview.Primitive)) {
+	s.delegate = delegate
+	s.begin()
+	if s.current < 0 {
+		s.Box.Focus(delegate)
+		return
+	}
+	delegate(s.questions[s.current].form)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (s *SurveyRunner) HasFocus() bool {
+	if s.current >= 0 && s.questions[s.current].form.HasFocus() {
+		return true
+	}
+	return s.Box.HasFocus()
+}
+
+// InputHandler returns the handler for this primitive.
+func (s *SurveyRunner) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if s.current < 0 {
+			return
+		}
+		if handler := s.questions[s.current].form.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (s *SurveyRunner) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return s.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		if s.current < 0 {
+			return
+		}
+		if handler := s.questions[s.current].form.PasteHandler(); handler != nil {
+			handler(pastedText, setFocus)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (s *SurveyRunner) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return s.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if s.current < 0 || !s.InRect(event.Position()) {
+			return false, nil
+		}
+		consumed, capture = s.questions[s.current].form.MouseHandler()(action, event, setFocus)
+		if !consumed && action == tview.MouseLeftDown {
+			setFocus(s)
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,280 @@
+package form
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// formTab is a tab of a TabbedFormScrollable.
+type formTab struct {
+	name string
+	form *FormScrollable
+}
+
+// TabbedFormScrollable groups form items into named tabs, e.g. for long
+// settings dialogs. Each tab is a form of its own and the names of all tabs
+// are shown in a row along the top. Only the current tab's form is shown, and
+// Tab and Backtab cycle through its elements. Ctrl+PgUp and Ctrl+PgDn switch
+// to the previous and next tab, as does clicking a tab's name.
+type TabbedFormScrollable struct {
+	*tview.Box
+
+	// The tabs and the index of the current one.
+	tabs    []*formTab
+	current int
+
+	// The delegate of the last call to Focus, used to focus a new tab.
+	delegate func(p tview.Primitive)
+
+	// The styles of the current tab's name and the other tabs' names.
+	currentStyle, otherStyle tcell.Style
+
+	// An optional function which is called when the user switched tabs.
+	changed func(index int)
+}
+
+// NewTabbedFormScrollable returns a new tabbed form without tabs.
+func NewTabbedFormScrollable() *TabbedFormScrollable {
+	return &TabbedFormScrollable{
+		Box: tview.NewBox(),
+		currentStyle: tcell.StyleDefault.
+			Foreground(tview.Styles.PrimitiveBackgroundColor).
+			Background(tview.Styles.PrimaryTextColor),
+		otherStyle: tcell.StyleDefault.
+			Foreground(tview.Styles.PrimaryTextColor).
+			Background(tview.Styles.ContrastBackgroundColor),
+	}
+}
+
+// AddTab adds a tab with the given name and form to the end of the tabs.
+func (t *TabbedFormScrollable) AddTab(name string, form *FormScrollable) *TabbedFormScrollable {
+	t.tabs = append(t.tabs, &formTab{name: name, form: form})
+	form.tabbed = true
+	return t
+}
+
+// GetTabCount returns the number of tabs.
+func (t *TabbedFormScrollable) GetTabCount() int {
+	return len(t.tabs)
+}
+
+// GetTab returns the form of the tab at the given index.
+func (t *TabbedFormScrollable) GetTab(index int) *FormScrollable {
+	return t.tabs[index].form
+}
+
+// GetCurrentTab returns the index of the current tab.
+func (t *TabbedFormScrollable) GetCurrentTab() int {
+	return t.current
+}
+
+// SetCurrentTab makes the tab at the given index the current tab.
+func (t *TabbedFormScrollable) SetCurrentTab(index int) *TabbedFormScrollable {
+	if index < 0 || index >= len(t.tabs) || index == t.current {
+		return t
+	}
+	hadFocus := t.HasFocus()
+	t.current = index
+	if hadFocus && t.delegate != nil {
+		t.delegate(t.tabs[index].form)
+	}
+	if t.changed != nil {
+		t.changed(index)
+	}
+	return t
+}
+
+// SetTabStyles sets the styles of the current tab's name and of the other
+// tabs' names.
+func (t *TabbedFormScrollable) SetTabStyles(current, other tcell.Style) *TabbedFormScrollable {
+	t.currentStyle, t.otherStyle = current, other
+	return t
+}
+
+// SetChangedFunc sets a handler which is called with the index of the new
+// current tab when the user switched tabs.
+func (t *TabbedFormScrollable) SetChangedFunc(handler func(index int)) *TabbedFormScrollable {
+	t.changed = handler
+	return t
+}
+
+// GetFormValues returns the values of the items of all tabs, keyed by their
+// labels (see FormScrollable.GetFormValues). If items of several tabs share a
+// label, the value of the last one is returned.
+func (t *TabbedFormScrollable) GetFormValues() map[string]any {
+	values := make(map[string]any)
+	for _, tab := range t.tabs {
+		for label, value := range tab.form.GetFormValues() {
+			values[label] = value
+		}
+	}
+	return values
+}
+
+// Validate validates the forms of all tabs (see FormScrollable.Validate) and
+// returns the errors of all invalid items. If there are any, the tab of the
+// first invalid item becomes the current tab and the item receives focus.
+func (t *TabbedFormScrollable) Validate() []error {
+	var errs []error
+	first := -1
+	for index, tab := range t.tabs {
+		tabErrs := tab.form.Validate()
+		if len(tabErrs) > 0 && first < 0 {
+			first = index
+			var validationErr *ValidationError
+			if errors.As(tabErrs[0], &validationErr) {
+				tab.form.SetFocus(validationErr.Index)
+			}
+		}
+		errs = append(errs, tabErrs...)
+	}
+	if first >= 0 {
+		hadFocus := t.HasFocus()
+		t.SetCurrentTab(first)
+		if hadFocus && t.delegate != nil {
+			t.delegate(t.tabs[first].form)
+		}
+	}
+	return errs
+}
+
+// tabNameWidth returns the screen width of the given tab's name as shown in
+// the row of tabs.
+func tabNameWidth(tab *formTab) int {
+	return tview.TaggedStringWidth(tview.Escape(tab.name)) + 2
+}
+
+// Draw draws this primitive onto the screen.
+func (t *TabbedFormScrollable) Draw(screen tcell.Screen) {
+	t.Box.DrawForSubclass(screen, t)
+	x, y, width, height := t.GetInnerRect()
+	if len(t.tabs) == 0 || height <= 0 {
+		return
+	}
+
+	// Draw the names of the tabs, separated by a space.
+	column := x
+	for index, tab := range t.tabs {
+		if column >= x+width {
+			break
+		}
+		style := t.otherStyle
+		if index == t.current {
+			style = t.currentStyle
+		}
+		nameWidth := min(tabNameWidth(tab), x+width-column)
+		for cell := column; cell < column+nameWidth; cell++ {
+			screen.SetContent(cell, y, ' ', nil, style)
+		}
+		printStyled(screen, tview.Escape(tab.name), column+1, y, nameWidth-1, tview.AlignLeft, style)
+		column += nameWidth + 1
+	}
+
+	// Draw the current tab.
+	form := t.tabs[t.current].form
+	form.SetRect(x, y+1, width, max(height-1, 0))
+	form.Draw(screen)
+}
+
+// tabAt returns the index of the tab whose name is at the given screen
+// position or -1 if there is none.
+func (t *TabbedFormScrollable) tabAt(atX, atY int) int {
+	x, y, _, _ := t.GetInnerRect()
+	if atY != y {
+		return -1
+	}
+	column := x
+	for index, tab := range t.tabs {
+		nameWidth := tabNameWidth(tab)
+		if atX >= column && atX < column+nameWidth {
+			return index
+		}
+		column += nameWidth + 1
+	}
+	return -1
+}
+
+// Focus is called when this primitive receives focus.
+func (t *TabbedFormScrollable) Focus(delegate func(p tview.Primitive)) {
+	t.delegate = delegate
+	if len(t.tabs) == 0 {
+		t.Box.Focus(delegate)
+		return
+	}
+	delegate(t.tabs[t.current].form)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (t *TabbedFormScrollable) HasFocus() bool {
+	if len(t.tabs) > 0 && t.tabs[t.current].form.HasFocus() {
+		return true
+	}
+	return t.Box.HasFocus()
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *TabbedFormScrollable) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if len(t.tabs) == 0 {
+			return
+		}
+
+		// Ctrl+PgUp/PgDn switch tabs.
+		if event.Modifiers()&tcell.ModCtrl != 0 {
+			switch event.Key() {
+			case tcell.KeyPgUp:
+				t.delegate = setFocus
+				t.SetCurrentTab((t.current + len(t.tabs) - 1) % len(t.tabs))
+				return
+			case tcell.KeyPgDn:
+				t.delegate = setFocus
+				t.SetCurrentTab((t.current + 1) % len(t.tabs))
+				return
+			}
+		}
+
+		if handler := t.tabs[t.current].form.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (t *TabbedFormScrollable) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return t.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		if len(t.tabs) == 0 {
+			return
+		}
+		if handler := t.tabs[t.current].form.PasteHandler(); handler != nil {
+			handler(pastedText, setFocus)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *TabbedFormScrollable) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return t.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if len(t.tabs) == 0 || !t.InRect(event.Position()) {
+			return false, nil
+		}
+
+		// Clicking a tab's name switches to it.
+		if index := t.tabAt(event.Position()); index >= 0 {
+			if action == tview.MouseLeftClick {
+				t.delegate = setFocus
+				t.SetCurrentTab(index)
+				setFocus(t.tabs[index].form)
+			}
+			return true, nil
+		}
+
+		consumed, capture = t.tabs[t.current].form.MouseHandler()(action, event, setFocus)
+		if !consumed && action == tview.MouseLeftDown {
+			setFocus(t)
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,449 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TagsField is a form item for a list of tags ("chips"). Enter adds the typed
+// text as a tag, Backspace in an empty field removes the last tag, and a click
+// on the "✕" of a tag removes it. Text which was typed but not added yet is
+// added when the item loses focus. Tags are suggested from a list (see
+// SetSuggestions) while typing, Down shows all suggestions.
+type TagsField struct {
+	*tview.Box
+
+	// The field the next tag is typed into.
+	input *tview.InputField
+
+	// The tags, in the order they were added.
+	tags []string
+
+	// The suggested tags and whether all of them are suggested, regardless of
+	// the text. The latter is set while Down is processed.
+	suggestions []string
+	showAll     bool
+
+	// Whether the suggestions are shown.
+	list suggestionList
+
+	// The label, its width (0 means the width of the label text), and the
+	// width of the field (0 means all available space).
+	label      string
+	labelWidth int
+	fieldWidth int
+
+	// Colors.
+	labelColor           tcell.Color
+	fieldBackgroundColor tcell.Color
+	tagStyle             tcell.Style
+
+	// The screen columns of the "✕" of the drawn tags, mapped to the index of
+	// the tag.
+	removeColumns map[int]int
+
+	// Whether the item is disabled.
+	disabled bool
+
+	// An optional function which is called when the tags changed.
+	changed func(tags []string)
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var (
+	_ tview.FormItem  = (*TagsField)(nil)
+	_ ItemValuer      = (*TagsField)(nil)
+	_ ItemValueSetter = (*TagsField)(nil)
+)
+
+// NewTagsField returns a new, empty tags field.
+func NewTagsField() *TagsField {
+	t := &TagsField{
+		Box:                  tview.NewBox(),
+		input:                tview.NewInputField(),
+		labelColor:           tview.Styles.SecondaryTextColor,
+		fieldBackgroundColor: tview.Styles.ContrastBackgroundColor,
+		tagStyle: tcell.StyleDefault.
+			Background(tview.Styles.MoreContrastBackgroundColor).
+			Foreground(tview.Styles.PrimaryTextColor),
+	}
+	t.input.SetAutocompleteFunc(t.suggest)
+	t.input.SetAutocompletedFunc(func(text string, index, source int) bool {
+		if source == tview.AutocompletedNavigate {
+			t.input.SetText(text)
+			return false
+		}
+		t.addTag(text)
+		t.input.SetText("")
+		return true
+	})
+	t.input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter && strings.TrimSpace(t.input.GetText()) != "" {
+			t.commitTyped()
+			return
+		}
+		if key != tcell.KeyEscape {
+			t.commitTyped()
+		}
+		if t.finished != nil {
+			t.finished(key)
+		}
+	})
+	return t
+}
+
+// SetLabel sets the text to be displayed before the field.
+func (t *TagsField) SetLabel(label string) *TagsField {
+	t.label = label
+	return t
+}
+
+// GetLabel returns the text to be displayed before the field.
+func (t *TagsField) GetLabel() string {
+	return t.label
+}
+
+// SetFieldWidth sets the screen width of the field. A value of 0 means the
+// field takes all available space.
+func (t *TagsField) SetFieldWidth(width int) *TagsField {
+	t.fieldWidth = width
+	return t
+}
+
+// SetTagStyle sets the style of the tags.
+func (t *TagsField) SetTagStyle(style tcell.Style) *TagsField {
+	t.tagStyle = style
+	return t
+}
+
+// SetTags replaces the tags. Empty and duplicate tags are dropped.
+func (t *TagsField) SetTags(tags []string) *TagsField {
+	var cleaned []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !containsString(cleaned, tag) {
+			cleaned = append(cleaned, tag)
+		}
+	}
+	t.setTags(cleaned)
+	return t
+}
+
+// GetTags returns a copy of the tags.
+func (t *TagsField) GetTags() []string {
+	return append([]string(nil), t.tags...)
+}
+
+// GetValue returns the tags as a []string (see GetTags).
+func (t *TagsField) GetValue() any {
+	return t.GetTags()
+}
+
+// SetValue sets the tags from a []string (see SetTags). Other values are
+// ignored.
+func (t *TagsField) SetValue(value any) {
+	if tags, ok := value.([]string); ok {
+		t.SetTags(tags)
+	}
+}
+
+// SetSuggestions sets the tags which are suggested while typing. Tags which
+// were already added are not suggested.
+func (t *TagsField) SetSuggestions(suggestions []string) *TagsField {
+	t.suggestions = suggestions
+	return t
+}
+
+// SetChangedFunc sets a handler which is called with the tags when they
+// changed.
+func (t *TagsField) SetChangedFunc(handler func(tags []string)) *TagsField {
+	t.changed = handler
+	return t
+}
+
+// setTags sets the tags and calls the "changed" handler if they changed.
+func (t *TagsField) setTags(tags []string) {
+	if len(tags) == len(t.tags) {
+		equal := true
+		for index, tag := range tags {
+			equal = equal && tag == t.tags[index]
+		}
+		if equal {
+			return
+		}
+	}
+	t.tags = tags
+	if t.changed != nil {
+		t.changed(t.GetTags())
+	}
+}
+
+// addTag appends the given tag unless it is empty or already present.
+func (t *TagsField) addTag(tag string) {
+	if tag = strings.TrimSpace(tag); tag != "" && !containsString(t.tags, tag) {
+		t.setTags(append(t.GetTags(), tag))
+	}
+}
+
+// removeTag removes the tag at the given index.
+func (t *TagsField) removeTag(index int) {
+	tags := t.GetTags()
+	t.setTags(append(tags[:index], tags[index+1:]...))
+}
+
+// commitTyped adds the typed text as a tag and clears the input.
+func (t *TagsField) commitTyped() {
+	if text := t.input.GetText(); text != "" {
+		t.addTag(text)
+		t.input.SetText("")
+	}
+}
+
+// suggest returns the suggestions containing the given text (ignoring case)
+// which are not tags yet. All of them are returned if requested with Down,
+// none for an empty text otherwise.
+func (t *TagsField) suggest(text string) []string {
+	if text == "" && !t.showAll {
+		return t.list.suggested(nil)
+	}
+	var entries []string
+	lower := strings.ToLower(text)
+	for _, suggestion := range t.suggestions {
+		if containsString(t.tags, suggestion) {
+			continue
+		}
+		if t.showAll || strings.Contains(strings.ToLower(suggestion), lower) {
+			entries = append(entries, tview.Escape(suggestion))
+		}
+	}
+	return t.list.suggested(entries)
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (t *TagsField) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	t.labelWidth = labelWidth
+	t.labelColor = labelColor
+	t.SetBackgroundColor(bgColor)
+	t.fieldBackgroundColor = fieldBgColor
+	t.input.SetFieldTextColor(fieldTextColor).
+		SetFieldBackgroundColor(fieldBgColor).
+		SetBackgroundColor(bgColor)
+	return t
+}
+
+// GetFieldWidth returns the screen width of the field. A value of 0 means the
+// field takes all available space.
+func (t *TagsField) GetFieldWidth() int {
+	return t.fieldWidth
+}
+
+// GetFieldHeight returns the height of the field.
+func (t *TagsField) GetFieldHeight() int {
+	return 1
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (t *TagsField) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	t.finished = handler
+	return t
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (t *TagsField) SetDisabled(disabled bool) tview.FormItem {
+	t.disabled = disabled
+	t.input.SetDisabled(disabled)
+	if t.finished != nil {
+		t.finished(-1)
+	}
+	return t
+}
+
+// Draw draws this primitive onto the screen.
+func (t *TagsField) Draw(screen tcell.Screen) {
+	t.Box.DrawForSubclass(screen, t)
+
+	x, y, width, height := t.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if t.labelWidth > 0 {
+		labelWidth := min(t.labelWidth, rightLimit-x)
+		tview.Print(screen, t.label, x, y, labelWidth, tview.AlignLeft, t.labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := tview.Print(screen, t.label, x, y, rightLimit-x, tview.AlignLeft, t.labelColor)
+		x += drawnWidth
+	}
+	if t.fieldWidth > 0 {
+		rightLimit = min(rightLimit, x+t.fieldWidth)
+	}
+	if rightLimit <= x {
+		return
+	}
+
+	// Draw field background.
+	fieldStyle := tcell.StyleDefault.Background(t.fieldBackgroundColor)
+	if t.disabled {
+		fieldStyle = fieldStyle.Background(t.GetBackgroundColor())
+	}
+	for column := x; column < rightLimit; column++ {
+		screen.SetContent(column, y, ' ', nil, fieldStyle)
+	}
+
+	// Determine the tags which fit, keeping space for typing. Leading tags
+	// are dropped first.
+	const minInputWidth = 8
+	widths := make([]int, len(t.tags))
+	total := 0
+	for index, tag := range t.tags {
+		widths[index] = tview.TaggedStringWidth(tview.Escape(tag)) + 4 // " tag ✕" plus a space.
+		total += widths[index]
+	}
+	first := 0
+	available := rightLimit - x - minInputWidth
+	for first < len(t.tags) && total > available {
+		total -= widths[first]
+		first++
+	}
+
+	// Draw tags.
+	t.removeColumns = make(map[int]int)
+	if first > 0 && x < rightLimit {
+		screen.SetContent(x, y, '…', nil, fieldStyle)
+		x++
+	}
+	for index := first; index < len(t.tags); index++ {
+		if x+widths[index] > rightLimit {
+			break
+		}
+		printStyled(screen, " "+tview.Escape(t.tags[index])+" ", x, y, widths[index]-2, tview.AlignLeft, t.tagStyle)
+		x += widths[index] - 2
+		screen.SetContent(x, y, '✕', nil, t.tagStyle)
+		if !t.disabled {
+			t.removeColumns[x] = index
+		}
+		x += 2
+	}
+
+	// Draw the input.
+	if x < rightLimit {
+		t.input.SetRect(x, y, rightLimit-x, 1)
+		t.input.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (t *TagsField) Focus(delegate func(p tview.Primitive)) {
+	if t.disabled && t.finished != nil {
+		t.finished(-1)
+		return
+	}
+	t.Box.Focus(delegate)
+	t.input.Focus(delegate)
+}
+
+// Blur is called when this primitive loses focus.
+func (t *TagsField) Blur() {
+	t.list.shown = false
+	t.commitTyped()
+	t.input.Blur()
+	t.Box.Blur()
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *TagsField) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if t.disabled {
+			return
+		}
+		switch event.Key() {
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if t.input.GetText() == "" {
+				if len(t.tags) > 0 {
+					t.removeTag(len(t.tags) - 1)
+				}
+				return
+			}
+		case tcell.KeyDown:
+			t.showAll = true
+			defer func() { t.showAll = false }()
+		}
+		t.input.InputHandler()(event, t.focusSelf(setFocus))
+		t.list.processed(event.Key())
+	})
+}
+
+// UsesKey returns whether the field uses the given key itself, e.g. Up and
+// Down while suggestions are shown.
+func (t *TagsField) UsesKey(key tcell.Key) bool {
+	return t.list.usesKey(key)
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *TagsField) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return t.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if t.disabled {
+			return false, nil
+		}
+
+		// The suggestions may be shown outside of the item.
+		if consumed, capture = t.input.MouseHandler()(action, event, t.focusSelf(setFocus)); consumed {
+			return
+		}
+		column, row := event.Position()
+		if !t.InRect(column, row) {
+			return false, nil
+		}
+		if action == tview.MouseLeftDown {
+			setFocus(t)
+			if index, ok := t.removeColumns[column]; ok {
+				t.removeTag(index)
+			}
+		}
+		return true, nil
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (t *TagsField) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return t.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		if !t.disabled {
+			t.input.PasteHandler()(pastedText, t.focusSelf(setFocus))
+		}
+	})
+}
+
+// focusSelf returns a focus function which focuses this item instead of the
+// primitives it contains.
+func (t *TagsField) focusSelf(setFocus func(p tview.Primitive)) func(p tview.Primitive) {
+	return func(p tview.Primitive) {
+		setFocus(t)
+	}
+}
+
+// containsString returns whether the given strings contain the given one.
+func containsString(list []string, s string) bool {
+	for _, element := range list {
+		if element == s {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTagsField adds a field for a list of tags to the form (see TagsField).
+// The optional "changed" function is called with the tags whenever they
+// change. Use SetSuggestions on the item to suggest tags while typing.
+func (f *FormScrollable) AddTagsField(label string, tags []string, changed func(tags []string)) *FormScrollable {
+	f.addItem(NewTagsField().
+		SetLabel(label).
+		SetTags(tags).
+		SetChangedFunc(changed))
+	return f
+}
@@ -0,0 +1,148 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// FormTheme is a set of colors and styles which can be applied to a form (see
+// FormScrollable.ApplyTheme) and to the widgets built from forms (see
+// Wizard.ApplyTheme and TabbedFormScrollable.ApplyTheme) at once, instead of
+// setting them one by one. (It is not named Theme to avoid a clash with
+// tview.Theme, which is tview's global set of default colors.)
+type FormTheme struct {
+	// The background color of the form.
+	Background tcell.Color
+
+	// The colors of the items' labels and of their input areas.
+	Label, FieldText, FieldBackground tcell.Color
+
+	// The styles of the buttons when they are not focused, when they are
+	// focused, and when they are disabled. The foreground color of the
+	// disabled style is also the label color of disabled items.
+	Button, ButtonActivated, ButtonDisabled tcell.Style
+
+	// The color of the labels and messages of items which failed validation.
+	Error tcell.Color
+
+	// The styles of the scroll bar's track and thumb.
+	ScrollBarTrack, ScrollBarThumb tcell.Style
+}
+
+// Built-in themes.
+var (
+	// ThemeDark uses light text on a black background.
+	ThemeDark = FormTheme{
+		Background:      tcell.ColorBlack,
+		Label:           tcell.ColorYellow,
+		FieldText:       tcell.ColorWhite,
+		FieldBackground: tcell.ColorDarkBlue,
+		Button:          tcell.StyleDefault.Background(tcell.ColorDarkBlue).Foreground(tcell.ColorWhite),
+		ButtonActivated: tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorDarkBlue),
+		ButtonDisabled:  tcell.StyleDefault.Background(tcell.ColorDarkBlue).Foreground(tcell.ColorGray),
+		Error:           tcell.ColorRed,
+		ScrollBarTrack:  tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorGray),
+		ScrollBarThumb:  tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorWhite),
+	}
+
+	// ThemeLight uses dark text on a white background.
+	ThemeLight = FormTheme{
+		Background:      tcell.ColorWhite,
+		Label:           tcell.ColorNavy,
+		FieldText:       tcell.ColorBlack,
+		FieldBackground: tcell.ColorLightGray,
+		Button:          tcell.StyleDefault.Background(tcell.ColorLightGray).Foreground(tcell.ColorBlack),
+		ButtonActivated: tcell.StyleDefault.Background(tcell.ColorNavy).Foreground(tcell.ColorWhite),
+		ButtonDisabled:  tcell.StyleDefault.Background(tcell.ColorLightGray).Foreground(tcell.ColorGray),
+		Error:           tcell.ColorMaroon,
+		ScrollBarTrack:  tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorLightGray),
+		ScrollBarThumb:  tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack),
+	}
+
+	// ThemeHighContrast uses only black, white, and yellow, with bold and
+	// underlined buttons so that they can be told apart without colors.
+	ThemeHighContrast = FormTheme{
+		Background:      tcell.ColorBlack,
+		Label:           tcell.ColorWhite,
+		FieldText:       tcell.ColorBlack,
+		FieldBackground: tcell.ColorWhite,
+		Button:          tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack).Bold(true),
+		ButtonActivated: tcell.StyleDefault.Background(tcell.ColorYellow).Foreground(tcell.ColorBlack).Bold(true).Underline(true),
+		ButtonDisabled:  tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorWhite).Dim(true),
+		Error:           tcell.ColorYellow,
+		ScrollBarTrack:  tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorWhite),
+		ScrollBarThumb:  tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorYellow),
+	}
+)
+
+// DefaultTheme returns the theme of new forms, which is derived from
+// tview.Styles.
+func DefaultTheme() FormTheme {
+	return FormTheme{
+		Background:      tview.Styles.PrimitiveBackgroundColor,
+		Label:           tview.Styles.SecondaryTextColor,
+		FieldText:       tview.Styles.PrimaryTextColor,
+		FieldBackground: tview.Styles.ContrastBackgroundColor,
+		Button:          tcell.StyleDefault.Background(tview.Styles.ContrastBackgroundColor).Foreground(tview.Styles.PrimaryTextColor),
+		ButtonActivated: tcell.StyleDefault.Background(tview.Styles.PrimaryTextColor).Foreground(tview.Styles.ContrastBackgroundColor),
+		ButtonDisabled:  tcell.StyleDefault.Background(tview.Styles.ContrastBackgroundColor).Foreground(tview.Styles.ContrastSecondaryTextColor),
+		Error:           tcell.ColorRed,
+		ScrollBarTrack:  tcell.StyleDefault.Background(tview.Styles.PrimitiveBackgroundColor).Foreground(tview.Styles.ContrastSecondaryTextColor),
+		ScrollBarThumb:  tcell.StyleDefault.Background(tview.Styles.PrimitiveBackgroundColor).Foreground(tview.Styles.PrimaryTextColor),
+	}
+}
+
+// ApplyTheme sets the form's colors and styles, including those of its scroll
+// buttons, to the given theme's. Styles of individual items (see SetItemStyle)
+// are kept.
+func (f *FormScrollable) ApplyTheme(theme FormTheme) *FormScrollable {
+	f.SetBackgroundColor(theme.Background)
+	f.labelColor = theme.Label
+	f.fieldTextColor = theme.FieldText
+	f.fieldBackgroundColor = theme.FieldBackground
+	f.buttonStyle = theme.Button
+	f.buttonActivatedStyle = theme.ButtonActivated
+	f.buttonDisabledStyle = theme.ButtonDisabled
+	f.errorColor = theme.Error
+	f.scrollBar.trackStyle = theme.ScrollBarTrack
+	f.scrollBar.thumbStyle = theme.ScrollBarThumb
+	for _, button := range []*NoneFocusableButton{
+		f.upScrollButton,
+		f.downScrollButton,
+		f.leftScrollButton,
+		f.rightScrollButton,
+		f.overflowButton,
+	} {
+		button.SetStyle(theme.Button).SetDisabledStyle(theme.ButtonDisabled)
+	}
+	return f
+}
+
+// ApplyTheme applies the given theme to the forms of all pages (see
+// FormScrollable.ApplyTheme) and to the header: the current page's title is
+// shown in the background color of the theme's activated button style, other
+// titles in the foreground color of its disabled button style. Pages added
+// later keep their forms' colors.
+func (w *Wizard) ApplyTheme(theme FormTheme) *Wizard {
+	w.SetBackgroundColor(theme.Background)
+	for _, page := range w.pages {
+		page.form.ApplyTheme(theme)
+	}
+	_, w.currentColor, _ = theme.ButtonActivated.Decompose()
+	w.otherColor, _, _ = theme.ButtonDisabled.Decompose()
+	w.errorColor = theme.Error
+	return w
+}
+
+// ApplyTheme applies the given theme to the forms of all tabs (see
+// FormScrollable.ApplyTheme) and to the tab names: the current tab's name is
+// shown in the theme's activated button style, other names in its button
+// style. Tabs added later keep their forms' colors.
+func (t *TabbedFormScrollable) ApplyTheme(theme FormTheme) *TabbedFormScrollable {
+	t.SetBackgroundColor(theme.Background)
+	for _, tab := range t.tabs {
+		tab.form.ApplyTheme(theme)
+	}
+	t.currentStyle, t.otherStyle = theme.ButtonActivated, theme.Button
+	return t
+}
@@ -0,0 +1,456 @@
+package form
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// The largest value of a duration field, in minutes.
+const maxDurationMinutes = 999*60 + 59
+
+// TimeField is a form item for a clock time ("15:04") or a duration
+// ("  1h 30m"). It has an hour and a minute segment. Left and right (or Home
+// and End) select a segment, up and down increment and decrement it (carrying
+// over into the hours), and digits replace its value.
+type TimeField struct {
+	*tview.Box
+
+	// Whether this is a duration field (rather than a clock time).
+	duration bool
+
+	// The value in minutes (since midnight for clock times).
+	minutes int
+
+	// The selected segment (0 for hours, 1 for minutes) and the digits typed
+	// into it so far.
+	segment int
+	typed   string
+
+	// The label and its width (0 means the width of the label text).
+	label      string
+	labelWidth int
+
+	// Colors.
+	labelColor           tcell.Color
+	fieldTextColor       tcell.Color
+	fieldBackgroundColor tcell.Color
+
+	// Whether the item is disabled.
+	disabled bool
+
+	// An optional function which is called when the value changed.
+	changed func(value time.Duration)
+
+	// An optional function which is called when the user leaves the item.
+	finished func(key tcell.Key)
+}
+
+var (
+	_ tview.FormItem  = (*TimeField)(nil)
+	_ ItemValuer      = (*TimeField)(nil)
+	_ ItemValueSetter = (*TimeField)(nil)
+)
+
+// NewTimeField returns a new field for a clock time, initially midnight.
+func NewTimeField() *TimeField {
+	return &TimeField{
+		Box:                  tview.NewBox(),
+		labelColor:           tview.Styles.SecondaryTextColor,
+		fieldTextColor:       tview.Styles.PrimaryTextColor,
+		fieldBackgroundColor: tview.Styles.ContrastBackgroundColor,
+	}
+}
+
+// NewDurationField returns a new field for a duration of up to 999 hours and
+// 59 minutes, initially 0.
+func NewDurationField() *TimeField {
+	t := NewTimeField()
+	t.duration = true
+	return t
+}
+
+// SetLabel sets the text to be displayed before the field.
+func (t *TimeField) SetLabel(label string) *TimeField {
+	t.label = label
+	return t
+}
+
+// GetLabel returns the text to be displayed before the field.
+func (t *TimeField) GetLabel() string {
+	return t.label
+}
+
+// SetClock sets the clock time. Values out of range wrap around.
+func (t *TimeField) SetClock(hour, minute int) *TimeField {
+	t.setMinutes(hour*60 + minute)
+	return t
+}
+
+// GetClock returns the hour and the minute of the clock time. For duration
+// fields, the hour may exceed 23.
+func (t *TimeField) GetClock() (hour, minute int) {
+	return t.minutes / 60, t.minutes % 60
+}
+
+// SetDuration sets the value. Seconds are truncated. For clock times, the
+// duration since midnight is set.
+func (t *TimeField) SetDuration(value time.Duration) *TimeField {
+	t.setMinutes(int(value / time.Minute))
+	return t
+}
+
+// GetDuration returns the value. For clock times, this is the duration since
+// midnight.
+func (t *TimeField) GetDuration() time.Duration {
+	return time.Duration(t.minutes) * time.Minute
+}
+
+// GetValue returns the value as a time.Duration (see GetDuration).
+func (t *TimeField) GetValue() any {
+	return t.GetDuration()
+}
+
+// SetValue sets the value from a time.Duration (see SetDuration). Other
+// values are ignored.
+func (t *TimeField) SetValue(value any) {
+	if duration, ok := value.(time.Duration); ok {
+		t.SetDuration(duration)
+	}
+}
+
+// GetText returns the value as text, "15:04" for clock times and formatted
+// like time.Duration.String for durations.
+func (t *TimeField) GetText() string {
+	if t.duration {
+		return t.GetDuration().String()
+	}
+	hour, minute := t.GetClock()
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+// SetText sets the value from text as returned by GetText. An error is
+// returned and the value is not changed if the text can't be parsed.
+func (t *TimeField) SetText(text string) error {
+	if t.duration {
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		t.SetDuration(duration)
+		return nil
+	}
+	var hour, minute int
+	if _, err := fmt.Sscanf(text, "%d:%d", &hour, &minute); err != nil {
+		return err
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return fmt.Errorf("invalid time %q", text)
+	}
+	t.SetClock(hour, minute)
+	return nil
+}
+
+// SetChangedFunc sets a handler which is called when the value changed.
+func (t *TimeField) SetChangedFunc(handler func(value time.Duration)) *TimeField {
+	t.changed = handler
+	return t
+}
+
+// setMinutes sets the value in minutes. Clock times wrap around, durations
+// are clamped. The "changed" handler is called if the value changed.
+func (t *TimeField) setMinutes(minutes int) {
+	if t.duration {
+		minutes = clamp(minutes, 0, maxDurationMinutes)
+	} else {
+		minutes = ((minutes % (24 * 60)) + 24*60) % (24 * 60)
+	}
+	if minutes == t.minutes {
+		return
+	}
+	t.minutes = minutes
+	if t.changed != nil {
+		t.changed(t.GetDuration())
+	}
+}
+
+// hourDigits returns the number of digits of the hour segment.
+func (t *TimeField) hourDigits() int {
+	if t.duration {
+		return 3
+	}
+	return 2
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (t *TimeField) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	t.labelWidth = labelWidth
+	t.labelColor = labelColor
+	t.SetBackgroundColor(bgColor)
+	t.fieldTextColor = fieldTextColor
+	t.fieldBackgroundColor = fieldBgColor
+	return t
+}
+
+// GetFieldWidth returns the screen width of the field.
+func (t *TimeField) GetFieldWidth() int {
+	if t.duration {
+		return 8 // "999h 59m"
+	}
+	return 5 // "23:59"
+}
+
+// GetFieldHeight returns the height of the field.
+func (t *TimeField) GetFieldHeight() int {
+	return 1
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (t *TimeField) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	t.finished = handler
+	return t
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (t *TimeField) SetDisabled(disabled bool) tview.FormItem {
+	t.disabled = disabled
+	if t.finished != nil {
+		t.finished(-1)
+	}
+	return t
+}
+
+// segments returns the texts of the hour and the minute segment and the
+// separators after them.
+func (t *TimeField) segments() (texts [2]string, separators [2]string) {
+	hour, minute := t.GetClock()
+	if t.duration {
+		return [2]string{fmt.Sprintf("%3d", hour), fmt.Sprintf("%2d", minute)}, [2]string{"h ", "m"}
+	}
+	return [2]string{fmt.Sprintf("%02d", hour), fmt.Sprintf("%02d", minute)}, [2]string{":", ""}
+}
+
+// Draw draws this primitive onto the screen.
+func (t *TimeField) Draw(screen tcell.Screen) {
+	t.Box.DrawForSubclass(screen, t)
+
+	x, y, width, height := t.GetInnerRect()
+	rightLimit := x + width
+	if height < 1 || rightLimit <= x {
+		return
+	}
+
+	// Draw label.
+	if t.labelWidth > 0 {
+		labelWidth := min(t.labelWidth, rightLimit-x)
+		tview.Print(screen, t.label, x, y, labelWidth, tview.AlignLeft, t.labelColor)
+		x += labelWidth
+	} else {
+		_, drawnWidth := tview.Print(screen, t.label, x, y, rightLimit-x, tview.AlignLeft, t.labelColor)
+		x += drawnWidth
+	}
+
+	// Draw field.
+	fieldStyle := tcell.StyleDefault.Background(t.fieldBackgroundColor).Foreground(t.fieldTextColor)
+	if t.disabled {
+		fieldStyle = fieldStyle.Background(t.GetBackgroundColor())
+	}
+	texts, separators := t.segments()
+	for index, text := range texts {
+		style := fieldStyle
+		if index == t.segment && t.HasFocus() {
+			style = style.Reverse(true)
+			if t.typed != "" {
+				text = fmt.Sprintf("%*s", len(text), t.typed)
+			}
+		}
+		for _, part := range []struct {
+			text  string
+			style tcell.Style
+		}{{text, style}, {separators[index], fieldStyle}} {
+			for _, r := range part.text {
+				if x >= rightLimit {
+					return
+				}
+				screen.SetContent(x, y, r, nil, part.style)
+				x++
+			}
+		}
+	}
+}
+
+// segmentAt returns the segment at the given screen column or -1 if there is
+// none.
+func (t *TimeField) segmentAt(column int) int {
+	x, _, _, _ := t.GetInnerRect()
+	if t.labelWidth > 0 {
+		x += t.labelWidth
+	} else {
+		x += tview.TaggedStringWidth(t.label)
+	}
+	texts, separators := t.segments()
+	for index, text := range texts {
+		width := len(text) + len(separators[index])
+		if column >= x && column < x+width {
+			return index
+		}
+		x += width
+	}
+	return -1
+}
+
+// commitTyped sets the value of the selected segment to the typed digits.
+func (t *TimeField) commitTyped() {
+	if t.typed == "" {
+		return
+	}
+	var value int
+	fmt.Sscan(t.typed, &value)
+	t.typed = ""
+	hour, minute := t.GetClock()
+	if t.segment == 0 {
+		hour = value
+	} else {
+		minute = min(value, 59)
+	}
+	t.setMinutes(hour*60 + minute)
+}
+
+// Focus is called when this primitive receives focus.
+func (t *TimeField) Focus(delegate func(p tview.Primitive)) {
+	if t.disabled && t.finished != nil {
+		t.finished(-1)
+		return
+	}
+	t.Box.Focus(delegate)
+}
+
+// Blur is called when this primitive loses focus.
+func (t *TimeField) Blur() {
+	t.commitTyped()
+	t.Box.Blur()
+}
+
+// UsesKey returns whether the field uses the given key itself: the arrow keys,
+// Home, and End select and change the segments.
+func (t *TimeField) UsesKey(key tcell.Key) bool {
+	switch key {
+	case tcell.KeyLeft, tcell.KeyRight, tcell.KeyUp, tcell.KeyDown, tcell.KeyHome, tcell.KeyEnd:
+		return true
+	}
+	return false
+}
+
+// SubmitsOnEnter returns true: Enter in the field submits the form like in
+// an input field (see SetSubmitFunc).
+func (t *TimeField) SubmitsOnEnter() bool {
+	return true
+}
+
+// InputHandler returns the handler for this primitive.
+func (t *TimeField) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if t.disabled {
+			return
+		}
+		step := 1
+		if t.segment == 0 {
+			step = 60
+		}
+		switch key := event.Key(); key {
+		case tcell.KeyLeft, tcell.KeyHome:
+			t.commitTyped()
+			t.segment = 0
+		case tcell.KeyRight, tcell.KeyEnd:
+			t.commitTyped()
+			t.segment = 1
+		case tcell.KeyUp:
+			t.commitTyped()
+			t.setMinutes(t.minutes + step)
+		case tcell.KeyDown:
+			t.commitTyped()
+			t.setMinutes(t.minutes - step)
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if t.typed != "" {
+				t.typed = t.typed[:len(t.typed)-1]
+			}
+		case tcell.KeyRune:
+			r := event.Rune()
+			if r < '0' || r > '9' {
+				break
+			}
+			t.typed += string(r)
+			digits := 2
+			if t.segment == 0 {
+				digits = t.hourDigits()
+			}
+			if len(t.typed) >= digits {
+				t.commitTyped()
+				t.segment = 1
+			}
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape:
+			t.commitTyped()
+			if t.finished != nil {
+				t.finished(key)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (t *TimeField) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return t.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if t.disabled || !t.InRect(event.Position()) {
+			return false, nil
+		}
+		switch action {
+		case tview.MouseLeftDown:
+			setFocus(t)
+			if column, _ := event.Position(); t.segmentAt(column) >= 0 {
+				t.commitTyped()
+				t.segment = t.segmentAt(column)
+			}
+			consumed = true
+		case tview.MouseScrollUp, tview.MouseScrollDown:
+			if !t.HasFocus() {
+				break
+			}
+			step := 1
+			if t.segment == 0 {
+				step = 60
+			}
+			if action == tview.MouseScrollDown {
+				step = -step
+			}
+			t.commitTyped()
+			t.setMinutes(t.minutes + step)
+			consumed = true
+		}
+		return
+	})
+}
+
+// AddTimeField adds a field for a clock time to the form (see TimeField). The
+// optional "changed" function is called with the new hour and minute.
+func (f *FormScrollable) AddTimeField(label string, hour, minute int, changed func(hour, minute int)) *FormScrollable {
+	field := NewTimeField().SetLabel(label).SetClock(hour, minute)
+	if changed != nil {
+		field.SetChangedFunc(func(value time.Duration) {
+			changed(field.GetClock())
+		})
+	}
+	f.addItem(field)
+	return f
+}
+
+// AddDurationField adds a field for a duration to the form (see TimeField).
+// The optional "changed" function is called with the new duration.
+func (f *FormScrollable) AddDurationField(label string, value time.Duration, changed func(value time.Duration)) *FormScrollable {
+	f.addItem(NewDurationField().
+		SetLabel(label).
+		SetDuration(value).
+		SetChangedFunc(changed))
+	return f
+}
@@ -0,0 +1,43 @@
+package form
+
+import (
+	"github.com/rivo/tview"
+)
+
+// ToolbarPosition determines where a toolbar is attached to a form.
+type ToolbarPosition int
+
+// Toolbar positions.
+const (
+	ToolbarTop ToolbarPosition = iota
+	ToolbarBottom
+)
+
+// ToolButton is a small button (usually labeled with an icon) which is shown in
+// a form toolbar. Like NoneFocusableButton, it never receives focus and is
+// triggered by mouse clicks only.
+type ToolButton struct {
+	*NoneFocusableButton
+}
+
+// NewToolButton returns a new tool button with the given label. The "selected"
+// function is called when the button is clicked. It may be nil.
+func NewToolButton(label string, selected func()) *ToolButton {
+	b := &ToolButton{
+		NoneFocusableButton: NewNoneFocusableButton(label),
+	}
+	b.SetClick(selected)
+	return b
+}
+
+// SetFocusable sets the primitive which receives focus when the button is
+// clicked.
+func (b *ToolButton) SetFocusable(f tview.Primitive) *ToolButton {
+	b.NoneFocusableButton.SetFocusable(f)
+	return b
+}
+
+// GetWidth returns the number of cells the button occupies in a toolbar.
+func (b *ToolButton) GetWidth() int {
+	return tview.TaggedStringWidth(b.GetLabel()) + 2
+}
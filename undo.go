@@ -0,0 +1,141 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// undoLimit is the maximum number of changes which can be undone.
+const undoLimit = 100
+
+// valueChange is a change the user made to the value of a form item, recorded
+// as snapshots of the value (see itemSnapshot).
+type valueChange struct {
+	item          FormItem
+	before, after any
+}
+
+// Undo reverts the last change the user made to the value of any form item
+// (e.g. typing into an input field, selecting an option, or toggling a
+// checkbox), which can then be redone with Redo. Consecutive changes to the
+// focused item count as one change. The user can also undo with Ctrl+Z and
+// redo with Ctrl+Y, except in text areas, which have their own undo history.
+// Returns whether there was a change to undo.
+func (f *FormScrollable) Undo() bool {
+	change, ok := f.popChange(&f.undoStack)
+	if !ok {
+		return false
+	}
+	f.applyChange(change.item, change.before)
+	f.redoStack = append(f.redoStack, change)
+	return true
+}
+
+// Redo applies the last change reverted with Undo again. Returns whether there
+// was a change to redo.
+func (f *FormScrollable) Redo() bool {
+	change, ok := f.popChange(&f.redoStack)
+	if !ok {
+		return false
+	}
+	f.applyChange(change.item, change.after)
+	f.undoStack = append(f.undoStack, change)
+	return true
+}
+
+// ClearUndoHistory forgets all changes which could be undone or redone.
+func (f *FormScrollable) ClearUndoHistory() *FormScrollable {
+	f.undoStack, f.redoStack, f.undoMerge = nil, nil, nil
+	return f
+}
+
+// popChange removes the last change from the given stack and returns it.
+// Changes of items which were removed from the form are dropped.
+func (f *FormScrollable) popChange(stack *[]valueChange) (valueChange, bool) {
+	f.undoMerge = nil
+	for len(*stack) > 0 {
+		change := (*stack)[len(*stack)-1]
+		*stack = (*stack)[:len(*stack)-1]
+		if f.itemIndex(change.item) >= 0 {
+			return change, true
+		}
+	}
+	return valueChange{}, false
+}
+
+// applyChange sets the value of the given item to the given snapshot.
+func (f *FormScrollable) applyChange(item FormItem, value any) {
+	restoreItemSnapshot(item, value)
+	if state, ok := f.itemStates[item]; ok && state.err != nil {
+		f.validateItem(item)
+	}
+}
+
+// undoKey processes Ctrl+Z and Ctrl+Y unless the focused item uses them
+// itself. Returns whether the event was consumed.
+func (f *FormScrollable) undoKey(event *tcell.EventKey) bool {
+	switch event.Key() {
+	case tcell.KeyCtrlZ:
+		return !f.focusedItemUsesKey(event.Key()) && f.Undo()
+	case tcell.KeyCtrlY:
+		return !f.focusedItemUsesKey(event.Key()) && f.Redo()
+	}
+	return false
+}
+
+// trackChanges takes snapshots of the values of the given items and returns a
+// function which records the changes made to them since, e.g. while an event
+// was processed.
+func (f *FormScrollable) trackChanges(items ...FormItem) func() {
+	before := make([]any, len(items))
+	for index, item := range items {
+		before[index], _ = itemSnapshot(item)
+	}
+	return func() {
+		for index, item := range items {
+			after, ok := itemSnapshot(item)
+			if ok && !reflect.DeepEqual(before[index], after) {
+				f.recordChange(item, before[index], after)
+			}
+		}
+	}
+}
+
+// recordChange adds a change to the undo history. It is merged with the last
+// change if that one was made to the same item while it kept focus.
+func (f *FormScrollable) recordChange(item FormItem, before, after any) {
+	f.redoStack = nil
+	if last := len(f.undoStack) - 1; last >= 0 && f.undoMerge == item && f.undoStack[last].item == item {
+		f.undoStack[last].after = after
+		return
+	}
+	f.undoStack = append(f.undoStack, valueChange{item: item, before: before, after: after})
+	if len(f.undoStack) > undoLimit {
+		f.undoStack = f.undoStack[len(f.undoStack)-undoLimit:]
+	}
+	f.undoMerge = item
+}
+
+// focusedItems returns the focused form item, if any.
+func (f *FormScrollable) focusedItems() []FormItem {
+	if index := f.focusIndex(); index >= 0 && index < len(f.items) {
+		return []FormItem{f.items[index]}
+	}
+	return nil
+}
+
+// mouseItems returns the focused form item and the form item at the given
+// screen position, if any.
+func (f *FormScrollable) mouseItems(x, y int) []FormItem {
+	items := f.focusedItems()
+	for _, item := range f.items {
+		itemX, itemY, width, height := item.GetRect()
+		inside := x >= itemX && x < itemX+width && y >= itemY && y < itemY+height
+		if inside && (len(items) == 0 || items[0] != item) {
+			return append(items, item)
+		}
+	}
+	return items
+}
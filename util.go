@@ -0,0 +1,109 @@
+package form
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+// clamp returns value limited to the range [low, high]. If high is smaller
+// than low, low is returned.
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+// max returns the larger of the two values.
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// min returns the smaller of the two values.
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Rect describes a rectangular area on screen.
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Contains returns whether the given screen position is inside the rectangle.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// DrawPhase tells a draw hook whether it is called before or after the
+// corresponding element is drawn.
+type DrawPhase int
+
+// Draw phases.
+const (
+	DrawBefore DrawPhase = iota
+	DrawAfter
+)
+
+// clampFloat returns value limited to the range [low, high]. If high is
+// smaller than low, low is returned.
+func clampFloat(value, low, high float64) float64 {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+// The off-screen screen input fields are drawn onto before their text is
+// replaced, see setInputFieldText.
+var (
+	offScreen      tcell.SimulationScreen
+	offScreenWidth = 1024
+	offScreenMutex sync.Mutex
+)
+
+// setInputFieldText replaces the text of the given input field. Until the
+// input field was drawn for the first time, tview inserts the new text at the
+// end of the old one instead of replacing it. If the field has text, it is
+// therefore drawn off-screen first, its position is kept.
+func setInputFieldText(field *tview.InputField, text string) {
+	if field.GetText() != "" {
+		offScreenMutex.Lock()
+		if offScreen == nil {
+			offScreen = tcell.NewSimulationScreen("")
+			offScreen.Init()
+			offScreen.SetSize(offScreenWidth, 1)
+		}
+		x, y, width, height := field.GetRect()
+		field.SetRect(0, 0, offScreenWidth, 1)
+		field.Draw(offScreen)
+		field.SetRect(x, y, width, height)
+		offScreenMutex.Unlock()
+	}
+	field.SetText(text)
+}
+
+// yamlToJSON converts the given YAML document to JSON, so that it can be read
+// like a JSON document. Mappings must have text keys.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
@@ -0,0 +1,190 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// ValidationError describes why the value of a form item is invalid.
+type ValidationError struct {
+	Index int    // The index of the item.
+	Label string // The label of the item.
+	Err   error  // The error returned by the item's validator.
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Label, e.Err)
+}
+
+// Unwrap returns the error returned by the item's validator.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// SetValidator sets a function which validates the value of the form item at
+// the given index. The value is passed as text (e.g. "true" or "false" for
+// checkboxes, the selected option for drop-downs). The item is validated when
+// the user leaves it and when Validate is called. If the validator returns an
+// error, the item's label is shown in the error color and the error message
+// is shown below the item's field. Set the validator to nil to remove it.
+func (f *FormScrollable) SetValidator(index int, validator func(value string) error) *FormScrollable {
+	state := f.state(f.items[index])
+	state.validator = validator
+	state.err = nil
+	return f
+}
+
+// SetErrorColor sets the color of labels and messages of items which failed
+// validation.
+func (f *FormScrollable) SetErrorColor(color tcell.Color) *FormScrollable {
+	f.errorColor = color
+	return f
+}
+
+// SetButtonRequiresValid sets whether the button at the given index is
+// disabled as long as the form does not validate. This is checked whenever the
+// form is drawn, without showing any error messages.
+func (f *FormScrollable) SetButtonRequiresValid(index int, requiresValid bool) *FormScrollable {
+	button := f.buttons[index]
+	if requiresValid {
+		if f.validButtons == nil {
+			f.validButtons = make(map[*Button]bool)
+		}
+		f.validButtons[button] = true
+	} else if f.validButtons[button] {
+		delete(f.validButtons, button)
+		button.SetDisabled(false)
+	}
+	return f
+}
+
+// Validate validates all form items and returns the errors of the items which
+// are invalid, as *ValidationError values. Required items which are empty (see
+// SetItemRequired) are invalid, too. An empty result means that the form is
+// valid. The errors are shown next to the items. Hidden items are not
+// validated.
+func (f *FormScrollable) Validate() []error {
+	var errs []error
+	for index, item := range f.items {
+		if !f.IsItemVisible(index) {
+			continue
+		}
+		if err := f.validateItem(item); err != nil {
+			errs = append(errs, &ValidationError{Index: index, Label: item.GetLabel(), Err: err})
+		}
+	}
+	return errs
+}
+
+// validateItem validates the given item, stores the result for display, and
+// returns it.
+func (f *FormScrollable) validateItem(item FormItem) error {
+	state, ok := f.itemStates[item]
+	if !ok || state.validator == nil && !state.required {
+		return nil
+	}
+	state.err = f.checkItem(item, state)
+	return state.err
+}
+
+// checkItem returns the error of the given item with the given state, if it
+// is required but empty or if its validator fails.
+func (f *FormScrollable) checkItem(item FormItem, state *itemState) error {
+	if state.required && isEmptyItem(item) {
+		return ErrRequired
+	}
+	if state.validator != nil {
+		return state.validator(f.rawText(item))
+	}
+	return nil
+}
+
+// isValid returns whether all items validate, without storing the results.
+func (f *FormScrollable) isValid() bool {
+	for _, item := range f.items {
+		if state, ok := f.itemStates[item]; ok && !state.hidden && f.checkItem(item, state) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// buttonRequiresValid returns whether the given button may only be enabled if
+// the form is valid.
+func (f *FormScrollable) buttonRequiresValid(button *Button) bool {
+	return f.validButtons[button]
+}
+
+// SetSubmitFunc sets a function which is called when the user presses Enter
+// in a single-line input field, like in HTML forms. Besides input fields, this
+// includes the items which implement ItemSubmitter, e.g. time and IP fields.
+// Before, the form is validated (see Validate). If it is invalid, the focus
+// moves to the first invalid item instead. If a submit button was marked with
+// MarkSubmitButton, that button is pressed instead of calling this function.
+func (f *FormScrollable) SetSubmitFunc(handler func()) *FormScrollable {
+	f.submit = handler
+	return f
+}
+
+// MarkSubmitButton marks the button at the given index as the form's submit
+// button which is pressed when the user presses Enter in a single-line input
+// field (see SetSubmitFunc). A negative index removes the mark.
+func (f *FormScrollable) MarkSubmitButton(index int) *FormScrollable {
+	if index < 0 {
+		f.submitButton = nil
+	} else {
+		f.submitButton = f.buttons[index]
+	}
+	return f
+}
+
+// submitOnEnter submits the form if Enter was pressed in the given item and
+// the item is a single-line input field or another item which submits on
+// Enter (see ItemSubmitter). Returns whether the key was handled.
+func (f *FormScrollable) submitOnEnter(item FormItem, setFocus func(p Primitive)) bool {
+	switch item := unwrapItem(item).(type) {
+	case ItemSubmitter:
+		if !item.SubmitsOnEnter() {
+			return false
+		}
+	case *InputField:
+	default:
+		return false
+	}
+	return f.submitForm(setFocus)
+}
+
+// submitForm validates the form and submits it if it is valid. Returns false
+// if there is no way to submit the form.
+func (f *FormScrollable) submitForm(setFocus func(p Primitive)) bool {
+	if f.submit == nil && f.submitButton == nil {
+		return false
+	}
+	if errs := f.Validate(); len(errs) > 0 {
+		var validationErr *ValidationError
+		if errors.As(errs[0], &validationErr) {
+			f.focusedElement = validationErr.Index
+			f.Focus(setFocus)
+		}
+		return true
+	}
+
+	if button := f.submitButton; button != nil {
+		// A button which requires a valid form may still be disabled because
+		// the form was invalid when it was last drawn. Disabled buttons
+		// ignore key events.
+		if f.buttonRequiresValid(button) {
+			button.SetDisabled(false)
+		}
+		if !button.IsDisabled() {
+			button.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+		}
+	} else {
+		f.submit()
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package form
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestValidate(t *testing.T) {
+	tooShort := errors.New("too short")
+	f := NewFormScrollable().
+		AddInputField("Name", "Jo", 20, nil, nil).
+		AddInputField("Mail", "", 20, nil, nil).
+		AddInputField("Hidden", "", 20, nil, nil).
+		SetValidator(0, func(value string) error {
+			if len(value) < 3 {
+				return tooShort
+			}
+			return nil
+		}).
+		SetItemRequired(1, true).
+		SetItemRequired(2, true).
+		SetItemVisible(2, false)
+
+	errs := f.Validate()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	var validationErr *ValidationError
+	if !errors.As(errs[0], &validationErr) || validationErr.Index != 0 || !errors.Is(errs[0], tooShort) {
+		t.Errorf("unexpected first error %v", errs[0])
+	}
+	if !errors.Is(errs[1], ErrRequired) {
+		t.Errorf("expected ErrRequired, got %v", errs[1])
+	}
+
+	setItemText(f.GetFormItem(0), "Joe")
+	setItemText(f.GetFormItem(1), "joe@example.com")
+	if errs := f.Validate(); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestSubmitFuncRequiresValidForm(t *testing.T) {
+	var submitted int
+	f := NewFormScrollable().
+		AddInputField("Name", "", 20, nil, nil).
+		AddInputField("Mail", "", 20, nil, nil).
+		SetItemRequired(0, true).
+		SetSubmitFunc(func() { submitted++ })
+	fc := &focuser{}
+	fc.setFocus(f)
+	f.SetFocus(1)
+	fc.setFocus(f)
+
+	fc.press(f, tcell.KeyEnter, 0)
+	if submitted != 0 {
+		t.Fatal("submitted an invalid form")
+	}
+	if index, _ := f.GetFocusedItemIndex(); index != 0 {
+		t.Fatalf("expected the focus on the invalid item, got %d", index)
+	}
+	fc.press(f, tcell.KeyRune, 'x')
+	fc.press(f, tcell.KeyEnter, 0)
+	if submitted != 1 {
+		t.Fatalf("expected the form to be submitted once, got %d", submitted)
+	}
+}
+
+func TestSubmitOnEnterInSingleLineFields(t *testing.T) {
+	var submitted int
+	f := NewFormScrollable().
+		AddTimeField("Alarm", 7, 30, nil).
+		AddIPField("Address", "10.0.0.1", false, nil).
+		AddMaskedInputField("Phone", "999-9999", "", nil).
+		AddTextArea("Notes", "", 20, 3, 0, nil).
+		SetSubmitFunc(func() { submitted++ })
+	fc := &focuser{}
+	for index, expected := range []int{1, 2, 3, 3} {
+		f.SetFocus(index)
+		fc.setFocus(f)
+		fc.press(f, tcell.KeyEnter, 0)
+		if submitted != expected {
+			t.Errorf("%s: expected %d submissions, got %d", f.GetFormItem(index).GetLabel(), expected, submitted)
+		}
+	}
+}
+
+func TestSubmitButton(t *testing.T) {
+	var pressed int
+	f := NewFormScrollable().
+		AddInputField("Name", "", 20, nil, nil).
+		SetItemRequired(0, true).
+		AddButton("Save", func() { pressed++ }).
+		SetButtonRequiresValid(0, true).
+		MarkSubmitButton(0)
+	fc := &focuser{}
+	fc.setFocus(f)
+
+	// The button was disabled when the form was drawn with an empty name.
+	drawForm(t, f, 40, 5)
+	if !f.GetButton(0).IsDisabled() {
+		t.Fatal("expected the button to be disabled while the form is invalid")
+	}
+	setItemText(f.GetFormItem(0), "Jane")
+	fc.press(f, tcell.KeyEnter, 0)
+	if pressed != 1 {
+		t.Fatalf("expected the button to be pressed once, got %d", pressed)
+	}
+
+	// Explicitly disabled buttons are never pressed.
+	f.SetButtonRequiresValid(0, false)
+	f.GetButton(0).SetDisabled(true)
+	fc.press(f, tcell.KeyEnter, 0)
+	if pressed != 1 {
+		t.Fatalf("expected a disabled button not to be pressed, got %d", pressed)
+	}
+}
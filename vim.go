@@ -0,0 +1,82 @@
+package form
+
+import (
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+// SetVimMode turns the vi-style navigation mode on or off. In this mode, the
+// form starts out navigating: j and k (or Down and Up) move the focus to the
+// next and the previous element, gg and G to the first and the last element.
+// Other keys don't reach the focused item, except for Enter on buttons, which
+// presses them, and the keys the form handles itself (e.g. Tab, PgUp, PgDn,
+// and Escape). i or Enter on a form item starts editing it, the keys reach
+// the item as usual then. Escape (unless it closes an open drop-down) and
+// moving the focus to another element return to navigating.
+func (f *FormScrollable) SetVimMode(enabled bool) *FormScrollable {
+	f.vimMode = enabled
+	f.vimEditing, f.vimPrefix = false, false
+	return f
+}
+
+// IsVimEditing returns whether the form is in the vi-style navigation mode
+// (see SetVimMode) and the focused item is being edited.
+func (f *FormScrollable) IsVimEditing() bool {
+	return f.vimMode && f.vimEditing && f.focusIndex() == f.vimEditIndex
+}
+
+// vimKeys are the keys which are passed on to the form while navigating in
+// the vi-style navigation mode.
+var vimKeys = []tcell.Key{
+	tcell.KeyTab,
+	tcell.KeyBacktab,
+	tcell.KeyEscape,
+	tcell.KeyPgUp,
+	tcell.KeyPgDn,
+	tcell.KeyHome,
+	tcell.KeyEnd,
+	tcell.KeyCtrlC,
+	tcell.KeyCtrlZ,
+	tcell.KeyCtrlY,
+}
+
+// vimKey processes the given event in the vi-style navigation mode (see
+// SetVimMode). It returns whether the event was consumed.
+func (f *FormScrollable) vimKey(event *tcell.EventKey, setFocus func(p Primitive)) bool {
+	if !f.vimMode || f.navigating {
+		return false
+	}
+	key := event.Key()
+	if f.IsVimEditing() {
+		if key == tcell.KeyEscape && !f.focusedItemIsOpen() {
+			f.vimEditing = false
+			return true
+		}
+		return false
+	}
+
+	// Navigating.
+	prefix := f.vimPrefix
+	f.vimEditing, f.vimPrefix = false, false
+	index := f.focusIndex()
+	isItem := index >= 0 && index < len(f.items)
+	switch {
+	case key == tcell.KeyDown || key == tcell.KeyRune && event.Rune() == 'j':
+		f.processAs(tcell.KeyTab, setFocus)
+	case key == tcell.KeyUp || key == tcell.KeyRune && event.Rune() == 'k':
+		f.processAs(tcell.KeyBacktab, setFocus)
+	case key == tcell.KeyRune && event.Rune() == 'g':
+		if prefix {
+			f.focusEdge(true, setFocus)
+		} else {
+			f.vimPrefix = true
+		}
+	case key == tcell.KeyRune && event.Rune() == 'G':
+		f.focusEdge(false, setFocus)
+	case (key == tcell.KeyRune && event.Rune() == 'i' || key == tcell.KeyEnter) && isItem:
+		f.vimEditing, f.vimEditIndex = true, index
+	case key == tcell.KeyEnter || containsKey(vimKeys, key):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestVimEscapeInTextAreaReturnsToNavigation(t *testing.T) {
+	var cancelled bool
+	f := NewFormScrollable().
+		AddTextArea("Notes", "", 20, 3, 0, nil).
+		SetCancelFunc(func() { cancelled = true }).
+		SetVimMode(true)
+	fc := &focuser{}
+	fc.setFocus(f)
+
+	fc.press(f, tcell.KeyRune, 'i')
+	if !f.IsVimEditing() {
+		t.Fatal("expected to edit after i")
+	}
+	fc.press(f, tcell.KeyEscape, 0)
+	if cancelled || f.IsVimEditing() {
+		t.Fatalf("cancelled=%t editing=%t, expected to return to navigation", cancelled, f.IsVimEditing())
+	}
+}
+
+func TestVimEscapeClosesOpenDropDown(t *testing.T) {
+	f := NewFormScrollable().
+		AddDropDown("Color", []string{"red", "green"}, 0, nil).
+		SetVimMode(true)
+	fc := &focuser{}
+	fc.setFocus(f)
+
+	fc.press(f, tcell.KeyRune, 'i')
+	fc.press(f, tcell.KeyEnter, 0)
+	fc.press(f, tcell.KeyEscape, 0)
+	if !f.IsVimEditing() {
+		t.Fatal("expected Escape to close the drop-down only")
+	}
+	fc.press(f, tcell.KeyEscape, 0)
+	if f.IsVimEditing() {
+		t.Fatal("expected to return to navigation")
+	}
+}
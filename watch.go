@@ -0,0 +1,124 @@
+package form
+
+import (
+	"errors"
+
+	. "github.com/rivo/tview"
+)
+
+// BindWatch updates the form item at the given index with each text received
+// from the given channel, turning the form into a small live dashboard, e.g.
+// for a CPU usage or the status of a job. Text views (see AddTextView) show
+// the text, other items get it as their value like in SetValidator. The
+// updates are applied in the event loop of the form's application, which then
+// redraws the form. The application must therefore be set (see
+// SetApplication) before binding, an error is returned otherwise, as well as
+// if there is no item at the given index. The application and the redraw
+// coordinator (see SetRedrawCoordinator) set at the time of binding are used
+// for the whole watch. Redraws are limited like the form's other redraws (see
+// SetLowBandwidthMode).
+//
+// While an update waits for the event loop, e.g. because the application is
+// busy or was stopped, texts received in the meantime are coalesced: only the
+// latest one is applied.
+//
+// Watched values don't make items dirty (see IsItemDirty). The watch ends
+// when the channel is closed, when the item is removed, when another channel
+// is bound to the item, or with UnbindWatch. A nil channel only ends the
+// current watch.
+func (f *FormScrollable) BindWatch(index int, values <-chan string) error {
+	if err := checkIndex("form item", index, len(f.items)); err != nil {
+		return err
+	}
+	item := f.items[index]
+	f.unwatchItem(item)
+	if values == nil {
+		return nil
+	}
+	app, coordinator := f.app, f.coordinator
+	if app == nil && coordinator == nil {
+		return errors.New("watching values requires the application to be set")
+	}
+	stop := make(chan struct{})
+	f.state(item).watchStop = stop
+
+	// queue queues the update of the item with the given text. The returned
+	// channel is closed when the update was queued.
+	queue := func(text string) chan struct{} {
+		queued := make(chan struct{})
+		update := func() {
+			if state, ok := f.itemStates[item]; ok && state.watchStop == stop {
+				f.showWatched(item, state, text)
+			}
+		}
+		go func() {
+			defer close(queued)
+			if coordinator != nil {
+				coordinator.QueueUpdateDraw(update)
+			} else {
+				f.redraws.queueUpdateDraw(app, update)
+			}
+		}()
+		return queued
+	}
+
+	go func() {
+		var (
+			queued  chan struct{} // Nil if no update is being queued.
+			pending *string       // The latest text received while queueing.
+		)
+		for {
+			if values == nil && queued == nil {
+				return // Closed and done.
+			}
+			select {
+			case <-stop:
+				return
+			case <-queued:
+				queued = nil
+				if pending != nil {
+					queued, pending = queue(*pending), nil
+				}
+			case text, ok := <-values:
+				if !ok {
+					values = nil
+					continue
+				}
+				if queued != nil {
+					pending = &text
+					continue
+				}
+				queued = queue(text)
+			}
+		}
+	}()
+	return nil
+}
+
+// UnbindWatch ends the watch of the form item at the given index (see
+// BindWatch), if any.
+func (f *FormScrollable) UnbindWatch(index int) *FormScrollable {
+	f.unwatchItem(f.items[index])
+	return f
+}
+
+// unwatchItem ends the watch of the given item, if any.
+func (f *FormScrollable) unwatchItem(item FormItem) {
+	if state, ok := f.itemStates[item]; ok && state.watchStop != nil {
+		close(state.watchStop)
+		state.watchStop = nil
+	}
+}
+
+// showWatched shows the given text received by the watch of the given item
+// with the given state.
+func (f *FormScrollable) showWatched(item FormItem, state *itemState, text string) {
+	if textView, ok := item.(*TextView); ok {
+		textView.SetText(text)
+	} else {
+		setItemText(item, text)
+	}
+	if state.hasDefault {
+		state.defaultValue, _ = f.snapshot(item)
+	}
+}
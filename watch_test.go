@@ -0,0 +1,57 @@
+package form
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	. "github.com/rivo/tview"
+)
+
+func TestBindWatchWithoutApplication(t *testing.T) {
+	f := NewFormScrollable().AddInputField("CPU", "", 10, nil, nil)
+	values := make(chan string)
+	if err := f.BindWatch(0, values); err == nil {
+		t.Fatal("expected an error without an application")
+	}
+	if err := f.BindWatch(1, values); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Fatalf("expected ErrIndexOutOfRange, got %v", err)
+	}
+}
+
+func TestBindWatch(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	app := NewApplication().SetScreen(screen)
+	f := NewFormScrollable().AddInputField("CPU", "", 10, nil, nil).SetApplication(app)
+	app.SetRoot(f, true)
+	done := make(chan error)
+	go func() { done <- app.Run() }()
+	defer func() {
+		app.Stop()
+		<-done
+	}()
+
+	// The form is bound in the event loop, which draws it meanwhile.
+	values := make(chan string)
+	var err error
+	app.QueueUpdate(func() { err = f.BindWatch(0, values) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	values <- "42%"
+	close(values)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var text string
+		app.QueueUpdate(func() { text = f.GetFormItem(0).(*InputField).GetText() })
+		if text == "42%" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %q, got %q", "42%", text)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,359 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// wizardPage is a page of a Wizard.
+type wizardPage struct {
+	title string
+	form  *FormScrollable
+
+	// The page's navigation buttons, back is nil on the first page.
+	back, next *tview.Button
+
+	// An optional function which checks the page before the user may leave
+	// it forwards.
+	gate func(form *FormScrollable) error
+}
+
+// Wizard is a multi-page form: a sequence of forms (pages) which the user fills
+// in one after the other. A header shows the titles of all pages with the
+// current one highlighted. Each page's form gets a "Back" button (except on
+// the first page) and a "Next" button ("Finish" on the last page). The user
+// can only move forward if the page's form validates (see
+// FormScrollable.Validate) and its gate, if any, accepts it (see
+// SetPageGate). Clicking the title of a previous page in the header goes back
+// to that page.
+type Wizard struct {
+	*tview.Box
+
+	// The pages and the index of the current one.
+	pages   []*wizardPage
+	current int
+
+	// The labels of the navigation buttons.
+	backLabel, nextLabel, finishLabel string
+
+	// The error returned by the current page's gate, shown below the header.
+	err error
+
+	// The delegate of the last call to Focus, used to focus a new page.
+	delegate func(p tview.Primitive)
+
+	// Colors.
+	currentColor, otherColor, errorColor tcell.Color
+
+	// Optional functions which are called when the user moved to another page
+	// and when the user finished the last page.
+	changed  func(index int)
+	finished func(values map[string]any)
+}
+
+// NewWizard returns a new wizard without pages.
+func NewWizard() *Wizard {
+	return &Wizard{
+		Box:          tview.NewBox(),
+		backLabel:    "Back",
+		nextLabel:    "Next",
+		finishLabel:  "Finish",
+		currentColor: tview.Styles.PrimaryTextColor,
+		otherColor:   tview.Styles.TertiaryTextColor,
+		errorColor:   tcell.ColorRed,
+	}
+}
+
+// AddPage adds a page with the given title and form to the end of the wizard
+// and adds the navigation buttons to the form.
+func (w *Wizard) AddPage(title string, form *FormScrollable) *Wizard {
+	page := &wizardPage{title: title, form: form}
+	if len(w.pages) > 0 {
+		w.pages[len(w.pages)-1].next.SetLabel(w.nextLabel)
+		page.back = tview.NewButton(w.backLabel).SetSelectedFunc(func() { w.Back() })
+		form.buttons = append(form.buttons, page.back)
+	}
+	page.next = tview.NewButton(w.finishLabel).SetSelectedFunc(func() { w.Next() })
+	form.buttons = append(form.buttons, page.next)
+	w.pages = append(w.pages, page)
+	return w
+}
+
+// SetButtonLabels sets the labels of the navigation buttons of all pages,
+// "Back", "Next", and "Finish" by default.
+func (w *Wizard) SetButtonLabels(back, next, finish string) *Wizard {
+	w.backLabel, w.nextLabel, w.finishLabel = back, next, finish
+	for index, page := range w.pages {
+		if page.back != nil {
+			page.back.SetLabel(back)
+		}
+		if index < len(w.pages)-1 {
+			page.next.SetLabel(next)
+		} else {
+			page.next.SetLabel(finish)
+		}
+	}
+	return w
+}
+
+// SetPageGate sets a function which checks the page at the given index (in
+// addition to its form's validators) before the user may leave it with
+// "Next" or "Finish". If it returns an error, the page stays and the error is
+// shown below the header.
+func (w *Wizard) SetPageGate(index int, gate func(form *FormScrollable) error) *Wizard {
+	w.pages[index].gate = gate
+	return w
+}
+
+// SetColors sets the colors of the current page's title, the other pages'
+// titles, and gate errors in the header.
+func (w *Wizard) SetColors(current, other, err tcell.Color) *Wizard {
+	w.currentColor, w.otherColor, w.errorColor = current, other, err
+	return w
+}
+
+// SetChangedFunc sets a handler which is called with the index of the new
+// current page when the user moved to another page.
+func (w *Wizard) SetChangedFunc(handler func(index int)) *Wizard {
+	w.changed = handler
+	return w
+}
+
+// SetFinishedFunc sets a handler which is called with the values of all pages
+// (see GetAllValues) when the user finished the last page.
+func (w *Wizard) SetFinishedFunc(handler func(values map[string]any)) *Wizard {
+	w.finished = handler
+	return w
+}
+
+// GetPageCount returns the number of pages.
+func (w *Wizard) GetPageCount() int {
+	return len(w.pages)
+}
+
+// GetPage returns the form of the page at the given index.
+func (w *Wizard) GetPage(index int) *FormScrollable {
+	return w.pages[index].form
+}
+
+// GetCurrentPage returns the index of the current page.
+func (w *Wizard) GetCurrentPage() int {
+	return w.current
+}
+
+// SetCurrentPage makes the page at the given index the current page without
+// checking the pages in between.
+func (w *Wizard) SetCurrentPage(index int) *Wizard {
+	if index < 0 || index >= len(w.pages) || index == w.current {
+		return w
+	}
+	hadFocus := w.HasFocus()
+	w.current, w.err = index, nil
+	if hadFocus && w.delegate != nil {
+		w.delegate(w.pages[index].form)
+	}
+	if w.changed != nil {
+		w.changed(index)
+	}
+	return w
+}
+
+// GetAllValues returns the values of the items of all pages, keyed by their
+// labels (see FormScrollable.GetFormValues). If items of several pages share
+// a label, the value of the last one is returned.
+func (w *Wizard) GetAllValues() map[string]any {
+	values := make(map[string]any)
+	for _, page := range w.pages {
+		for label, value := range page.form.GetFormValues() {
+			values[label] = value
+		}
+	}
+	return values
+}
+
+// Next moves on to the next page or, on the last page, calls the "finished"
+// handler, provided that the current page's form validates and its gate
+// accepts it. Otherwise, the focus moves to the first invalid item or the
+// gate's error is shown. Returns whether the current page was accepted.
+func (w *Wizard) Next() bool {
+	if len(w.pages) == 0 {
+		return false
+	}
+	page := w.pages[w.current]
+	if errs := page.form.Validate(); len(errs) > 0 {
+		var validationErr *ValidationError
+		if errors.As(errs[0], &validationErr) {
+			page.form.SetFocus(validationErr.Index)
+			if w.delegate != nil && w.HasFocus() {
+				w.delegate(page.form)
+			}
+		}
+		return false
+	}
+	if page.gate != nil {
+		if w.err = page.gate(page.form); w.err != nil {
+			return false
+		}
+	}
+	w.err = nil
+	if w.current < len(w.pages)-1 {
+		w.SetCurrentPage(w.current + 1)
+	} else if w.finished != nil {
+		w.finished(w.GetAllValues())
+	}
+	return true
+}
+
+// Back moves to the previous page. Returns false on the first page.
+func (w *Wizard) Back() bool {
+	if w.current == 0 {
+		return false
+	}
+	w.SetCurrentPage(w.current - 1)
+	return true
+}
+
+// headerHeight returns the number of rows above the current page's form.
+func (w *Wizard) headerHeight() int {
+	if w.err != nil {
+		return 2
+	}
+	return 1
+}
+
+// headerTitles returns the titles of the pages as shown in the header.
+func (w *Wizard) headerTitles() []string {
+	titles := make([]string, len(w.pages))
+	for index, page := range w.pages {
+		titles[index] = fmt.Sprintf("%d. %s", index+1, page.title)
+	}
+	return titles
+}
+
+// headerSeparator separates the page titles in the header.
+const headerSeparator = " › "
+
+// Draw draws this primitive onto the screen.
+func (w *Wizard) Draw(screen tcell.Screen) {
+	w.Box.DrawForSubclass(screen, w)
+	x, y, width, height := w.GetInnerRect()
+	if len(w.pages) == 0 || height <= 0 {
+		return
+	}
+
+	// Draw the header.
+	column := x
+	for index, title := range w.headerTitles() {
+		if index > 0 {
+			_, printed := tview.Print(screen, headerSeparator, column, y, x+width-column, tview.AlignLeft, w.otherColor)
+			column += printed
+		}
+		text, color := tview.Escape(title), w.otherColor
+		if index == w.current {
+			text, color = "[::b]"+text, w.currentColor
+		}
+		_, printed := tview.Print(screen, text, column, y, x+width-column, tview.AlignLeft, color)
+		column += printed
+	}
+	if w.err != nil {
+		tview.Print(screen, tview.Escape(w.err.Error()), x, y+1, width, tview.AlignLeft, w.errorColor)
+	}
+
+	// Draw the current page.
+	header := w.headerHeight()
+	form := w.pages[w.current].form
+	form.SetRect(x, y+header, width, max(height-header, 0))
+	form.Draw(screen)
+}
+
+// pageTitleAt returns the index of the page whose title in the header is at
+// the given screen position or -1 if there is none.
+func (w *Wizard) pageTitleAt(atX, atY int) int {
+	x, y, _, _ := w.GetInnerRect()
+	if atY != y {
+		return -1
+	}
+	column := x
+	for index, title := range w.headerTitles() {
+		if index > 0 {
+			column += tview.TaggedStringWidth(headerSeparator)
+		}
+		width := tview.TaggedStringWidth(tview.Escape(title))
+		if atX >= column && atX < column+width {
+			return index
+		}
+		column += width
+	}
+	return -1
+}
+
+// Focus is called when this primitive receives focus.
+func (w *Wizard) Focus(delegate func(p tview.Primitive)) {
+	w.delegate = delegate
+	if len(w.pages) == 0 {
+		w.Box.Focus(delegate)
+		return
+	}
+	delegate(w.pages[w.current].form)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (w *Wizard) HasFocus() bool {
+	if len(w.pages) > 0 && w.pages[w.current].form.HasFocus() {
+		return true
+	}
+	return w.Box.HasFocus()
+}
+
+// InputHandler returns the handler for this primitive.
+func (w *Wizard) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return w.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if len(w.pages) == 0 {
+			return
+		}
+		if handler := w.pages[w.current].form.InputHandler(); handler != nil {
+			handler(event, setFocus)
+		}
+	})
+}
+
+// PasteHandler returns the handler for this primitive.
+func (w *Wizard) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return w.WrapPasteHandler(func(pastedText string, setFocus func(p tview.Primitive)) {
+		if len(w.pages) == 0 {
+			return
+		}
+		if handler := w.pages[w.current].form.PasteHandler(); handler != nil {
+			handler(pastedText, setFocus)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (w *Wizard) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return w.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if len(w.pages) == 0 || !w.InRect(event.Position()) {
+			return false, nil
+		}
+
+		// Clicking the title of a previous page goes back to it.
+		if index := w.pageTitleAt(event.Position()); index >= 0 {
+			if action == tview.MouseLeftClick && index < w.current {
+				w.delegate = setFocus
+				w.SetCurrentPage(index)
+				setFocus(w.pages[index].form)
+			}
+			return true, nil
+		}
+
+		consumed, capture = w.pages[w.current].form.MouseHandler()(action, event, setFocus)
+		if !consumed && action == tview.MouseLeftDown {
+			setFocus(w)
+			consumed = true
+		}
+		return
+	})
+}